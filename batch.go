@@ -0,0 +1,38 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package graph
+
+// Batcher is implemented by graphs that can apply a group of changes
+// atomically. Batch calls fn with a transaction, a graph that stages the
+// changes: either all of them are committed when fn returns nil, or none of
+// them is if fn returns an error or the changes violate a constraint of the
+// graph.
+//
+// Constraints that depend on the whole graph, such as PreventCycles, are
+// checked once when the batch is committed rather than for every edge, so a
+// batch may pass through intermediate states that would be rejected on their
+// own. A violated constraint rolls the batch back and is returned, e.g.
+// ErrEdgeCreatesCycle.
+//
+// The transaction must only be used within fn, and fn must not use the graph
+// itself: depending on the implementation, other changes to the graph wait
+// until the batch is committed or rolled back.
+//
+// Example:
+//
+//	err := g.(graph.Batcher[string, string]).Batch(func(tx graph.Interface[string, string]) error {
+//		for _, v := range []string{"lib", "app"} {
+//			if err := tx.AddVertexWithOptions(v); err != nil {
+//				return err
+//			}
+//		}
+//		return tx.AddEdgeWithOptions("app", "lib")
+//	})
+type Batcher[K Ordered, T any] interface {
+	// Batch runs fn against a transaction and commits or rolls back its
+	// changes as a unit.
+	Batch(fn func(tx Interface[K, T]) error) error
+}
@@ -0,0 +1,520 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package csr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
+	"github.com/sixafter/graph/simple"
+)
+
+// Graph is an immutable snapshot of a graph in compressed sparse row (CSR)
+// form. Vertex keys are interned to dense indices in key order, and the edges
+// of each vertex are stored contiguously in flat arrays:
+//
+//	outOffsets[i]..outOffsets[i+1] indexes the edges leaving vertex i in
+//	outTargets (target indices), outWeights and outEdges.
+//
+// The incoming edges are stored the same way. Graph implements the read
+// methods of graph.Interface from these arrays and graph.Indexed, which lets
+// algorithms walk the graph without building adjacency maps. All methods that
+// modify the graph return graph.ErrImmutableGraph; Clone returns a mutable
+// copy.
+//
+// Vertex and edge properties are shared by every caller and must not be
+// modified.
+//
+// Example:
+//
+//	frozen, err := csr.Freeze(g)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	ranks, err := metrics.PageRank[string, string](frozen, 0.85, 100, 1e-6)
+type Graph[K graph.Ordered, T any] struct {
+	hash   graph.Hash[K, T]
+	traits *graph.Traits
+
+	// keys maps indices to vertex keys, in ascending order.
+	keys []K
+
+	// index maps vertex keys to indices.
+	index map[K]int
+
+	// values and vertexProps hold the vertex values and properties by index.
+	values      []T
+	vertexProps []graph.VertexProperties
+
+	// outOffsets has one entry per vertex plus one; the edges leaving vertex
+	// i are at positions outOffsets[i] to outOffsets[i+1] of the other out
+	// arrays, sorted by target and then by edge ID.
+	outOffsets []int
+	outTargets []int
+	outWeights []float64
+	outEdges   []graph.Edge[K]
+
+	// The in arrays hold the edges entering each vertex, sorted by source.
+	// In an undirected graph they are the out arrays.
+	inOffsets []int
+	inSources []int
+	inWeights []float64
+	inEdges   []graph.Edge[K]
+
+	// edges is the edge list, as returned by Edges of the frozen graph.
+	edges []graph.Edge[K]
+}
+
+// Freeze creates an immutable CSR snapshot of g. Later changes to g do not
+// affect the snapshot.
+func Freeze[K graph.Ordered, T any](g graph.Interface[K, T]) (*Graph[K, T], error) {
+	if g == nil {
+		return nil, graph.ErrNilInputGraph
+	}
+
+	vertices, err := g.Vertices()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetEdges, err)
+	}
+
+	f := &Graph[K, T]{
+		hash:   g.Hash(),
+		traits: g.Traits().Clone(),
+		index:  make(map[K]int, len(vertices)),
+	}
+
+	sort.Slice(vertices, func(i, j int) bool {
+		return vertices[i].ID() < vertices[j].ID()
+	})
+
+	f.keys = make([]K, len(vertices))
+	f.values = make([]T, len(vertices))
+	f.vertexProps = make([]graph.VertexProperties, len(vertices))
+	for i, vertex := range vertices {
+		f.keys[i] = vertex.ID()
+		f.values[i] = vertex.Value()
+		f.vertexProps[i] = vertex.Properties()
+		f.index[vertex.ID()] = i
+	}
+
+	// Every edge is stored leaving its source; in an undirected graph also
+	// leaving its target, reversed, unless it is a self-loop.
+	type arc struct {
+		from, to int
+		edge     graph.Edge[K]
+	}
+
+	arcs := make([]arc, 0, 2*len(edges))
+	f.edges = make([]graph.Edge[K], 0, len(edges))
+	for _, edge := range edges {
+		from, ok := f.index[edge.Source()]
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", graph.ErrVertexNotFound, edge.Source())
+		}
+		to, ok := f.index[edge.Target()]
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", graph.ErrVertexNotFound, edge.Target())
+		}
+
+		e := edge.Clone()
+		f.edges = append(f.edges, e)
+		arcs = append(arcs, arc{from: from, to: to, edge: e})
+
+		if !f.traits.IsDirected && from != to {
+			arcs = append(arcs, arc{from: to, to: from, edge: edgeutil.Reverse(e)})
+		}
+	}
+
+	sort.SliceStable(arcs, func(i, j int) bool {
+		if arcs[i].from != arcs[j].from {
+			return arcs[i].from < arcs[j].from
+		}
+		if arcs[i].to != arcs[j].to {
+			return arcs[i].to < arcs[j].to
+		}
+		return edgeutil.ID(arcs[i].edge) < edgeutil.ID(arcs[j].edge)
+	})
+
+	f.outOffsets = make([]int, len(vertices)+1)
+	f.outTargets = make([]int, len(arcs))
+	f.outWeights = make([]float64, len(arcs))
+	f.outEdges = make([]graph.Edge[K], len(arcs))
+	for i, a := range arcs {
+		f.outOffsets[a.from+1]++
+		f.outTargets[i] = a.to
+		f.outWeights[i] = edgeutil.Weight(a.edge)
+		f.outEdges[i] = a.edge
+	}
+	for i := range vertices {
+		f.outOffsets[i+1] += f.outOffsets[i]
+	}
+
+	if !f.traits.IsDirected {
+		f.inOffsets, f.inSources, f.inWeights, f.inEdges = f.outOffsets, f.outTargets, f.outWeights, f.outEdges
+		return f, nil
+	}
+
+	sort.SliceStable(arcs, func(i, j int) bool {
+		if arcs[i].to != arcs[j].to {
+			return arcs[i].to < arcs[j].to
+		}
+		if arcs[i].from != arcs[j].from {
+			return arcs[i].from < arcs[j].from
+		}
+		return edgeutil.ID(arcs[i].edge) < edgeutil.ID(arcs[j].edge)
+	})
+
+	f.inOffsets = make([]int, len(vertices)+1)
+	f.inSources = make([]int, len(arcs))
+	f.inWeights = make([]float64, len(arcs))
+	f.inEdges = make([]graph.Edge[K], len(arcs))
+	for i, a := range arcs {
+		f.inOffsets[a.to+1]++
+		f.inSources[i] = a.from
+		f.inWeights[i] = edgeutil.Weight(a.edge)
+		f.inEdges[i] = a.edge
+	}
+	for i := range vertices {
+		f.inOffsets[i+1] += f.inOffsets[i]
+	}
+
+	return f, nil
+}
+
+// VertexIndex returns the index of the vertex, and false if the vertex does
+// not exist.
+func (f *Graph[K, T]) VertexIndex(hash K) (int, bool) {
+	i, ok := f.index[hash]
+	return i, ok
+}
+
+// VertexKey returns the key of the vertex with index i.
+func (f *Graph[K, T]) VertexKey(i int) K {
+	return f.keys[i]
+}
+
+// Successors returns the indices of the targets of the edges leaving the
+// vertex with index i, in ascending order, and the weights of the edges.
+func (f *Graph[K, T]) Successors(i int) ([]int, []float64) {
+	start, end := f.outOffsets[i], f.outOffsets[i+1]
+	return f.outTargets[start:end:end], f.outWeights[start:end:end]
+}
+
+// Predecessors returns the indices of the sources of the edges entering the
+// vertex with index i, in ascending order, and the weights of the edges.
+func (f *Graph[K, T]) Predecessors(i int) ([]int, []float64) {
+	start, end := f.inOffsets[i], f.inOffsets[i+1]
+	return f.inSources[start:end:end], f.inWeights[start:end:end]
+}
+
+func (f *Graph[K, T]) AddVertex(_ graph.Vertex[K, T]) error {
+	return graph.ErrImmutableGraph
+}
+
+func (f *Graph[K, T]) AddVertexWithOptions(_ T, _ ...graph.VertexOption) error {
+	return graph.ErrImmutableGraph
+}
+
+func (f *Graph[K, T]) AddVerticesFrom(_ graph.Interface[K, T]) error {
+	return graph.ErrImmutableGraph
+}
+
+func (f *Graph[K, T]) Vertex(hash K) (graph.Vertex[K, T], error) {
+	i, ok := f.index[hash]
+	if !ok {
+		return nil, graph.ErrVertexNotFound
+	}
+
+	return simple.NewVertex(hash, f.values[i], f.vertexProps[i]), nil
+}
+
+func (f *Graph[K, T]) SetVertexWithOptions(_ T, _ ...graph.VertexOption) error {
+	return graph.ErrImmutableGraph
+}
+
+func (f *Graph[K, T]) RemoveVertex(_ K) error {
+	return graph.ErrImmutableGraph
+}
+
+func (f *Graph[K, T]) Vertices() ([]graph.Vertex[K, T], error) {
+	vertices := make([]graph.Vertex[K, T], len(f.keys))
+	for i, key := range f.keys {
+		vertices[i] = simple.NewVertex(key, f.values[i], f.vertexProps[i])
+	}
+
+	return vertices, nil
+}
+
+// StreamVerticesWithContext streams vertices from the graph in paginated batches. The cursor
+// holds the position in the stream as a decimal number; an empty state starts at the beginning.
+func (f *Graph[K, T]) StreamVerticesWithContext(ctx context.Context, cursor graph.Cursor, limit int, ch chan<- []graph.Vertex[K, T]) (graph.Cursor, error) {
+	defer close(ch) // Ensure the channel is closed when the function returns
+
+	position, err := cursorPosition(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	vertices, _ := f.Vertices()
+	for position < len(vertices) {
+		end := min(position+limit, len(vertices))
+
+		select {
+		case <-ctx.Done(): // Handle cancellation
+			return cursor, ctx.Err()
+		case ch <- vertices[position:end]: // Send batch to channel
+		}
+
+		position = end
+		if err = cursor.SetState([]byte(strconv.Itoa(position))); err != nil {
+			return cursor, err
+		}
+	}
+
+	return cursor, nil
+}
+
+func (f *Graph[K, T]) HasVertex(hash K) (bool, error) {
+	_, ok := f.index[hash]
+	return ok, nil
+}
+
+func (f *Graph[K, T]) AddEdge(_ graph.Edge[K]) error {
+	return graph.ErrImmutableGraph
+}
+
+func (f *Graph[K, T]) AddEdgeWithOptions(_, _ K, _ ...graph.EdgeOption) error {
+	return graph.ErrImmutableGraph
+}
+
+func (f *Graph[K, T]) AddEdgesFrom(_ graph.Interface[K, T]) error {
+	return graph.ErrImmutableGraph
+}
+
+// Edge retrieves the edge between two vertices. Of several parallel edges,
+// the one with the lowest ID is returned.
+func (f *Graph[K, T]) Edge(source, target K) (graph.Edge[T], error) {
+	edge, err := f.find(source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return simple.NewEdge(f.values[f.index[source]], f.values[f.index[target]], edge.Properties()), nil
+}
+
+func (f *Graph[K, T]) Edges() ([]graph.Edge[K], error) {
+	edges := make([]graph.Edge[K], len(f.edges))
+	copy(edges, f.edges)
+	return edges, nil
+}
+
+// StreamEdgesWithContext streams edges from the graph in paginated batches. The cursor
+// holds the position in the stream as a decimal number; an empty state starts at the beginning.
+func (f *Graph[K, T]) StreamEdgesWithContext(ctx context.Context, cursor graph.Cursor, limit int, ch chan<- graph.Edge[K]) (graph.Cursor, error) {
+	defer close(ch) // Ensure the channel is closed when the function returns
+
+	position, err := cursorPosition(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for position < len(f.edges) {
+		end := min(position+limit, len(f.edges))
+
+		for _, edge := range f.edges[position:end] {
+			select {
+			case <-ctx.Done(): // Handle cancellation
+				return cursor, ctx.Err()
+			case ch <- edge: // Send each edge to the channel
+			}
+		}
+
+		position = end
+		if err = cursor.SetState([]byte(strconv.Itoa(position))); err != nil {
+			return cursor, err
+		}
+	}
+
+	return cursor, nil
+}
+
+func (f *Graph[K, T]) SetEdgeWithOptions(_, _ K, _ ...graph.EdgeOption) error {
+	return graph.ErrImmutableGraph
+}
+
+func (f *Graph[K, T]) RemoveEdge(_, _ K) error {
+	return graph.ErrImmutableGraph
+}
+
+func (f *Graph[K, T]) HasEdge(source, target K) (bool, error) {
+	_, err := f.find(source, target)
+	return err == nil, nil
+}
+
+// AdjacencyMap builds an adjacency map from the CSR arrays. Of several
+// parallel edges, the one with the lowest weight is kept.
+func (f *Graph[K, T]) AdjacencyMap() (map[K]map[K]graph.Edge[K], error) {
+	return f.toMap(f.outOffsets, f.outTargets, f.outEdges), nil
+}
+
+// PredecessorMap builds a predecessor map from the CSR arrays. Of several
+// parallel edges, the one with the lowest weight is kept.
+func (f *Graph[K, T]) PredecessorMap() (map[K]map[K]graph.Edge[K], error) {
+	return f.toMap(f.inOffsets, f.inSources, f.inEdges), nil
+}
+
+// Clone returns a mutable copy of the graph, backed by the default in-memory
+// store of the simple package.
+func (f *Graph[K, T]) Clone() (graph.Interface[K, T], error) {
+	clone, err := simple.NewLike[K, T](f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToCloneGraph, err)
+	}
+
+	if err = clone.AddVerticesFrom(f); err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToAddVertices, err)
+	}
+
+	if err = clone.AddEdgesFrom(f); err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToAddEdges, err)
+	}
+
+	return clone, nil
+}
+
+func (f *Graph[K, T]) Order() (int, error) {
+	return len(f.keys), nil
+}
+
+func (f *Graph[K, T]) Size() (int, error) {
+	return len(f.edges), nil
+}
+
+func (f *Graph[K, T]) Hash() graph.Hash[K, T] {
+	return f.hash
+}
+
+func (f *Graph[K, T]) Traits() *graph.Traits {
+	return f.traits
+}
+
+func (f *Graph[K, T]) Neighbors(hash K) ([]graph.Vertex[K, T], error) {
+	i, ok := f.index[hash]
+	if !ok {
+		return nil, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
+	}
+
+	targets, _ := f.Successors(i)
+	neighbors := make([]graph.Vertex[K, T], 0, len(targets))
+	for j, target := range targets {
+		// Parallel edges lead to the same neighbor.
+		if j > 0 && targets[j-1] == target {
+			continue
+		}
+		neighbors = append(neighbors, simple.NewVertex(f.keys[target], f.values[target], f.vertexProps[target]))
+	}
+
+	return neighbors, nil
+}
+
+// Degree returns the number of edges incident to the vertex. In a directed
+// graph it is the sum of the in- and out-degree.
+func (f *Graph[K, T]) Degree(hash K) (int, error) {
+	out, err := f.OutDegree(hash)
+	if err != nil || !f.traits.IsDirected {
+		return out, err
+	}
+
+	in, err := f.InDegree(hash)
+	return in + out, err
+}
+
+func (f *Graph[K, T]) InDegree(hash K) (int, error) {
+	i, ok := f.index[hash]
+	if !ok {
+		return 0, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
+	}
+
+	return f.inOffsets[i+1] - f.inOffsets[i], nil
+}
+
+func (f *Graph[K, T]) OutDegree(hash K) (int, error) {
+	i, ok := f.index[hash]
+	if !ok {
+		return 0, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
+	}
+
+	return f.outOffsets[i+1] - f.outOffsets[i], nil
+}
+
+// find returns the first edge from source to target by binary search in the
+// row of source.
+func (f *Graph[K, T]) find(source, target K) (graph.Edge[K], error) {
+	i, ok := f.index[source]
+	if !ok {
+		return nil, graph.ErrVertexNotFound
+	}
+	j, ok := f.index[target]
+	if !ok {
+		return nil, graph.ErrVertexNotFound
+	}
+
+	start, end := f.outOffsets[i], f.outOffsets[i+1]
+	k := start + sort.SearchInts(f.outTargets[start:end], j)
+	if k == end || f.outTargets[k] != j {
+		return nil, graph.ErrEdgeNotFound
+	}
+
+	return f.outEdges[k], nil
+}
+
+// toMap builds an adjacency or predecessor map from CSR arrays.
+func (f *Graph[K, T]) toMap(offsets, neighbors []int, edges []graph.Edge[K]) map[K]map[K]graph.Edge[K] {
+	m := make(map[K]map[K]graph.Edge[K], len(f.keys))
+	for i, key := range f.keys {
+		row := make(map[K]graph.Edge[K], offsets[i+1]-offsets[i])
+		for k := offsets[i]; k < offsets[i+1]; k++ {
+			neighbor := f.keys[neighbors[k]]
+			if current, ok := row[neighbor]; !ok || edgeutil.Weight(edges[k]) < edgeutil.Weight(current) {
+				row[neighbor] = edges[k]
+			}
+		}
+		m[key] = row
+	}
+
+	return m
+}
+
+// cursorPosition validates the stream arguments and returns the position held
+// by the cursor.
+func cursorPosition(cursor graph.Cursor, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be greater than zero")
+	}
+
+	state := cursor.State()
+	if len(state) == 0 {
+		return 0, nil
+	}
+
+	position, err := strconv.Atoi(string(state))
+	if err != nil || position < 0 {
+		return 0, fmt.Errorf("invalid cursor state %q", state)
+	}
+
+	return position, nil
+}
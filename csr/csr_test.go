@@ -0,0 +1,225 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package csr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/metrics"
+	"github.com/sixafter/graph/simple"
+	"github.com/sixafter/graph/traverse"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGraph(t *testing.T, options ...func(*graph.Traits)) graph.Interface[string, string] {
+	is := assert.New(t)
+
+	g, err := simple.New(graph.StringHash, options...)
+	is.NoError(err)
+
+	for _, v := range []string{"A", "B", "C", "D", "E"} {
+		is.NoError(g.AddVertexWithOptions(v, simple.VertexWeight(1)))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "D", simple.EdgeWeight(3)))
+	is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(4)))
+	is.NoError(g.AddEdgeWithOptions("D", "A", simple.EdgeWeight(5)))
+
+	return g
+}
+
+func TestFreeze_Directed(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g := newGraph(t, graph.Directed(), graph.Weighted())
+	f, err := Freeze(g)
+	is.NoError(err)
+
+	order, _ := f.Order()
+	is.Equal(5, order)
+	size, _ := f.Size()
+	is.Equal(5, size)
+
+	i, ok := f.VertexIndex("D")
+	is.True(ok)
+	is.Equal("D", f.VertexKey(i))
+	targets, weights := f.Successors(i)
+	is.Equal([]int{0}, targets)
+	is.Equal([]float64{5}, weights)
+	sources, _ := f.Predecessors(i)
+	is.Equal([]int{1, 2}, sources)
+
+	degree, _ := f.Degree("D")
+	is.Equal(3, degree)
+	in, _ := f.InDegree("A")
+	is.Equal(1, in)
+	_, err = f.OutDegree("Z")
+	is.ErrorIs(err, graph.ErrVertexNotFound)
+
+	e, err := f.Edge("C", "D")
+	is.NoError(err)
+	is.Equal("C", e.Source())
+	is.Equal(float64(4), e.Properties().Weight())
+	_, err = f.Edge("D", "C")
+	is.ErrorIs(err, graph.ErrEdgeNotFound)
+
+	ok, _ = f.HasEdge("B", "D")
+	is.True(ok)
+	ok, _ = f.HasVertex("E")
+	is.True(ok)
+
+	expected, _ := g.AdjacencyMap()
+	actual, _ := f.AdjacencyMap()
+	is.Equal(len(expected), len(actual))
+	for source, targets := range expected {
+		is.Len(actual[source], len(targets))
+		for target := range targets {
+			is.Equal(source, actual[source][target].Source())
+			is.Equal(target, actual[source][target].Target())
+		}
+	}
+
+	predecessors, _ := f.PredecessorMap()
+	is.Len(predecessors["D"], 2)
+	is.Equal("B", predecessors["D"]["B"].Source())
+
+	// The snapshot does not follow later changes.
+	is.NoError(g.RemoveEdge("D", "A"))
+	ok, _ = f.HasEdge("D", "A")
+	is.True(ok)
+}
+
+func TestFreeze_Undirected(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g := newGraph(t, graph.MultiGraph())
+	is.NoError(g.AddEdgeWithOptions("B", "A", simple.EdgeWeight(0.5)))
+	is.NoError(g.AddEdgeWithOptions("E", "E"))
+
+	f, err := Freeze(g)
+	is.NoError(err)
+
+	size, _ := f.Size()
+	is.Equal(7, size)
+
+	a, _ := f.VertexIndex("A")
+	targets, weights := f.Successors(a)
+	is.Equal([]int{1, 1, 2, 3}, targets)
+	is.Equal([]float64{1, 0.5, 2, 5}, weights)
+
+	degree, _ := f.Degree("A")
+	is.Equal(4, degree)
+	degree, _ = f.Degree("E")
+	is.Equal(1, degree)
+
+	e, err := f.Edge("D", "B")
+	is.NoError(err)
+	is.Equal(float64(3), e.Properties().Weight())
+
+	adjacency, _ := f.AdjacencyMap()
+	is.Equal(float64(0.5), adjacency["A"]["B"].Properties().Weight())
+	is.Equal("B", adjacency["B"]["A"].Source())
+
+	neighbors, _ := f.Neighbors("A")
+	keys := make([]string, 0, len(neighbors))
+	for _, n := range neighbors {
+		keys = append(keys, n.ID())
+	}
+	is.Equal([]string{"B", "C", "D"}, keys)
+}
+
+func TestFreeze_Immutable(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	f, err := Freeze(newGraph(t, graph.Directed()))
+	is.NoError(err)
+
+	is.ErrorIs(f.AddVertexWithOptions("F"), graph.ErrImmutableGraph)
+	is.ErrorIs(f.AddEdgeWithOptions("E", "A"), graph.ErrImmutableGraph)
+	is.ErrorIs(f.SetEdgeWithOptions("A", "B", simple.EdgeWeight(9)), graph.ErrImmutableGraph)
+	is.ErrorIs(f.RemoveEdge("A", "B"), graph.ErrImmutableGraph)
+	is.ErrorIs(f.RemoveVertex("E"), graph.ErrImmutableGraph)
+
+	// Clones can be modified.
+	clone, err := f.Clone()
+	is.NoError(err)
+	is.NoError(clone.AddEdgeWithOptions("E", "A"))
+	size, _ := clone.Size()
+	is.Equal(6, size)
+	size, _ = f.Size()
+	is.Equal(5, size)
+}
+
+func TestFreeze_Stream(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	f, err := Freeze(newGraph(t, graph.Directed()))
+	is.NoError(err)
+
+	ch := make(chan []graph.Vertex[string, string])
+	cursor := simple.EmptyCursor()
+	go func() {
+		_, err := f.StreamVerticesWithContext(context.Background(), cursor, 2, ch)
+		is.NoError(err)
+	}()
+
+	var keys []string
+	for batch := range ch {
+		is.LessOrEqual(len(batch), 2)
+		for _, v := range batch {
+			keys = append(keys, v.ID())
+		}
+	}
+	is.Equal([]string{"A", "B", "C", "D", "E"}, keys)
+	is.Equal("5", string(cursor.State()))
+
+	edges := make(chan graph.Edge[string])
+	go func() {
+		_, err := f.StreamEdgesWithContext(context.Background(), simple.EmptyCursor(), 3, edges)
+		is.NoError(err)
+	}()
+
+	count := 0
+	for range edges {
+		count++
+	}
+	is.Equal(5, count)
+}
+
+func TestFreeze_Algorithms(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g := newGraph(t, graph.Directed(), graph.Weighted())
+	f, err := Freeze(g)
+	is.NoError(err)
+
+	expected, err := metrics.PageRank(g, 0.85, 100, 1e-9)
+	is.NoError(err)
+	actual, err := metrics.PageRank[string, string](f, 0.85, 100, 1e-9)
+	is.NoError(err)
+	for key, rank := range expected {
+		is.InDelta(rank, actual[key], 1e-9)
+	}
+
+	var visitedG, visitedF []string
+	is.NoError(traverse.BFS(g, "A", func(k string) bool {
+		visitedG = append(visitedG, k)
+		return false
+	}))
+	is.NoError(traverse.BFS[string, string](f, "A", func(k string) bool {
+		visitedF = append(visitedF, k)
+		return false
+	}))
+	is.Equal(visitedG, visitedF)
+}
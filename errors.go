@@ -22,6 +22,10 @@ var (
 	// ErrAddEdge is used as the base error when failing to add an edge.
 	ErrAddEdge = errors.New("failed to add edge")
 
+	// ErrChangeVetoed is returned when a hook of an observable graph rejects
+	// a change.
+	ErrChangeVetoed = errors.New("change vetoed by hook")
+
 	// ErrCloneGraph is returned when cloning a graph fails.
 	ErrCloneGraph = errors.New("failed to clone the graph")
 
@@ -35,6 +39,10 @@ var (
 	// where cycles are not allowed.
 	ErrEdgeCreatesCycle = errors.New("edge would create a cycle")
 
+	// ErrEdgeDisconnectsTree is returned when removing an edge would split a
+	// rooted tree.
+	ErrEdgeDisconnectsTree = errors.New("removing the edge would disconnect the tree")
+
 	// ErrEdgeNotFound is returned when an edge is not found in the graph.
 	ErrEdgeNotFound = errors.New("edge not found")
 
@@ -83,6 +91,9 @@ var (
 	// ErrGetVertex is returned when a vertex cannot be retrieved.
 	ErrGetVertex = errors.New("failed to get vertex")
 
+	// ErrImmutableGraph is returned when a frozen, read-only graph is modified.
+	ErrImmutableGraph = errors.New("graph is immutable")
+
 	// ErrPredecessorMapFailed is returned when there is an error obtaining the predecessor
 	// map of a graph. The predecessor map is used for operations like cycle detection.
 	ErrPredecessorMapFailed = errors.New("could not get predecessor map")
@@ -96,10 +107,29 @@ var (
 	// valid for DirectedGraph graphs.
 	ErrSCCDetectionNotDirected = errors.New("strongly connected components (SCCs) can only be detected in directed graph graphs")
 
+	// ErrSnapshotReleased is returned when a snapshot is used after it has
+	// been released.
+	ErrSnapshotReleased = errors.New("snapshot has been released")
+
+	// ErrSnapshotsNotSupported is returned when a snapshot is requested of a
+	// graph whose store cannot take snapshots.
+	ErrSnapshotsNotSupported = errors.New("store does not support snapshots")
+
+	// ErrStoreClosed is returned when a store that has been closed is modified.
+	ErrStoreClosed = errors.New("store is closed")
+
+	// ErrStoreCorrupted is returned when the persisted data of a store cannot be
+	// read back, for example a damaged snapshot file.
+	ErrStoreCorrupted = errors.New("store is corrupted")
+
 	// ErrTargetNotReachable is returned when the target vertex is not reachable
 	// from the source vertex in a graph operation such as ShortestPath.
 	ErrTargetNotReachable = errors.New("target vertex not reachable from source")
 
+	// ErrTreeHasNoRoot is returned when a rooted tree without vertices is asked
+	// for its root.
+	ErrTreeHasNoRoot = errors.New("tree has no root")
+
 	// ErrUndirectedGraph indicates that the operation cannot be performed on an Undirected graph.
 	ErrUndirectedGraph = errors.New("operation cannot be performed on Undirected graph")
 
@@ -109,9 +139,21 @@ var (
 	// ErrVertexHasEdges is returned when trying to remove a vertex that still has edges.
 	ErrVertexHasEdges = errors.New("vertex has edges")
 
+	// ErrVertexHasParent is returned when an edge would give a vertex of a
+	// rooted tree a second parent.
+	ErrVertexHasParent = errors.New("vertex already has a parent")
+
+	// ErrVertexIsRoot is returned when the parent of the root of a tree is
+	// requested.
+	ErrVertexIsRoot = errors.New("vertex is the root of the tree")
+
 	// ErrVertexNotFound is returned when a vertex is not found in the graph.
 	ErrVertexNotFound = errors.New("vertex not found")
 
+	// ErrVertexNotInTree is returned when a vertex of a rooted tree is not
+	// connected to the root, for example before its first edge is added.
+	ErrVertexNotInTree = errors.New("vertex is not connected to the root of the tree")
+
 	// ErrGraphTypeMismatch is returned when attempting to perform set operations
 	// on graphs with differing types or traits.
 	ErrGraphTypeMismatch = errors.New("graph type mismatch")
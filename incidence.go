@@ -0,0 +1,47 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package graph
+
+// Incidence is implemented by graphs that index the edges of each vertex, so
+// that the neighbors and edges of one vertex are found without building the
+// AdjacencyMap or PredecessorMap of the whole graph.
+//
+// Neighbors returns the successors of a vertex; InNeighbors is its
+// counterpart for predecessors. In an undirected graph, every edge both
+// leaves and enters each of its vertices, so the in and out queries return
+// the same vertices and edges.
+//
+// Example:
+//
+//	if inc, ok := g.(graph.Incidence[string, string]); ok {
+//		edges, _ := inc.InEdges("B")
+//		for _, e := range edges {
+//			fmt.Printf("%v -> B (%v)\n", e.Source(), e.Properties().Weight())
+//		}
+//	}
+type Incidence[K Ordered, T any] interface {
+	// OutNeighbors returns the targets of the edges leaving the vertex in
+	// ascending order of their keys, once each. It returns ErrVertexNotFound
+	// if the vertex does not exist.
+	OutNeighbors(hash K) ([]Vertex[K, T], error)
+
+	// InNeighbors returns the sources of the edges entering the vertex in
+	// ascending order of their keys, once each. It returns ErrVertexNotFound
+	// if the vertex does not exist.
+	InNeighbors(hash K) ([]Vertex[K, T], error)
+
+	// OutEdges returns the edges leaving the vertex with their properties,
+	// including parallel edges, ordered by target. The edges of an undirected
+	// graph have the vertex as their source. It returns ErrVertexNotFound if
+	// the vertex does not exist.
+	OutEdges(hash K) ([]Edge[K], error)
+
+	// InEdges returns the edges entering the vertex with their properties,
+	// including parallel edges, ordered by source. The edges of an undirected
+	// graph have the vertex as their target. It returns ErrVertexNotFound if
+	// the vertex does not exist.
+	InEdges(hash K) ([]Edge[K], error)
+}
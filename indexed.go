@@ -0,0 +1,42 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package graph
+
+// Indexed is implemented by graphs that number their vertices with dense
+// integer indices from 0 to Order()-1, such as the frozen graphs of the csr
+// package. Algorithms check for it to walk the graph through slices instead
+// of building an adjacency map.
+//
+// In an undirected graph every edge is listed among the successors and the
+// predecessors of both of its vertices.
+//
+// Example:
+//
+//	if ix, ok := g.(graph.Indexed[string]); ok {
+//		i, _ := ix.VertexIndex("A")
+//		targets, weights := ix.Successors(i)
+//		for j, target := range targets {
+//			fmt.Printf("A -> %v (%v)\n", ix.VertexKey(target), weights[j])
+//		}
+//	}
+type Indexed[K Ordered] interface {
+	// VertexIndex returns the index of the vertex, and false if the vertex
+	// does not exist.
+	VertexIndex(hash K) (int, bool)
+
+	// VertexKey returns the key of the vertex with index i.
+	VertexKey(i int) K
+
+	// Successors returns the indices of the targets of the edges leaving the
+	// vertex with index i, in ascending order, and the weights of the edges.
+	// Parallel edges are listed once each. The slices must not be modified.
+	Successors(i int) (targets []int, weights []float64)
+
+	// Predecessors returns the indices of the sources of the edges entering
+	// the vertex with index i, in ascending order, and the weights of the
+	// edges. The slices must not be modified.
+	Predecessors(i int) (sources []int, weights []float64)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package edgeutil holds the helpers for reading and reorienting edges that
+// the graph implementations of the module share.
+package edgeutil
+
+import (
+	"cmp"
+
+	"github.com/sixafter/graph"
+)
+
+// ID returns the ID of edge, or zero if it has none.
+func ID[K any](edge graph.Edge[K]) graph.EdgeID {
+	if e, ok := edge.(graph.IdentifiedEdge[K]); ok {
+		return e.ID()
+	}
+	return 0
+}
+
+// Weight returns the weight of edge, or zero if it has no properties.
+func Weight[K any](edge graph.Edge[K]) float64 {
+	if p := edge.Properties(); p != nil {
+		return p.Weight()
+	}
+	return 0
+}
+
+// Compare orders edges by source, then by target, then by ID.
+func Compare[K graph.Ordered](a, b graph.Edge[K]) int {
+	return cmp.Or(
+		cmp.Compare(a.Source(), b.Source()),
+		cmp.Compare(a.Target(), b.Target()),
+		cmp.Compare(ID(a), ID(b)),
+	)
+}
+
+// reversedEdge is an edge seen in the opposite direction. It keeps the ID and
+// the properties of the edge.
+type reversedEdge[K any] struct {
+	graph.Edge[K]
+}
+
+// Reverse returns edge in the opposite direction.
+func Reverse[K any](edge graph.Edge[K]) graph.Edge[K] {
+	return &reversedEdge[K]{Edge: edge}
+}
+
+func (e *reversedEdge[K]) Source() K {
+	return e.Edge.Target()
+}
+
+func (e *reversedEdge[K]) Target() K {
+	return e.Edge.Source()
+}
+
+func (e *reversedEdge[K]) Clone() graph.Edge[K] {
+	return Reverse(e.Edge.Clone())
+}
+
+// ID returns the ID of the edge in its original direction.
+func (e *reversedEdge[K]) ID() graph.EdgeID {
+	return ID(e.Edge)
+}
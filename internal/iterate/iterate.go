@@ -0,0 +1,164 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package iterate gives the algorithms of the module range-over-func access to
+// any graph. Graphs implementing graph.Iterable are read through their
+// iterators; other graphs are read once into maps that the returned
+// sequences walk.
+package iterate
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/sixafter/graph"
+)
+
+// Vertices returns the keys of the vertices of g in ascending order.
+func Vertices[K graph.Ordered, T any](g graph.Interface[K, T]) (iter.Seq[K], error) {
+	if it, ok := g.(graph.Iterable[K, T]); ok {
+		return func(yield func(K) bool) {
+			for key := range it.AllVertices() {
+				if !yield(key) {
+					return
+				}
+			}
+		}, nil
+	}
+
+	vertices, err := g.Vertices()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	keys := make([]K, len(vertices))
+	for i, vertex := range vertices {
+		keys[i] = vertex.ID()
+	}
+	slices.Sort(keys)
+
+	return slices.Values(keys), nil
+}
+
+// Edges returns the edges of g. An edge of an undirected graph is returned
+// once.
+func Edges[K graph.Ordered, T any](g graph.Interface[K, T]) (iter.Seq[graph.Edge[K]], error) {
+	if it, ok := g.(graph.Iterable[K, T]); ok {
+		return it.AllEdges(), nil
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	return slices.Values(edges), nil
+}
+
+// Successors returns a function that lists the successors of a vertex of g in
+// ascending order.
+func Successors[K graph.Ordered, T any](g graph.Interface[K, T]) (func(K) iter.Seq[K], error) {
+	if it, ok := g.(graph.Iterable[K, T]); ok {
+		return it.Successors, nil
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrGetAdjacencyMap, err)
+	}
+
+	return sortedKeys(adjacencyMap), nil
+}
+
+// Predecessors returns a function that lists the predecessors of a vertex of
+// g in ascending order.
+func Predecessors[K graph.Ordered, T any](g graph.Interface[K, T]) (func(K) iter.Seq[K], error) {
+	if it, ok := g.(graph.Iterable[K, T]); ok {
+		return it.Predecessors, nil
+	}
+
+	predecessorMap, err := g.PredecessorMap()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetPredecessorMap, err)
+	}
+
+	return sortedKeys(predecessorMap), nil
+}
+
+// OutEdges returns a function that lists the edges leaving a vertex of g,
+// each with the key of the vertex it leads to. In an undirected graph, these
+// are all the edges of the vertex. Parallel edges are listed once each.
+func OutEdges[K graph.Ordered, T any](g graph.Interface[K, T]) (func(K) iter.Seq2[K, graph.Edge[K]], error) {
+	if it, ok := g.(graph.Iterable[K, T]); ok {
+		return func(hash K) iter.Seq2[K, graph.Edge[K]] {
+			return func(yield func(K, graph.Edge[K]) bool) {
+				for edge := range it.IncidentEdges(hash) {
+					if edge.Source() == hash && !yield(edge.Target(), edge) {
+						return
+					}
+				}
+			}
+		}, nil
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	type neighbor struct {
+		key  K
+		edge graph.Edge[K]
+	}
+
+	out := make(map[K][]neighbor)
+	for _, edge := range edges {
+		out[edge.Source()] = append(out[edge.Source()], neighbor{key: edge.Target(), edge: edge})
+		if !g.Traits().IsDirected && edge.Source() != edge.Target() {
+			out[edge.Target()] = append(out[edge.Target()], neighbor{key: edge.Source(), edge: edge})
+		}
+	}
+
+	return func(hash K) iter.Seq2[K, graph.Edge[K]] {
+		return func(yield func(K, graph.Edge[K]) bool) {
+			for _, n := range out[hash] {
+				if !yield(n.key, n.edge) {
+					return
+				}
+			}
+		}
+	}, nil
+}
+
+// Adjacent returns a function that reports whether g has an edge from source
+// to target.
+func Adjacent[K graph.Ordered, T any](g graph.Interface[K, T]) (func(source, target K) bool, error) {
+	if _, ok := g.(graph.Iterable[K, T]); ok {
+		return func(source, target K) bool {
+			_, err := g.Edge(source, target)
+			return err == nil
+		}, nil
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrGetAdjacencyMap, err)
+	}
+
+	return func(source, target K) bool {
+		_, ok := adjacencyMap[source][target]
+		return ok
+	}, nil
+}
+
+// sortedKeys returns a function that lists the keys of the inner map of m
+// for a vertex in ascending order.
+func sortedKeys[K graph.Ordered](m map[K]map[K]graph.Edge[K]) func(K) iter.Seq[K] {
+	return func(hash K) iter.Seq[K] {
+		return slices.Values(slices.Sorted(maps.Keys(m[hash])))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/iterate"
 	"github.com/sixafter/graph/internal/queue"
 )
 
@@ -44,7 +45,7 @@ func WouldCreateCycle[K graph.Ordered, T any](g graph.Interface[K, T], source, t
 		return true, graph.ErrSameSourceAndTarget
 	}
 
-	predecessors, err := g.PredecessorMap()
+	predecessors, err := iterate.Predecessors(g)
 	if err != nil {
 		return false, fmt.Errorf("%w: %v", graph.ErrPredecessorMapFailed, err)
 	}
@@ -64,7 +65,7 @@ func WouldCreateCycle[K graph.Ordered, T any](g graph.Interface[K, T], source, t
 
 			visited[current] = true
 
-			for adjacency := range predecessors[current] {
+			for adjacency := range predecessors(current) {
 				s.Push(adjacency)
 			}
 		}
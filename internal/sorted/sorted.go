@@ -0,0 +1,376 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package sorted provides Map, an ordered map held in a B-tree whose copies
+// share their nodes until they are changed. The in-memory store uses it to
+// list and page its vertices and edges in order and to fork in constant time.
+package sorted
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// degree is the minimum number of children of an inner node other than the
+// root. Every node other than the root holds between minItems and maxItems
+// items.
+const (
+	degree   = 16
+	minItems = degree - 1
+	maxItems = 2*degree - 1
+)
+
+// Owner marks the nodes that may be changed in place. A change made with an
+// owner copies every node it touches that belongs to another owner, so
+// copies of a Map stay apart as long as each is changed with its own Owner.
+type Owner struct {
+	// The field gives owners a size, so that each new owner has a distinct
+	// address.
+	_ byte
+}
+
+// Map is an ordered map from keys to values. The zero value is an empty map.
+//
+// Copying a Map shares its nodes with the copy. Before either is changed,
+// the holder of the copy that is changed must switch to a new Owner, as
+// changes with the owner of the shared nodes would be seen by both.
+type Map[K cmp.Ordered, V any] struct {
+	root   *node[K, V]
+	length int
+}
+
+// node is a node of the B-tree. A leaf has no children; an inner node has
+// one more child than it has items.
+type node[K cmp.Ordered, V any] struct {
+	items    []item[K, V]
+	children []*node[K, V]
+	owner    *Owner
+}
+
+// item is a key and its value.
+type item[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+}
+
+// Len returns the number of keys in the map.
+func (m *Map[K, V]) Len() int {
+	return m.length
+}
+
+// Get returns the value of key and whether the map holds it.
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	for n := m.root; n != nil; {
+		i, found := n.search(key)
+		if found {
+			return n.items[i].value, true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Set stores value under key, replacing any value it had. Nodes not
+// belonging to owner are copied first.
+func (m *Map[K, V]) Set(owner *Owner, key K, value V) {
+	if m.root == nil {
+		m.root = &node[K, V]{items: []item[K, V]{{key: key, value: value}}, owner: owner}
+		m.length = 1
+		return
+	}
+
+	m.root = m.root.mutable(owner)
+	if len(m.root.items) == maxItems {
+		middle, right := m.root.split(owner, maxItems/2)
+		m.root = &node[K, V]{
+			items:    []item[K, V]{middle},
+			children: []*node[K, V]{m.root, right},
+			owner:    owner,
+		}
+	}
+
+	if m.root.insert(owner, key, value) {
+		m.length++
+	}
+}
+
+// Delete removes key and returns its value and whether the map held it.
+// Nodes not belonging to owner are copied first.
+func (m *Map[K, V]) Delete(owner *Owner, key K) (V, bool) {
+	var zero V
+	if m.root == nil {
+		return zero, false
+	}
+	if _, ok := m.Get(key); !ok {
+		return zero, false
+	}
+
+	m.root = m.root.mutable(owner)
+	removed := m.root.remove(owner, key)
+	m.length--
+
+	// A root left without items gives way to its only child.
+	if len(m.root.items) == 0 {
+		if m.root.leaf() {
+			m.root = nil
+		} else {
+			m.root = m.root.children[0]
+		}
+	}
+
+	return removed.value, true
+}
+
+// All returns the keys and values of the map in ascending order of the keys.
+// The map must not be changed while the sequence runs.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.ascend(nil, yield)
+		}
+	}
+}
+
+// After returns the keys greater than key and their values, in ascending
+// order of the keys. The map must not be changed while the sequence runs.
+func (m *Map[K, V]) After(key K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.ascend(&key, yield)
+		}
+	}
+}
+
+// Keys returns the keys of the map in ascending order.
+func (m *Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.length)
+	for key := range m.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// leaf reports whether n has no children.
+func (n *node[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// search returns the position of key in the items of n, or the position of
+// the child that would hold it, and whether it was found.
+func (n *node[K, V]) search(key K) (int, bool) {
+	return slices.BinarySearchFunc(n.items, key, func(it item[K, V], key K) int {
+		return cmp.Compare(it.key, key)
+	})
+}
+
+// mutable returns n if it belongs to owner, or a copy of it that does.
+func (n *node[K, V]) mutable(owner *Owner) *node[K, V] {
+	if n.owner == owner && owner != nil {
+		return n
+	}
+
+	copied := &node[K, V]{
+		items: append(make([]item[K, V], 0, maxItems), n.items...),
+		owner: owner,
+	}
+	if !n.leaf() {
+		copied.children = append(make([]*node[K, V], 0, maxItems+1), n.children...)
+	}
+	return copied
+}
+
+// mutableChild replaces the child of n at i with a copy belonging to owner,
+// if it is not one already, and returns it.
+func (n *node[K, V]) mutableChild(owner *Owner, i int) *node[K, V] {
+	child := n.children[i].mutable(owner)
+	n.children[i] = child
+	return child
+}
+
+// split moves the items of n after position i, and their children, to a new
+// node. It returns the item at i, which is removed from n, and the new node.
+func (n *node[K, V]) split(owner *Owner, i int) (item[K, V], *node[K, V]) {
+	middle := n.items[i]
+
+	right := &node[K, V]{
+		items: append(make([]item[K, V], 0, maxItems), n.items[i+1:]...),
+		owner: owner,
+	}
+	clear(n.items[i:])
+	n.items = n.items[:i]
+
+	if !n.leaf() {
+		right.children = append(make([]*node[K, V], 0, maxItems+1), n.children[i+1:]...)
+		clear(n.children[i+1:])
+		n.children = n.children[:i+1]
+	}
+
+	return middle, right
+}
+
+// insert stores value under key in the subtree of n, which must belong to
+// owner and not be full. It reports whether the key is new.
+func (n *node[K, V]) insert(owner *Owner, key K, value V) bool {
+	i, found := n.search(key)
+	if found {
+		n.items[i].value = value
+		return false
+	}
+
+	if n.leaf() {
+		n.items = slices.Insert(n.items, i, item[K, V]{key: key, value: value})
+		return true
+	}
+
+	// A full child is split first, so that there is room for an item that
+	// moves up from below.
+	if len(n.children[i].items) == maxItems {
+		middle, right := n.mutableChild(owner, i).split(owner, maxItems/2)
+		n.items = slices.Insert(n.items, i, middle)
+		n.children = slices.Insert(n.children, i+1, right)
+
+		switch c := cmp.Compare(key, middle.key); {
+		case c == 0:
+			n.items[i].value = value
+			return false
+		case c > 0:
+			i++
+		}
+	}
+
+	return n.mutableChild(owner, i).insert(owner, key, value)
+}
+
+// remove removes key, which must be held, from the subtree of n, which must
+// belong to owner, and returns its item.
+func (n *node[K, V]) remove(owner *Owner, key K) item[K, V] {
+	i, found := n.search(key)
+
+	if n.leaf() {
+		removed := n.items[i]
+		n.items = slices.Delete(n.items, i, i+1)
+		return removed
+	}
+
+	// The child descended into must be able to give up an item.
+	if len(n.children[i].items) <= minItems {
+		n.growChild(owner, i)
+		return n.remove(owner, key)
+	}
+
+	child := n.mutableChild(owner, i)
+	if found {
+		// The item is replaced by the largest item before it.
+		removed := n.items[i]
+		n.items[i] = child.removeMax(owner)
+		return removed
+	}
+
+	return child.remove(owner, key)
+}
+
+// removeMax removes and returns the largest item of the subtree of n, which
+// must belong to owner.
+func (n *node[K, V]) removeMax(owner *Owner) item[K, V] {
+	if n.leaf() {
+		last := len(n.items) - 1
+		removed := n.items[last]
+		n.items[last] = item[K, V]{}
+		n.items = n.items[:last]
+		return removed
+	}
+
+	i := len(n.items)
+	if len(n.children[i].items) <= minItems {
+		n.growChild(owner, i)
+		return n.removeMax(owner)
+	}
+
+	return n.mutableChild(owner, i).removeMax(owner)
+}
+
+// growChild gives the child of n at i, which holds minItems items, another
+// item: it takes one from a sibling through n, or is merged with a sibling
+// and the item of n between them.
+func (n *node[K, V]) growChild(owner *Owner, i int) {
+	switch {
+	case i > 0 && len(n.children[i-1].items) > minItems:
+		child := n.mutableChild(owner, i)
+		left := n.mutableChild(owner, i-1)
+
+		last := len(left.items) - 1
+		child.items = slices.Insert(child.items, 0, n.items[i-1])
+		n.items[i-1] = left.items[last]
+		left.items[last] = item[K, V]{}
+		left.items = left.items[:last]
+
+		if !left.leaf() {
+			last = len(left.children) - 1
+			child.children = slices.Insert(child.children, 0, left.children[last])
+			left.children[last] = nil
+			left.children = left.children[:last]
+		}
+
+	case i < len(n.items) && len(n.children[i+1].items) > minItems:
+		child := n.mutableChild(owner, i)
+		right := n.mutableChild(owner, i+1)
+
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.items[0]
+		right.items = slices.Delete(right.items, 0, 1)
+
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = slices.Delete(right.children, 0, 1)
+		}
+
+	default:
+		// Merge the child with its right sibling, or with its left one if
+		// it is the last child. The sibling is only read.
+		if i == len(n.items) {
+			i--
+		}
+		child := n.mutableChild(owner, i)
+		sibling := n.children[i+1]
+
+		child.items = append(child.items, n.items[i])
+		child.items = append(child.items, sibling.items...)
+		child.children = append(child.children, sibling.children...)
+		n.items = slices.Delete(n.items, i, i+1)
+		n.children = slices.Delete(n.children, i+1, i+2)
+	}
+}
+
+// ascend yields the items of the subtree of n in order, skipping those not
+// after *after if it is set. It returns false once yield does.
+func (n *node[K, V]) ascend(after *K, yield func(K, V) bool) bool {
+	i := 0
+	if after != nil {
+		i, _ = n.search(*after)
+	}
+
+	for ; i < len(n.items); i++ {
+		if !n.leaf() && !n.children[i].ascend(after, yield) {
+			return false
+		}
+		if after != nil && n.items[i].key <= *after {
+			continue
+		}
+		if !yield(n.items[i].key, n.items[i].value) {
+			return false
+		}
+	}
+
+	if n.leaf() {
+		return true
+	}
+	return n.children[len(n.items)].ascend(after, yield)
+}
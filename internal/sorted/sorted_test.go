@@ -0,0 +1,128 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package sorted
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// check asserts that m holds exactly the entries of expected, in order.
+func check(is *assert.Assertions, m *Map[int, int], expected map[int]int) {
+	is.Equal(len(expected), m.Len())
+	is.Equal(slices.Sorted(maps.Keys(expected)), m.Keys())
+
+	for key, value := range expected {
+		got, ok := m.Get(key)
+		is.True(ok, "key %d", key)
+		is.Equal(value, got, "key %d", key)
+	}
+}
+
+func TestMap_SetDelete(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var m Map[int, int]
+	owner := new(Owner)
+	expected := make(map[int]int)
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for i := range 20000 {
+		key := r.IntN(2000)
+		if r.IntN(3) == 0 {
+			_, held := expected[key]
+			value, ok := m.Delete(owner, key)
+			is.Equal(held, ok)
+			is.Equal(expected[key], value)
+			delete(expected, key)
+		} else {
+			m.Set(owner, key, i)
+			expected[key] = i
+		}
+	}
+	check(is, &m, expected)
+
+	for key := range expected {
+		m.Delete(owner, key)
+	}
+	is.Zero(m.Len())
+	is.Empty(m.Keys())
+	_, ok := m.Get(0)
+	is.False(ok)
+}
+
+func TestMap_After(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var m Map[int, int]
+	owner := new(Owner)
+	for i := range 1000 {
+		m.Set(owner, i*2, i)
+	}
+
+	for _, after := range []int{-1, 0, 1, 500, 997, 1998, 5000} {
+		var keys []int
+		for key := range m.After(after) {
+			keys = append(keys, key)
+			if len(keys) == 5 {
+				break
+			}
+		}
+
+		var expected []int
+		for key := after + 1; key < 2000 && len(expected) < 5; key++ {
+			if key >= 0 && key%2 == 0 {
+				expected = append(expected, key)
+			}
+		}
+		is.Equal(expected, keys, "after %d", after)
+	}
+}
+
+func TestMap_Copies(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var m Map[int, int]
+	owner := new(Owner)
+	expected := make(map[int]int)
+	for i := range 3000 {
+		m.Set(owner, i, i)
+		expected[i] = i
+	}
+
+	// Each copy is changed with its own owner, as is the original after
+	// the copy is made.
+	copied := m
+	copiedExpected := maps.Clone(expected)
+	owner, copiedOwner := new(Owner), new(Owner)
+
+	r := rand.New(rand.NewPCG(3, 4))
+	for i := range 5000 {
+		key := r.IntN(4000)
+		if i%2 == 0 {
+			m.Set(owner, key, -i)
+			expected[key] = -i
+			removed := r.IntN(4000)
+			copied.Delete(copiedOwner, removed)
+			delete(copiedExpected, removed)
+		} else {
+			m.Delete(owner, key)
+			delete(expected, key)
+			copied.Set(copiedOwner, key, i)
+			copiedExpected[key] = i
+		}
+	}
+
+	check(is, &m, expected)
+	check(is, &copied, copiedExpected)
+}
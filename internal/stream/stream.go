@@ -0,0 +1,136 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+// Package stream holds the cursor state and paging shared by the
+// implementations of StreamVerticesWithContext and StreamEdgesWithContext. A
+// cursor holds the last vertex key or edge sent, so a resumed stream
+// continues after it whatever was added or removed in between.
+package stream
+
+import (
+	"cmp"
+	"container/heap"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
+)
+
+// VertexPosition is the cursor state of a vertex stream: the key of the last
+// vertex sent.
+type VertexPosition[K graph.Ordered] struct {
+	Key K `json:"key"`
+}
+
+// EdgePosition is the cursor state of an edge stream: the last edge sent.
+type EdgePosition[K graph.Ordered] struct {
+	Source K            `json:"source"`
+	Target K            `json:"target"`
+	ID     graph.EdgeID `json:"id"`
+}
+
+// EdgePositionOf returns the position of a stream that has sent edge.
+func EdgePositionOf[K graph.Ordered](edge graph.Edge[K]) EdgePosition[K] {
+	return EdgePosition[K]{Source: edge.Source(), Target: edge.Target(), ID: edgeutil.ID(edge)}
+}
+
+// Before reports whether edge comes after the position in the order of
+// edgeutil.Compare, and so has not been sent yet.
+func (p EdgePosition[K]) Before(edge graph.Edge[K]) bool {
+	return cmp.Or(
+		cmp.Compare(p.Source, edge.Source()),
+		cmp.Compare(p.Target, edge.Target()),
+		cmp.Compare(p.ID, edgeutil.ID(edge)),
+	) < 0
+}
+
+// ReadCursor decodes the position held by cursor. It returns nil for a cursor
+// with an empty state, which starts at the beginning of the stream.
+func ReadCursor[P any](cursor graph.Cursor) (*P, error) {
+	if cursor == nil {
+		return nil, errors.New("cursor must not be nil")
+	}
+
+	state := cursor.State()
+	if len(state) == 0 {
+		return nil, nil
+	}
+
+	var position P
+	if err := json.Unmarshal(state, &position); err != nil {
+		return nil, fmt.Errorf("invalid cursor state %q: %w", state, err)
+	}
+
+	return &position, nil
+}
+
+// WriteCursor stores position as the state of cursor.
+func WriteCursor[P any](cursor graph.Cursor, position P) error {
+	state, err := json.Marshal(position)
+	if err != nil {
+		return err
+	}
+
+	return cursor.SetState(state)
+}
+
+// SelectPage returns the smallest limit items of seq accepted by include, in
+// ascending order. It keeps no more than limit items at a time, so paging
+// through a graph does not copy or sort all of it.
+func SelectPage[E any](seq iter.Seq[E], limit int, include func(E) bool, compare func(a, b E) int) []E {
+	page := &maxHeap[E]{compare: compare}
+
+	for item := range seq {
+		if !include(item) {
+			continue
+		}
+
+		if len(page.items) < limit {
+			heap.Push(page, item)
+			continue
+		}
+
+		// Replace the largest item kept if this one comes before it.
+		if compare(item, page.items[0]) < 0 {
+			page.items[0] = item
+			heap.Fix(page, 0)
+		}
+	}
+
+	slices.SortFunc(page.items, compare)
+	return page.items
+}
+
+// maxHeap is a heap.Interface that keeps the largest item at the top.
+type maxHeap[E any] struct {
+	items   []E
+	compare func(a, b E) int
+}
+
+func (h *maxHeap[E]) Len() int {
+	return len(h.items)
+}
+
+func (h *maxHeap[E]) Less(i, j int) bool {
+	return h.compare(h.items[i], h.items[j]) > 0
+}
+
+func (h *maxHeap[E]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *maxHeap[E]) Push(item any) {
+	h.items = append(h.items, item.(E))
+}
+
+func (h *maxHeap[E]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
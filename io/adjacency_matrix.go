@@ -0,0 +1,172 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+)
+
+// AdjacencyMatrix reads and writes graphs as dense adjacency matrices in
+// plain text: one row per line, with whitespace-separated entries, as loaded
+// by numpy.loadtxt. A non-zero entry in row i and column j is an edge from
+// the vertex at index i to the vertex at index j.
+//
+// Weighted graphs store edge weights as entries, so an edge with a weight of
+// zero cannot be represented; unweighted graphs store 1 for every edge. The
+// matrix of an undirected graph is symmetric, and reading one into an
+// undirected graph fails if it is not. Blank lines and lines starting with
+// '#' or '%' are ignored.
+//
+// Example:
+//
+//	am := io.NewAdjacencyMatrix[int, int](func(i int) (int, error) { return i, nil })
+//	if err := am.WriteGraph(os.Stdout, g); err != nil {
+//		log.Fatal(err)
+//	}
+type AdjacencyMatrix[K graph.Ordered, T any] struct {
+	matrixOptions[K, T]
+}
+
+// NewAdjacencyMatrix creates a dense adjacency matrix reader/writer that uses
+// vertex to convert a zero-based row index into a vertex value when reading.
+func NewAdjacencyMatrix[K graph.Ordered, T any](vertex func(i int) (T, error), options ...MatrixOption[K, T]) *AdjacencyMatrix[K, T] {
+	return &AdjacencyMatrix[K, T]{matrixOptions: newMatrixOptions(vertex, options)}
+}
+
+// WriteGraph writes the adjacency matrix of g to w.
+func (am *AdjacencyMatrix[K, T]) WriteGraph(w io.Writer, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	indices, n, err := am.indices(g)
+	if err != nil {
+		return err
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	traits := g.Traits()
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+	}
+	for _, edge := range edges {
+		value := 1.0
+		if p := edge.Properties(); traits.IsWeighted && p != nil {
+			value = p.Weight()
+		}
+
+		i, j := indices[edge.Source()], indices[edge.Target()]
+		matrix[i][j] = value
+		if !traits.IsDirected {
+			matrix[j][i] = value
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, row := range matrix {
+		for j, value := range row {
+			if j > 0 {
+				bw.WriteByte(' ')
+			}
+			bw.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+		}
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
+
+// ReadGraph parses an adjacency matrix from r and adds a vertex for every row
+// and an edge for every non-zero entry to g. Entries become edge weights if g
+// is weighted.
+func (am *AdjacencyMatrix[K, T]) ReadGraph(r io.Reader, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	type row struct {
+		line   int
+		values []float64
+	}
+
+	var rows []row
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "%") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(rows) > 0 && len(fields) != len(rows[0].values) {
+			return &ParseError{Format: "matrix", Line: line, Err: fmt.Errorf("expected %d columns, found %d", len(rows[0].values), len(fields))}
+		}
+
+		values := make([]float64, len(fields))
+		for i, f := range fields {
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return &ParseError{Format: "matrix", Line: line, Err: fmt.Errorf("invalid entry %q: %w", f, err)}
+			}
+			values[i] = v
+		}
+		rows = append(rows, row{line: line, values: values})
+	}
+	if err := scanner.Err(); err != nil {
+		return &ParseError{Format: "matrix", Line: line, Err: err}
+	}
+
+	if len(rows) > 0 && len(rows) != len(rows[0].values) {
+		return &ParseError{Format: "matrix", Line: line, Err: fmt.Errorf("matrix is %dx%d, not square", len(rows), len(rows[0].values))}
+	}
+
+	keys, err := am.addVertices(g, len(rows))
+	if err != nil {
+		return &ParseError{Format: "matrix", Line: 1, Err: err}
+	}
+
+	traits := g.Traits()
+	for i, r := range rows {
+		for j, value := range r.values {
+			if value == 0 {
+				continue
+			}
+
+			if !traits.IsDirected {
+				if value != rows[j].values[i] {
+					return &ParseError{Format: "matrix", Line: r.line, Err: fmt.Errorf("%w: entry (%d, %d) differs from (%d, %d)", graph.ErrGraphTypeMismatch, i, j, j, i)}
+				}
+				if j < i {
+					continue
+				}
+			}
+
+			var options []graph.EdgeOption
+			if traits.IsWeighted {
+				options = append(options, simple.EdgeWeight(value))
+			}
+			if err = g.AddEdgeWithOptions(keys[i], keys[j], options...); err != nil {
+				return &ParseError{Format: "matrix", Line: r.line, Err: err}
+			}
+		}
+	}
+
+	return nil
+}
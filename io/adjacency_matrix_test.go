@@ -0,0 +1,103 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/sets"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdjacencyMatrix_Write(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.IntHash, graph.Directed(), graph.Weighted())
+	for i := 0; i < 3; i++ {
+		is.NoError(g.AddVertexWithOptions(i))
+	}
+	is.NoError(g.AddEdgeWithOptions(0, 1, simple.EdgeWeight(2.5)))
+	is.NoError(g.AddEdgeWithOptions(2, 0, simple.EdgeWeight(1)))
+
+	var buf bytes.Buffer
+	is.NoError(NewAdjacencyMatrix[int, int](indexVertex).WriteGraph(&buf, g))
+	is.Equal("0 2.5 0\n0 0 0\n1 0 0\n", buf.String())
+
+	h, _ := simple.New(graph.IntHash)
+	for i := 0; i < 3; i++ {
+		is.NoError(h.AddVertexWithOptions(i))
+	}
+	is.NoError(h.AddEdgeWithOptions(0, 1, simple.EdgeWeight(2.5)))
+
+	buf.Reset()
+	is.NoError(NewAdjacencyMatrix[int, int](indexVertex).WriteGraph(&buf, h))
+	is.Equal("0 1 0\n1 0 0\n0 0 0\n", buf.String())
+}
+
+func TestAdjacencyMatrix_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, directed := range []bool{true, false} {
+		is := assert.New(t)
+
+		options := []func(*graph.Traits){graph.Weighted()}
+		if directed {
+			options = append(options, graph.Directed())
+		}
+
+		g, _ := simple.New(graph.IntHash, options...)
+		for i := 0; i < 4; i++ {
+			is.NoError(g.AddVertexWithOptions(i))
+		}
+		is.NoError(g.AddEdgeWithOptions(0, 1, simple.EdgeWeight(1.5)))
+		is.NoError(g.AddEdgeWithOptions(3, 1, simple.EdgeWeight(-2)))
+		is.NoError(g.AddEdgeWithOptions(2, 2, simple.EdgeWeight(4)))
+
+		am := NewAdjacencyMatrix[int, int](indexVertex)
+
+		var buf bytes.Buffer
+		is.NoError(am.WriteGraph(&buf, g))
+
+		h, _ := simple.New(graph.IntHash, options...)
+		is.NoError(am.ReadGraph(&buf, h))
+
+		equal, err := sets.Equals(g, h)
+		is.NoError(err)
+		is.True(equal)
+	}
+}
+
+func TestAdjacencyMatrix_ReadErrors(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	am := NewAdjacencyMatrix[int, int](indexVertex)
+	var perr *ParseError
+
+	g, _ := simple.New(graph.IntHash)
+	err := am.ReadGraph(strings.NewReader("# header\n0 1\n0 0\n"), g)
+	is.ErrorIs(err, graph.ErrGraphTypeMismatch)
+	is.True(errors.As(err, &perr))
+	is.Equal(2, perr.Line)
+
+	g, _ = simple.New(graph.IntHash, graph.Directed())
+	err = am.ReadGraph(strings.NewReader("0 1\n0 0 0\n"), g)
+	is.True(errors.As(err, &perr))
+	is.Equal(2, perr.Line)
+
+	err = am.ReadGraph(strings.NewReader("0 1\n0 x\n"), g)
+	is.True(errors.As(err, &perr))
+	is.Equal(2, perr.Line)
+
+	err = am.ReadGraph(strings.NewReader("0 1 0\n0 0 1\n"), g)
+	is.True(errors.As(err, &perr))
+}
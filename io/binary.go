@@ -0,0 +1,976 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+)
+
+const (
+	// BinaryFormatVersion is the version of the binary snapshot format written
+	// by this package. Readers reject snapshots with a higher version.
+	BinaryFormatVersion = 1
+
+	// binaryMagic starts every binary snapshot.
+	binaryMagic = "SXGB"
+
+	// binaryMaxLength bounds the length prefixes accepted by the decoder.
+	binaryMaxLength = 1 << 30
+
+	// binaryMaxPrealloc bounds the capacity reserved from the counts in the
+	// header and the length prefixes, so a corrupt snapshot cannot trigger a
+	// huge allocation before the data it announces has been read.
+	binaryMaxPrealloc = 1 << 20
+
+	// binaryMaxDepth bounds how deeply lists and maps may be nested in items
+	// and metadata, so a crafted snapshot cannot overflow the stack of the
+	// recursive decoder.
+	binaryMaxDepth = 64
+)
+
+// Trait flags stored in the snapshot header.
+const (
+	binaryDirected byte = 1 << iota
+	binaryMultiGraph
+	binaryAcyclic
+	binaryRooted
+	binaryWeighted
+	binaryPreventCycles
+)
+
+// Property flags that prefix every vertex and edge record.
+const (
+	binaryHasWeight byte = 1 << iota
+	binaryHasItems
+	binaryHasMetadata
+)
+
+// Tags of the self-describing values used for items and metadata.
+const (
+	binaryNil byte = iota
+	binaryFalse
+	binaryTrue
+	binaryInt
+	binaryInt8
+	binaryInt16
+	binaryInt32
+	binaryInt64
+	binaryUint
+	binaryUint8
+	binaryUint16
+	binaryUint32
+	binaryUint64
+	binaryFloat32
+	binaryFloat64
+	binaryString
+	binaryBytes
+	binaryList
+	binaryMap
+	binaryTime
+)
+
+// Binary reads and writes compact, checksummed binary snapshots of graphs,
+// for services that need to save and restore large graphs quickly.
+//
+// A snapshot is laid out as follows, with all integers varint-encoded:
+//
+//	magic "SXGB" | version | header length | header | vertex table | edge table | CRC-32
+//
+// The header holds the Traits flags and the vertex and edge counts.
+// Each vertex record holds the key and value, encoded with the configured
+// codecs, followed by the weight, items and metadata. Each edge record refers
+// to its endpoints by their position in the vertex table, followed by its
+// properties. The trailing IEEE CRC-32 covers every preceding byte.
+//
+// Items and metadata may hold nil, bools, integers, floats, strings, byte
+// slices, time.Time values, and []any and map[string]any values built from
+// those. Other types cause WriteGraph to fail.
+//
+// Load bulk-builds a simple graph from a snapshot with simple.Builder, which
+// is much faster than ReadGraph for large graphs. NewDecoder gives streaming
+// access to the records.
+//
+// Example:
+//
+//	bin := io.NewBinary[string, string](io.BinaryValueCodec[string, string](io.OrderedCodec[string]()))
+//	g, err := bin.Load(f, graph.StringHash)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+type Binary[K graph.Ordered, T any] struct {
+	keyCodec   Codec[K]
+	valueCodec Codec[T]
+}
+
+// BinaryOption configures a Binary reader/writer.
+type BinaryOption[K graph.Ordered, T any] func(*Binary[K, T])
+
+// NewBinary creates a binary snapshot reader/writer. Keys are encoded with
+// OrderedCodec and values with JSONCodec unless configured otherwise.
+func NewBinary[K graph.Ordered, T any](options ...BinaryOption[K, T]) *Binary[K, T] {
+	b := &Binary[K, T]{
+		keyCodec:   OrderedCodec[K](),
+		valueCodec: JSONCodec[T](),
+	}
+
+	for _, option := range options {
+		option(b)
+	}
+
+	return b
+}
+
+// BinaryKeyCodec sets the codec used for vertex keys.
+func BinaryKeyCodec[K graph.Ordered, T any](codec Codec[K]) BinaryOption[K, T] {
+	return func(b *Binary[K, T]) {
+		b.keyCodec = codec
+	}
+}
+
+// BinaryValueCodec sets the codec used for vertex values.
+func BinaryValueCodec[K graph.Ordered, T any](codec Codec[T]) BinaryOption[K, T] {
+	return func(b *Binary[K, T]) {
+		b.valueCodec = codec
+	}
+}
+
+// BinaryHeader describes a binary snapshot.
+type BinaryHeader struct {
+	// Version is the format version the snapshot was written with.
+	Version int
+
+	// Traits are the traits of the encoded graph.
+	Traits graph.Traits
+
+	// Vertices is the number of vertex records.
+	Vertices int
+
+	// Edges is the number of edge records.
+	Edges int
+}
+
+// WriteGraph writes a binary snapshot of g to w.
+func (b *Binary[K, T]) WriteGraph(w io.Writer, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	vertices, err := g.Vertices()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	sort.SliceStable(vertices, func(i, j int) bool {
+		return vertices[i].ID() < vertices[j].ID()
+	})
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].Source() == edges[j].Source() {
+			return edges[i].Target() < edges[j].Target()
+		}
+		return edges[i].Source() < edges[j].Source()
+	})
+
+	crc := crc32.NewIEEE()
+	bw := bufio.NewWriter(io.MultiWriter(w, crc))
+
+	header := []byte{binaryTraits(g.Traits())}
+	header = binary.AppendUvarint(header, uint64(len(vertices)))
+	header = binary.AppendUvarint(header, uint64(len(edges)))
+
+	buf := []byte(binaryMagic)
+	buf = binary.AppendUvarint(buf, BinaryFormatVersion)
+	buf = binary.AppendUvarint(buf, uint64(len(header)))
+	buf = append(buf, header...)
+	if _, err = bw.Write(buf); err != nil {
+		return err
+	}
+
+	indices := make(map[K]uint64, len(vertices))
+	for i, vertex := range vertices {
+		indices[vertex.ID()] = uint64(i)
+
+		key, err := b.keyCodec.Marshal(vertex.ID())
+		if err != nil {
+			return fmt.Errorf("failed to encode key of vertex %v: %w", vertex.ID(), err)
+		}
+		value, err := b.valueCodec.Marshal(vertex.Value())
+		if err != nil {
+			return fmt.Errorf("failed to encode value of vertex %v: %w", vertex.ID(), err)
+		}
+
+		buf = appendBinaryBytes(buf[:0], key)
+		buf = appendBinaryBytes(buf, value)
+
+		var (
+			weight   float64
+			items    map[string]any
+			metadata any
+		)
+		if p := vertex.Properties(); p != nil {
+			weight, items, metadata = p.Weight(), p.Items(), p.Metadata()
+		}
+		if buf, err = appendBinaryProperties(buf, weight, items, metadata); err != nil {
+			return fmt.Errorf("failed to encode properties of vertex %v: %w", vertex.ID(), err)
+		}
+
+		if _, err = bw.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	for _, edge := range edges {
+		buf = binary.AppendUvarint(buf[:0], indices[edge.Source()])
+		buf = binary.AppendUvarint(buf, indices[edge.Target()])
+
+		var (
+			weight   float64
+			items    map[string]any
+			metadata any
+		)
+		if p := edge.Properties(); p != nil {
+			weight, items, metadata = p.Weight(), p.Items(), p.Metadata()
+		}
+		if buf, err = appendBinaryProperties(buf, weight, items, metadata); err != nil {
+			return fmt.Errorf("failed to encode properties of edge (%v, %v): %w", edge.Source(), edge.Target(), err)
+		}
+
+		if _, err = bw.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+
+	_, err = w.Write(binary.LittleEndian.AppendUint32(nil, crc.Sum32()))
+	return err
+}
+
+// ReadGraph decodes a binary snapshot from r and adds its vertices and edges
+// to g through the regular, validating graph methods. It returns
+// graph.ErrGraphTypeMismatch if the snapshot's directedness differs from g's.
+//
+// The records are decoded and the checksum verified before g is changed, so a
+// corrupt or truncated snapshot leaves g as it was. If g implements
+// graph.Batcher, the records are added in one batch, so a vertex or edge that
+// g rejects leaves it as it was too.
+func (b *Binary[K, T]) ReadGraph(r io.Reader, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	d, err := b.NewDecoder(r)
+	if err != nil {
+		return err
+	}
+
+	if d.header.Traits.IsDirected != g.Traits().IsDirected {
+		return d.fail(fmt.Errorf("%w: snapshot has directed=%t", graph.ErrGraphTypeMismatch, d.header.Traits.IsDirected))
+	}
+
+	type vertexRecord struct {
+		value   T
+		options []graph.VertexOption
+	}
+	type edgeRecord struct {
+		source, target int
+		options        []graph.EdgeOption
+	}
+
+	vertices := make([]vertexRecord, 0, min(d.header.Vertices, binaryMaxPrealloc))
+	for {
+		_, value, options, err := d.nextVertex()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		vertices = append(vertices, vertexRecord{value: value, options: options})
+		d.keys = append(d.keys, g.Hash()(value))
+	}
+
+	edges := make([]edgeRecord, 0, min(d.header.Edges, binaryMaxPrealloc))
+	for {
+		source, target, options, err := d.nextEdge()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		edges = append(edges, edgeRecord{source: source, target: target, options: options})
+	}
+
+	apply := func(g graph.Interface[K, T]) error {
+		for _, vertex := range vertices {
+			if err := g.AddVertexWithOptions(vertex.value, vertex.options...); err != nil {
+				return err
+			}
+		}
+
+		for _, edge := range edges {
+			if err := g.AddEdgeWithOptions(d.keys[edge.source], d.keys[edge.target], edge.options...); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if batcher, ok := g.(graph.Batcher[K, T]); ok {
+		return batcher.Batch(apply)
+	}
+
+	return apply(g)
+}
+
+// Load decodes a binary snapshot from r into a new simple graph with the
+// snapshot's traits, bulk-building it with simple.Builder instead of
+// validating every edge. hash must map each decoded value to its key.
+func (b *Binary[K, T]) Load(r io.Reader, hash graph.Hash[K, T]) (graph.Interface[K, T], error) {
+	d, err := b.NewDecoder(r)
+	if err != nil {
+		return nil, err
+	}
+
+	traits := d.header.Traits
+	builder := simple.NewBuilder(hash, func(t *graph.Traits) {
+		*t = traits
+	})
+
+	for {
+		_, value, options, err := d.nextVertex()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		d.keys = append(d.keys, builder.AddVertexWithOptions(value, options...))
+	}
+
+	for {
+		source, target, options, err := d.nextEdge()
+		if errors.Is(err, io.EOF) {
+			return builder.Build()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err = builder.AddEdgeWithOptions(d.keys[source], d.keys[target], options...); err != nil {
+			return nil, d.fail(err)
+		}
+	}
+}
+
+// BinaryDecoder reads the records of a binary snapshot one at a time, so a
+// snapshot can be processed without materializing the graph. All vertices
+// must be read before the edges. The checksum is verified once the last edge
+// has been read.
+type BinaryDecoder[K graph.Ordered, T any] struct {
+	codecs   *Binary[K, T]
+	r        *bufio.Reader
+	crc      hash.Hash32
+	offset   int64
+	header   BinaryHeader
+	keys     []K
+	vertices int
+	edges    int
+	done     bool
+}
+
+// NewDecoder reads the header of the binary snapshot in r and returns a
+// decoder positioned at its first vertex record.
+func (b *Binary[K, T]) NewDecoder(r io.Reader) (*BinaryDecoder[K, T], error) {
+	d := &BinaryDecoder[K, T]{
+		codecs: b,
+		r:      bufio.NewReader(r),
+		crc:    crc32.NewIEEE(),
+	}
+
+	magic, err := d.read(len(binaryMagic))
+	if err != nil {
+		return nil, d.fail(err)
+	}
+	if string(magic) != binaryMagic {
+		return nil, d.fail(errors.New("not a binary graph snapshot"))
+	}
+
+	version, err := binary.ReadUvarint(d)
+	if err != nil {
+		return nil, d.fail(err)
+	}
+	if version == 0 || version > BinaryFormatVersion {
+		return nil, d.fail(fmt.Errorf("%w: %d", ErrUnsupportedVersion, version))
+	}
+
+	header, err := d.readBytes()
+	if err != nil {
+		return nil, d.fail(err)
+	}
+	if len(header) < 1 {
+		return nil, d.fail(errors.New("truncated header"))
+	}
+
+	vertices, n := binary.Uvarint(header[1:])
+	if n <= 0 {
+		return nil, d.fail(errors.New("invalid vertex count"))
+	}
+	edges, m := binary.Uvarint(header[1+n:])
+	if m <= 0 {
+		return nil, d.fail(errors.New("invalid edge count"))
+	}
+	if vertices > math.MaxInt32 || edges > math.MaxInt {
+		return nil, d.fail(errors.New("counts out of range"))
+	}
+
+	d.header = BinaryHeader{
+		Version:  int(version),
+		Traits:   binaryTraitsFrom(header[0]),
+		Vertices: int(vertices),
+		Edges:    int(edges),
+	}
+	d.keys = make([]K, 0, min(d.header.Vertices, binaryMaxPrealloc))
+
+	return d, nil
+}
+
+// Header returns the snapshot header.
+func (d *BinaryDecoder[K, T]) Header() BinaryHeader {
+	return d.header
+}
+
+// NextVertex returns the next vertex record. It returns io.EOF once all
+// vertices have been read.
+func (d *BinaryDecoder[K, T]) NextVertex() (graph.Vertex[K, T], error) {
+	key, value, options, err := d.nextVertex()
+	if err != nil {
+		return nil, err
+	}
+
+	d.keys = append(d.keys, key)
+	return simple.NewVertexWithOptions(key, value, options...), nil
+}
+
+// NextEdge returns the next edge record. It returns io.EOF once all edges
+// have been read and the checksum has been verified.
+func (d *BinaryDecoder[K, T]) NextEdge() (graph.Edge[K], error) {
+	if d.vertices < d.header.Vertices {
+		return nil, errors.New("vertices must be read before edges")
+	}
+
+	source, target, options, err := d.nextEdge()
+	if err != nil {
+		return nil, err
+	}
+
+	return simple.NewEdgeWithOptions(d.keys[source], d.keys[target], options...), nil
+}
+
+// nextVertex decodes the next vertex record. Callers record the key of the
+// vertex in d.keys.
+func (d *BinaryDecoder[K, T]) nextVertex() (K, T, []graph.VertexOption, error) {
+	var (
+		key   K
+		value T
+	)
+
+	if d.vertices == d.header.Vertices {
+		return key, value, nil, io.EOF
+	}
+
+	raw, err := d.readBytes()
+	if err != nil {
+		return key, value, nil, d.fail(err)
+	}
+	if key, err = d.codecs.keyCodec.Unmarshal(raw); err != nil {
+		return key, value, nil, d.fail(fmt.Errorf("invalid key: %w", err))
+	}
+
+	if raw, err = d.readBytes(); err != nil {
+		return key, value, nil, d.fail(err)
+	}
+	if value, err = d.codecs.valueCodec.Unmarshal(raw); err != nil {
+		return key, value, nil, d.fail(fmt.Errorf("invalid value: %w", err))
+	}
+
+	weight, hasWeight, items, metadata, err := d.readProperties()
+	if err != nil {
+		return key, value, nil, d.fail(err)
+	}
+
+	var options []graph.VertexOption
+	if hasWeight {
+		options = append(options, simple.VertexWeight(weight))
+	}
+	if items != nil {
+		options = append(options, simple.VertexItems(items))
+	}
+	if metadata != nil {
+		options = append(options, simple.VertexMetadata(metadata))
+	}
+
+	d.vertices++
+	return key, value, options, nil
+}
+
+// nextEdge decodes the next edge record, returning the vertex table indices of
+// its endpoints. After the last edge it verifies the checksum.
+func (d *BinaryDecoder[K, T]) nextEdge() (int, int, []graph.EdgeOption, error) {
+	if d.edges == d.header.Edges {
+		if err := d.verify(); err != nil {
+			return 0, 0, nil, err
+		}
+		return 0, 0, nil, io.EOF
+	}
+
+	var ends [2]int
+	for i := range ends {
+		index, err := binary.ReadUvarint(d)
+		if err != nil {
+			return 0, 0, nil, d.fail(err)
+		}
+		if index >= uint64(len(d.keys)) {
+			return 0, 0, nil, d.fail(fmt.Errorf("vertex index %d is out of range", index))
+		}
+		ends[i] = int(index)
+	}
+
+	weight, hasWeight, items, metadata, err := d.readProperties()
+	if err != nil {
+		return 0, 0, nil, d.fail(err)
+	}
+
+	var options []graph.EdgeOption
+	if hasWeight {
+		options = append(options, simple.EdgeWeight(weight))
+	}
+	if items != nil {
+		options = append(options, simple.EdgeItems(items))
+	}
+	if metadata != nil {
+		options = append(options, simple.EdgeData(metadata))
+	}
+
+	d.edges++
+	return ends[0], ends[1], options, nil
+}
+
+// verify checks the trailing checksum once.
+func (d *BinaryDecoder[K, T]) verify() error {
+	if d.done {
+		return nil
+	}
+
+	want := d.crc.Sum32()
+	trailer, err := d.read(4)
+	if err != nil {
+		return d.fail(err)
+	}
+	if got := binary.LittleEndian.Uint32(trailer); got != want {
+		return d.fail(fmt.Errorf("%w: stored %08x, computed %08x", ErrChecksumMismatch, got, want))
+	}
+
+	d.done = true
+	return nil
+}
+
+// fail wraps err in a ParseError at the current offset.
+func (d *BinaryDecoder[K, T]) fail(err error) error {
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return &ParseError{Format: "binary", Offset: d.offset, Err: err}
+}
+
+// ReadByte implements io.ByteReader, keeping the checksum and offset current.
+func (d *BinaryDecoder[K, T]) ReadByte() (byte, error) {
+	c, err := d.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	d.crc.Write([]byte{c})
+	d.offset++
+	return c, nil
+}
+
+// read returns the next n bytes. They are read in chunks of at most
+// binaryMaxPrealloc bytes, so the buffer only grows as data arrives.
+func (d *BinaryDecoder[K, T]) read(n int) ([]byte, error) {
+	buf := make([]byte, 0, min(n, binaryMaxPrealloc))
+	for len(buf) < n {
+		chunk := min(n-len(buf), binaryMaxPrealloc)
+		buf = slices.Grow(buf, chunk)
+
+		read, err := io.ReadFull(d.r, buf[len(buf):len(buf)+chunk])
+		d.crc.Write(buf[len(buf) : len(buf)+read])
+		d.offset += int64(read)
+		buf = buf[:len(buf)+read]
+		if err != nil {
+			return buf, err
+		}
+	}
+
+	return buf, nil
+}
+
+// readBytes returns the next length-prefixed byte string.
+func (d *BinaryDecoder[K, T]) readBytes() ([]byte, error) {
+	n, err := binary.ReadUvarint(d)
+	if err != nil {
+		return nil, err
+	}
+	if n > binaryMaxLength {
+		return nil, fmt.Errorf("length %d exceeds limit", n)
+	}
+	return d.read(int(n))
+}
+
+// readFloat returns the next IEEE 754 double.
+func (d *BinaryDecoder[K, T]) readFloat() (float64, error) {
+	buf, err := d.read(8)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(buf)), nil
+}
+
+// readProperties decodes the weight, items and metadata of a record.
+func (d *BinaryDecoder[K, T]) readProperties() (float64, bool, map[string]any, any, error) {
+	var (
+		weight   float64
+		items    map[string]any
+		metadata any
+	)
+
+	flags, err := d.ReadByte()
+	if err != nil {
+		return 0, false, nil, nil, err
+	}
+
+	if flags&binaryHasWeight != 0 {
+		if weight, err = d.readFloat(); err != nil {
+			return 0, false, nil, nil, err
+		}
+	}
+
+	if flags&binaryHasItems != 0 {
+		v, err := d.readMap(1)
+		if err != nil {
+			return 0, false, nil, nil, err
+		}
+		items = v
+	}
+
+	if flags&binaryHasMetadata != 0 {
+		if metadata, err = d.readValue(0); err != nil {
+			return 0, false, nil, nil, err
+		}
+	}
+
+	return weight, flags&binaryHasWeight != 0, items, metadata, nil
+}
+
+// readMap decodes a count followed by name/value pairs, nested in depth lists
+// and maps.
+func (d *BinaryDecoder[K, T]) readMap(depth int) (map[string]any, error) {
+	n, err := binary.ReadUvarint(d)
+	if err != nil {
+		return nil, err
+	}
+	if n > binaryMaxLength {
+		return nil, fmt.Errorf("map length %d exceeds limit", n)
+	}
+
+	m := make(map[string]any, min(n, binaryMaxPrealloc))
+	for range n {
+		name, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		if m[string(name)], err = d.readValue(depth); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// readValue decodes a tagged value nested in depth lists and maps.
+func (d *BinaryDecoder[K, T]) readValue(depth int) (any, error) {
+	tag, err := d.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case binaryNil:
+		return nil, nil
+	case binaryFalse:
+		return false, nil
+	case binaryTrue:
+		return true, nil
+	case binaryInt, binaryInt8, binaryInt16, binaryInt32, binaryInt64:
+		x, err := binary.ReadVarint(d)
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case binaryInt8:
+			return int8(x), nil
+		case binaryInt16:
+			return int16(x), nil
+		case binaryInt32:
+			return int32(x), nil
+		case binaryInt64:
+			return x, nil
+		default:
+			return int(x), nil
+		}
+	case binaryUint, binaryUint8, binaryUint16, binaryUint32, binaryUint64:
+		x, err := binary.ReadUvarint(d)
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case binaryUint8:
+			return uint8(x), nil
+		case binaryUint16:
+			return uint16(x), nil
+		case binaryUint32:
+			return uint32(x), nil
+		case binaryUint64:
+			return x, nil
+		default:
+			return uint(x), nil
+		}
+	case binaryFloat32:
+		buf, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(buf)), nil
+	case binaryFloat64:
+		return d.readFloat()
+	case binaryString:
+		buf, err := d.readBytes()
+		return string(buf), err
+	case binaryBytes:
+		return d.readBytes()
+	case binaryList:
+		if depth == binaryMaxDepth {
+			return nil, fmt.Errorf("values nested more than %d deep", binaryMaxDepth)
+		}
+		n, err := binary.ReadUvarint(d)
+		if err != nil {
+			return nil, err
+		}
+		if n > binaryMaxLength {
+			return nil, fmt.Errorf("list length %d exceeds limit", n)
+		}
+		list := make([]any, 0, min(n, binaryMaxPrealloc))
+		for range n {
+			v, err := d.readValue(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case binaryMap:
+		if depth == binaryMaxDepth {
+			return nil, fmt.Errorf("values nested more than %d deep", binaryMaxDepth)
+		}
+		return d.readMap(depth + 1)
+	case binaryTime:
+		buf, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		var t time.Time
+		err = t.UnmarshalBinary(buf)
+		return t, err
+	default:
+		return nil, fmt.Errorf("unknown value tag %d", tag)
+	}
+}
+
+// binaryTraits packs traits into header flags.
+func binaryTraits(t *graph.Traits) byte {
+	var flags byte
+	for _, f := range []struct {
+		set  bool
+		flag byte
+	}{
+		{t.IsDirected, binaryDirected},
+		{t.IsMultiGraph, binaryMultiGraph},
+		{t.IsAcyclic, binaryAcyclic},
+		{t.IsRooted, binaryRooted},
+		{t.IsWeighted, binaryWeighted},
+		{t.PreventCycles, binaryPreventCycles},
+	} {
+		if f.set {
+			flags |= f.flag
+		}
+	}
+	return flags
+}
+
+// binaryTraitsFrom unpacks header flags into traits.
+func binaryTraitsFrom(flags byte) graph.Traits {
+	return graph.Traits{
+		IsDirected:    flags&binaryDirected != 0,
+		IsMultiGraph:  flags&binaryMultiGraph != 0,
+		IsAcyclic:     flags&binaryAcyclic != 0,
+		IsRooted:      flags&binaryRooted != 0,
+		IsWeighted:    flags&binaryWeighted != 0,
+		PreventCycles: flags&binaryPreventCycles != 0,
+	}
+}
+
+// appendBinaryBytes appends a length-prefixed byte string.
+func appendBinaryBytes(buf, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+// appendBinaryProperties appends the flags, weight, items and metadata of a
+// record.
+func appendBinaryProperties(buf []byte, weight float64, items map[string]any, metadata any) ([]byte, error) {
+	var flags byte
+	if weight != 0 {
+		flags |= binaryHasWeight
+	}
+	if len(items) > 0 {
+		flags |= binaryHasItems
+	}
+	if metadata != nil {
+		flags |= binaryHasMetadata
+	}
+
+	buf = append(buf, flags)
+	if weight != 0 {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(weight))
+	}
+
+	var err error
+	if len(items) > 0 {
+		if buf, err = appendBinaryMap(buf, items, 1); err != nil {
+			return nil, err
+		}
+	}
+	if metadata != nil {
+		if buf, err = appendBinaryValue(buf, metadata, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+// appendBinaryMap appends a count followed by name/value pairs in name order,
+// nested in depth lists and maps.
+func appendBinaryMap(buf []byte, m map[string]any, depth int) ([]byte, error) {
+	buf = binary.AppendUvarint(buf, uint64(len(m)))
+
+	var err error
+	for _, name := range sortedKeys(m) {
+		buf = appendBinaryBytes(buf, []byte(name))
+		if buf, err = appendBinaryValue(buf, m[name], depth); err != nil {
+			return nil, fmt.Errorf("item %q: %w", name, err)
+		}
+	}
+
+	return buf, nil
+}
+
+// appendBinaryValue appends a tagged value nested in depth lists and maps.
+// Values nested deeper than the decoder accepts are rejected.
+func appendBinaryValue(buf []byte, v any, depth int) ([]byte, error) {
+	switch x := v.(type) {
+	case nil:
+		return append(buf, binaryNil), nil
+	case bool:
+		if x {
+			return append(buf, binaryTrue), nil
+		}
+		return append(buf, binaryFalse), nil
+	case int:
+		return binary.AppendVarint(append(buf, binaryInt), int64(x)), nil
+	case int8:
+		return binary.AppendVarint(append(buf, binaryInt8), int64(x)), nil
+	case int16:
+		return binary.AppendVarint(append(buf, binaryInt16), int64(x)), nil
+	case int32:
+		return binary.AppendVarint(append(buf, binaryInt32), int64(x)), nil
+	case int64:
+		return binary.AppendVarint(append(buf, binaryInt64), x), nil
+	case uint:
+		return binary.AppendUvarint(append(buf, binaryUint), uint64(x)), nil
+	case uint8:
+		return binary.AppendUvarint(append(buf, binaryUint8), uint64(x)), nil
+	case uint16:
+		return binary.AppendUvarint(append(buf, binaryUint16), uint64(x)), nil
+	case uint32:
+		return binary.AppendUvarint(append(buf, binaryUint32), uint64(x)), nil
+	case uint64:
+		return binary.AppendUvarint(append(buf, binaryUint64), x), nil
+	case float32:
+		return binary.LittleEndian.AppendUint32(append(buf, binaryFloat32), math.Float32bits(x)), nil
+	case float64:
+		return binary.LittleEndian.AppendUint64(append(buf, binaryFloat64), math.Float64bits(x)), nil
+	case string:
+		return appendBinaryBytes(append(buf, binaryString), []byte(x)), nil
+	case []byte:
+		return appendBinaryBytes(append(buf, binaryBytes), x), nil
+	case []any:
+		if depth == binaryMaxDepth {
+			return nil, fmt.Errorf("values nested more than %d deep", binaryMaxDepth)
+		}
+		buf = binary.AppendUvarint(append(buf, binaryList), uint64(len(x)))
+		var err error
+		for _, e := range x {
+			if buf, err = appendBinaryValue(buf, e, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case map[string]any:
+		if depth == binaryMaxDepth {
+			return nil, fmt.Errorf("values nested more than %d deep", binaryMaxDepth)
+		}
+		return appendBinaryMap(append(buf, binaryMap), x, depth+1)
+	case time.Time:
+		b, err := x.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		return appendBinaryBytes(append(buf, binaryTime), b), nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", v)
+	}
+}
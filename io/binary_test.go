@@ -0,0 +1,239 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/sets"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+// binaryTestGraph returns a small graph with weights, items and metadata.
+func binaryTestGraph(t *testing.T, options ...func(*graph.Traits)) graph.Interface[string, string] {
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash, options...)
+	is.NoError(g.AddVertexWithOptions("A",
+		simple.VertexItems(map[string]any{"label": "Alpha", "count": 3, "ratio": 0.25}),
+		simple.VertexMetadata(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))))
+	is.NoError(g.AddVertexWithOptions("B", simple.VertexWeight(1.5)))
+	is.NoError(g.AddVertexWithOptions("C"))
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(2), simple.EdgeItem("kind", "dep")))
+	is.NoError(g.AddEdgeWithOptions("B", "C", simple.EdgeWeight(0.5),
+		simple.EdgeData(map[string]any{"tags": []any{"x", int64(-7), uint8(9), nil, true}})))
+
+	return g
+}
+
+func TestBinary_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, directed := range []bool{true, false} {
+		options := []func(*graph.Traits){graph.Weighted()}
+		if directed {
+			options = append(options, graph.Directed())
+		}
+
+		is := assert.New(t)
+		g := binaryTestGraph(t, options...)
+		bin := NewBinary[string, string](BinaryValueCodec[string, string](OrderedCodec[string]()))
+
+		var buf bytes.Buffer
+		is.NoError(bin.WriteGraph(&buf, g))
+		snapshot := buf.Bytes()
+
+		h, _ := simple.New(graph.StringHash, options...)
+		is.NoError(bin.ReadGraph(bytes.NewReader(snapshot), h))
+
+		equal, err := sets.Equals(g, h)
+		is.NoError(err)
+		is.True(equal)
+
+		l, err := bin.Load(bytes.NewReader(snapshot), graph.StringHash)
+		is.NoError(err)
+		is.Equal(*g.Traits(), *l.Traits())
+
+		equal, err = sets.Equals(g, l)
+		is.NoError(err)
+		is.True(equal)
+
+		for _, loaded := range []graph.Interface[string, string]{h, l} {
+			a, _ := loaded.Vertex("A")
+			is.Equal(map[string]any{"label": "Alpha", "count": 3, "ratio": 0.25}, a.Properties().Items())
+			is.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), a.Properties().Metadata())
+
+			b, _ := loaded.Vertex("B")
+			is.Equal(1.5, b.Properties().Weight())
+
+			e, _ := loaded.Edge("A", "B")
+			is.Equal(float64(2), e.Properties().Weight())
+			is.Equal("dep", e.Properties().Items()["kind"])
+
+			e, _ = loaded.Edge("B", "C")
+			is.Equal(map[string]any{"tags": []any{"x", int64(-7), uint8(9), nil, true}}, e.Properties().Metadata())
+		}
+
+		// Directedness must match when reading into an existing graph.
+		var other []func(*graph.Traits)
+		if !directed {
+			other = append(other, graph.Directed())
+		}
+		m, _ := simple.New(graph.StringHash, other...)
+		is.ErrorIs(bin.ReadGraph(bytes.NewReader(snapshot), m), graph.ErrGraphTypeMismatch)
+	}
+}
+
+func TestBinary_Decoder(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.IntHash, graph.Directed(), graph.Acyclic())
+	for i := range 4 {
+		is.NoError(g.AddVertexWithOptions(i))
+	}
+	is.NoError(g.AddEdgeWithOptions(0, 1))
+	is.NoError(g.AddEdgeWithOptions(1, 3))
+	is.NoError(g.AddEdgeWithOptions(2, 3))
+
+	bin := NewBinary[int, int]()
+
+	var buf bytes.Buffer
+	is.NoError(bin.WriteGraph(&buf, g))
+
+	d, err := bin.NewDecoder(&buf)
+	is.NoError(err)
+	is.Equal(BinaryHeader{
+		Version:  BinaryFormatVersion,
+		Traits:   graph.Traits{IsDirected: true, IsAcyclic: true},
+		Vertices: 4,
+		Edges:    3,
+	}, d.Header())
+
+	_, err = d.NextEdge()
+	is.Error(err)
+
+	var keys []int
+	for {
+		v, err := d.NextVertex()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		is.NoError(err)
+		keys = append(keys, v.ID())
+	}
+	is.Equal([]int{0, 1, 2, 3}, keys)
+
+	var edges [][2]int
+	for {
+		e, err := d.NextEdge()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		is.NoError(err)
+		edges = append(edges, [2]int{e.Source(), e.Target()})
+	}
+	is.Equal([][2]int{{0, 1}, {1, 3}, {2, 3}}, edges)
+}
+
+func TestBinary_Errors(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	bin := NewBinary[string, string]()
+	g := binaryTestGraph(t, graph.Directed())
+
+	var buf bytes.Buffer
+	is.NoError(bin.WriteGraph(&buf, g))
+	snapshot := buf.Bytes()
+
+	var pe *ParseError
+
+	// A flipped byte in the vertex table fails the checksum.
+	corrupt := bytes.Clone(snapshot)
+	i := bytes.Index(corrupt, []byte("Alpha"))
+	corrupt[i] = 'a'
+	_, err := bin.Load(bytes.NewReader(corrupt), graph.StringHash)
+	is.ErrorIs(err, ErrChecksumMismatch)
+	is.ErrorAs(err, &pe)
+	is.Equal("binary", pe.Format)
+
+	// A truncated snapshot is reported as such.
+	_, err = bin.Load(bytes.NewReader(snapshot[:len(snapshot)-10]), graph.StringHash)
+	is.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	// Snapshots from newer versions are rejected.
+	newer := bytes.Clone(snapshot)
+	newer[len(binaryMagic)] = BinaryFormatVersion + 1
+	_, err = bin.Load(bytes.NewReader(newer), graph.StringHash)
+	is.ErrorIs(err, ErrUnsupportedVersion)
+
+	_, err = bin.Load(bytes.NewReader([]byte("GIF89a")), graph.StringHash)
+	is.ErrorAs(err, &pe)
+
+	// A corrupt length prefix does not reserve the memory it announces.
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	prefix := binary.AppendUvarint([]byte(binaryMagic+"\x01"), binaryMaxLength)
+	_, err = bin.Load(bytes.NewReader(append(prefix, "short"...)), graph.StringHash)
+	runtime.ReadMemStats(&after)
+	is.ErrorIs(err, io.ErrUnexpectedEOF)
+	is.Less(after.TotalAlloc-before.TotalAlloc, uint64(binaryMaxLength/4))
+
+	// ReadGraph leaves the graph unchanged when the snapshot is corrupt or
+	// truncated.
+	for _, bad := range [][]byte{corrupt, snapshot[:len(snapshot)-10]} {
+		h, _ := simple.New(graph.StringHash, graph.Directed())
+		is.Error(bin.ReadGraph(bytes.NewReader(bad), h))
+		order, _ := h.Order()
+		is.Zero(order)
+	}
+
+	// Deeply nested values are rejected instead of exhausting the stack.
+	key, _ := bin.keyCodec.Marshal("A")
+	value, _ := bin.valueCodec.Marshal("A")
+	header := binary.AppendUvarint(binary.AppendUvarint([]byte{binaryDirected}, 1), 0)
+	nested := binary.AppendUvarint([]byte(binaryMagic+"\x01"), uint64(len(header)))
+	nested = append(nested, header...)
+	nested = appendBinaryBytes(appendBinaryBytes(nested, key), value)
+	nested = append(nested, binaryHasMetadata)
+	nested = append(nested, bytes.Repeat([]byte{binaryList, 1}, 1_000_000)...)
+	_, err = bin.Load(bytes.NewReader(nested), graph.StringHash)
+	is.ErrorAs(err, &pe)
+	is.ErrorContains(err, "nested")
+
+	// Values nested up to the limit round-trip; deeper ones cannot be
+	// written.
+	var deep any = "leaf"
+	for range binaryMaxDepth {
+		deep = []any{deep}
+	}
+	h, _ := simple.New(graph.StringHash)
+	is.NoError(h.AddVertexWithOptions("A", simple.VertexMetadata(deep)))
+	buf.Reset()
+	is.NoError(bin.WriteGraph(&buf, h))
+	l, err := bin.Load(&buf, graph.StringHash)
+	is.NoError(err)
+	a, _ := l.Vertex("A")
+	is.Equal(deep, a.Properties().Metadata())
+
+	h, _ = simple.New(graph.StringHash)
+	is.NoError(h.AddVertexWithOptions("A", simple.VertexMetadata([]any{deep})))
+	is.Error(bin.WriteGraph(io.Discard, h))
+
+	// Values of unsupported types cannot be written.
+	h, _ = simple.New(graph.StringHash)
+	is.NoError(h.AddVertexWithOptions("A", simple.VertexMetadata(struct{}{})))
+	is.Error(bin.WriteGraph(io.Discard, h))
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/sixafter/graph"
+)
+
+// Codec converts values of type T to and from their serialized form. Formats
+// use a Codec for vertex values and, where needed, vertex keys.
+type Codec[T any] interface {
+	// Marshal returns the serialized form of v.
+	Marshal(v T) ([]byte, error)
+
+	// Unmarshal parses data and returns the value it represents.
+	Unmarshal(data []byte) (T, error)
+}
+
+// jsonCodec is a Codec backed by encoding/json.
+type jsonCodec[T any] struct{}
+
+// JSONCodec returns a Codec that uses encoding/json. It is the default value
+// codec of the node-link format.
+func JSONCodec[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+func (jsonCodec[T]) Marshal(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec[T]) Unmarshal(data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+// orderedCodec is a compact binary Codec for the kinds allowed by
+// graph.Ordered.
+type orderedCodec[K graph.Ordered] struct{}
+
+// OrderedCodec returns a compact binary Codec for strings, integers and
+// floating-point numbers, including named types based on them. Strings are
+// stored as raw bytes, integers as varints and floats as IEEE 754 bits. It is
+// the default key codec of the binary format.
+func OrderedCodec[K graph.Ordered]() Codec[K] {
+	return orderedCodec[K]{}
+}
+
+func (orderedCodec[K]) Marshal(v K) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return []byte(rv.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(nil, rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(nil, rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return binary.LittleEndian.AppendUint64(nil, math.Float64bits(rv.Float())), nil
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", rv.Kind())
+	}
+}
+
+func (orderedCodec[K]) Unmarshal(data []byte) (K, error) {
+	var v K
+	rv := reflect.ValueOf(&v).Elem()
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(string(data))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, n := binary.Varint(data)
+		if n <= 0 || n != len(data) {
+			return v, errors.New("invalid varint")
+		}
+		rv.SetInt(x)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, n := binary.Uvarint(data)
+		if n <= 0 || n != len(data) {
+			return v, errors.New("invalid uvarint")
+		}
+		rv.SetUint(x)
+	case reflect.Float32, reflect.Float64:
+		if len(data) != 8 {
+			return v, errors.New("invalid float")
+		}
+		rv.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+	default:
+		return v, fmt.Errorf("unsupported kind %s", rv.Kind())
+	}
+
+	return v, nil
+}
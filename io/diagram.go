@@ -0,0 +1,178 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/sixafter/graph"
+)
+
+// Direction is the direction in which a diagram is laid out.
+type Direction string
+
+const (
+	// TopDown lays a diagram out from top to bottom.
+	TopDown Direction = "TD"
+
+	// LeftRight lays a diagram out from left to right.
+	LeftRight Direction = "LR"
+)
+
+// diagramOptions holds the settings shared by the diagram writers.
+type diagramOptions[K graph.Ordered, T any] struct {
+	direction      Direction
+	vertexLabel    func(graph.Vertex[K, T]) string
+	edgeLabel      func(graph.Edge[K]) string
+	partition      map[K]int
+	partitionLabel func(int) string
+}
+
+// DiagramOption configures a MermaidWriter or PlantUMLWriter.
+type DiagramOption[K graph.Ordered, T any] func(*diagramOptions[K, T])
+
+// DiagramDirection sets the layout direction. The default is TopDown.
+func DiagramDirection[K graph.Ordered, T any](direction Direction) DiagramOption[K, T] {
+	return func(o *diagramOptions[K, T]) {
+		o.direction = direction
+	}
+}
+
+// DiagramVertexLabel sets the function used to derive the label of a vertex.
+// The default is DefaultDiagramVertexLabel.
+func DiagramVertexLabel[K graph.Ordered, T any](label func(graph.Vertex[K, T]) string) DiagramOption[K, T] {
+	return func(o *diagramOptions[K, T]) {
+		o.vertexLabel = label
+	}
+}
+
+// DiagramEdgeLabel sets the function used to derive the label of an edge. An
+// empty label is omitted. The default is DefaultDiagramEdgeLabel.
+func DiagramEdgeLabel[K graph.Ordered, T any](label func(graph.Edge[K]) string) DiagramOption[K, T] {
+	return func(o *diagramOptions[K, T]) {
+		o.edgeLabel = label
+	}
+}
+
+// DiagramPartition groups vertices into subgraphs by community, using a
+// partition of the form accepted by metrics.Modularity. Vertices missing from
+// the partition are drawn outside of any group.
+func DiagramPartition[K graph.Ordered, T any](partition map[K]int) DiagramOption[K, T] {
+	return func(o *diagramOptions[K, T]) {
+		o.partition = partition
+	}
+}
+
+// DiagramPartitionLabel sets the function used to derive the title of the
+// group drawn for a community. The default formats the community number.
+func DiagramPartitionLabel[K graph.Ordered, T any](label func(community int) string) DiagramOption[K, T] {
+	return func(o *diagramOptions[K, T]) {
+		o.partitionLabel = label
+	}
+}
+
+// DefaultDiagramVertexLabel labels a vertex with its value, formatted with
+// fmt.Sprint.
+func DefaultDiagramVertexLabel[K graph.Ordered, T any](v graph.Vertex[K, T]) string {
+	return fmt.Sprint(v.Value())
+}
+
+// DefaultDiagramEdgeLabel labels an edge with its "label" item if it has one,
+// and otherwise with its weight if that is non-zero.
+func DefaultDiagramEdgeLabel[K graph.Ordered](e graph.Edge[K]) string {
+	p := e.Properties()
+	if p == nil {
+		return ""
+	}
+
+	if label, ok := p.Items()["label"]; ok {
+		return fmt.Sprint(label)
+	}
+
+	if p.Weight() != 0 {
+		return strconv.FormatFloat(p.Weight(), 'g', -1, 64)
+	}
+
+	return ""
+}
+
+// newDiagramOptions applies options on top of the defaults.
+func newDiagramOptions[K graph.Ordered, T any](options []DiagramOption[K, T]) diagramOptions[K, T] {
+	o := diagramOptions[K, T]{
+		direction:      TopDown,
+		vertexLabel:    DefaultDiagramVertexLabel[K, T],
+		edgeLabel:      DefaultDiagramEdgeLabel[K],
+		partitionLabel: strconv.Itoa,
+	}
+
+	for _, option := range options {
+		option(&o)
+	}
+
+	return o
+}
+
+// diagram is a graph prepared for writing: vertices are split into groups and
+// every vertex is given a node ID that is safe to use in any diagram syntax,
+// whatever its key.
+type diagram[K graph.Ordered, T any] struct {
+	ids        map[K]string
+	ungrouped  []graph.Vertex[K, T]
+	groups     []int
+	members    map[int][]graph.Vertex[K, T]
+	edges      []graph.Edge[K]
+	isDirected bool
+}
+
+// prepare lists the vertices and edges of g in key order and groups the
+// vertices by partition.
+func (o *diagramOptions[K, T]) prepare(g graph.Interface[K, T]) (*diagram[K, T], error) {
+	vertices, err := g.Vertices()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	sort.SliceStable(vertices, func(i, j int) bool {
+		return vertices[i].ID() < vertices[j].ID()
+	})
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].Source() == edges[j].Source() {
+			return edges[i].Target() < edges[j].Target()
+		}
+		return edges[i].Source() < edges[j].Source()
+	})
+
+	d := &diagram[K, T]{
+		ids:        make(map[K]string, len(vertices)),
+		members:    make(map[int][]graph.Vertex[K, T]),
+		edges:      edges,
+		isDirected: g.Traits().IsDirected,
+	}
+
+	for i, vertex := range vertices {
+		d.ids[vertex.ID()] = "n" + strconv.Itoa(i)
+
+		community, ok := o.partition[vertex.ID()]
+		if !ok {
+			d.ungrouped = append(d.ungrouped, vertex)
+			continue
+		}
+		if _, seen := d.members[community]; !seen {
+			d.groups = append(d.groups, community)
+		}
+		d.members[community] = append(d.members[community], vertex)
+	}
+	sort.Ints(d.groups)
+
+	return d, nil
+}
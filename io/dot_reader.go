@@ -0,0 +1,909 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+)
+
+// DOTReader reads graphs written in the Graphviz DOT language into an existing
+// graph, typically one created with simple.New.
+//
+// It supports `graph`, `digraph` and `strict` graphs, node, edge and attribute
+// statements, attribute lists, subgraphs and edge chains such as `a -> b -> c`
+// or `a -> {b c}`. Node and edge attributes are stored as string values with
+// simple.VertexItems and simple.EdgeItems; a numeric `weight` attribute is
+// stored with simple.VertexWeight or simple.EdgeWeight instead.
+//
+// DOT node IDs are converted into vertex values with the parse function given
+// to NewDOTReader. Repeated node statements merge their attributes into the
+// existing vertex, and repeated edges merge their attributes into the existing
+// edge. A multigraph instead gets a parallel edge for each repeated edge,
+// unless the document is strict.
+//
+// Malformed input is reported as a *ParseError carrying the line and column
+// of the offending token.
+//
+// Example:
+//
+//	g, _ := simple.New(graph.StringHash, graph.Directed())
+//	r := io.NewDOTReader[string, string](func(id string) (string, error) { return id, nil })
+//	if err := r.ReadGraph(f, g); err != nil {
+//		log.Fatal(err)
+//	}
+type DOTReader[K graph.Ordered, T any] struct {
+	parse func(id string) (T, error)
+}
+
+// NewDOTReader creates a DOTReader that uses parse to convert DOT node IDs
+// into vertex values.
+func NewDOTReader[K graph.Ordered, T any](parse func(id string) (T, error)) *DOTReader[K, T] {
+	return &DOTReader[K, T]{
+		parse: parse,
+	}
+}
+
+// ReadGraph parses a DOT document from r and adds its vertices and edges to g.
+// It returns graph.ErrGraphTypeMismatch if the document is a `digraph` and g
+// is undirected, or vice versa.
+func (d *DOTReader[K, T]) ReadGraph(r io.Reader, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	p := &dotParser[K, T]{
+		lexer:  newDOTLexer(src),
+		g:      g,
+		parse:  d.parse,
+		keys:   make(map[string]K),
+		values: make(map[string]T),
+	}
+
+	if err = p.next(); err != nil {
+		return err
+	}
+
+	return p.parseGraph()
+}
+
+// dotTokenKind identifies the kind of a DOT token.
+type dotTokenKind int
+
+const (
+	dotEOF dotTokenKind = iota
+	dotID
+	dotLBrace
+	dotRBrace
+	dotLBracket
+	dotRBracket
+	dotEqual
+	dotSemicolon
+	dotComma
+	dotColon
+	dotPlus
+	dotEdgeOp
+)
+
+// dotPunctuation maps single-character tokens to their kinds.
+var dotPunctuation = map[byte]dotTokenKind{
+	'{': dotLBrace,
+	'}': dotRBrace,
+	'[': dotLBracket,
+	']': dotRBracket,
+	'=': dotEqual,
+	';': dotSemicolon,
+	',': dotComma,
+	':': dotColon,
+	'+': dotPlus,
+}
+
+// dotToken is a lexical token along with its position in the input.
+type dotToken struct {
+	kind   dotTokenKind
+	text   string
+	quoted bool
+	line   int
+	column int
+}
+
+// dotLexer splits DOT source into tokens, skipping whitespace and comments.
+type dotLexer struct {
+	src    []byte
+	offset int
+	line   int
+	column int
+}
+
+func newDOTLexer(src []byte) *dotLexer {
+	return &dotLexer{
+		src:    src,
+		line:   1,
+		column: 1,
+	}
+}
+
+// dotErrorf returns a *ParseError at the given position.
+func dotErrorf(line, column int, format string, args ...any) error {
+	return &ParseError{
+		Format: "dot",
+		Line:   line,
+		Column: column,
+		Err:    fmt.Errorf(format, args...),
+	}
+}
+
+func (l *dotLexer) peekByte(n int) byte {
+	if l.offset+n < len(l.src) {
+		return l.src[l.offset+n]
+	}
+	return 0
+}
+
+func (l *dotLexer) advance() byte {
+	c := l.src[l.offset]
+	l.offset++
+	if c == '\n' {
+		l.line++
+		l.column = 1
+	} else {
+		l.column++
+	}
+	return c
+}
+
+// skip consumes whitespace, comments and preprocessor output lines.
+func (l *dotLexer) skip() error {
+	for l.offset < len(l.src) {
+		c := l.peekByte(0)
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			l.advance()
+		case c == '#' && l.column == 1:
+			for l.offset < len(l.src) && l.peekByte(0) != '\n' {
+				l.advance()
+			}
+		case c == '/' && l.peekByte(1) == '/':
+			for l.offset < len(l.src) && l.peekByte(0) != '\n' {
+				l.advance()
+			}
+		case c == '/' && l.peekByte(1) == '*':
+			line, column := l.line, l.column
+			l.advance()
+			l.advance()
+			for {
+				if l.offset >= len(l.src) {
+					return dotErrorf(line, column, "unterminated comment")
+				}
+				if l.peekByte(0) == '*' && l.peekByte(1) == '/' {
+					l.advance()
+					l.advance()
+					break
+				}
+				l.advance()
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// next returns the next token in the input.
+func (l *dotLexer) next() (dotToken, error) {
+	if err := l.skip(); err != nil {
+		return dotToken{}, err
+	}
+
+	tok := dotToken{line: l.line, column: l.column}
+	if l.offset >= len(l.src) {
+		tok.kind = dotEOF
+		return tok, nil
+	}
+
+	c := l.peekByte(0)
+
+	switch {
+	case c == '-' && (l.peekByte(1) == '>' || l.peekByte(1) == '-'):
+		l.advance()
+		op := l.advance()
+		tok.kind = dotEdgeOp
+		tok.text = "-" + string(op)
+	case dotPunctuation[c] != dotEOF:
+		l.advance()
+		tok.kind = dotPunctuation[c]
+		tok.text = string(c)
+	case c == '"':
+		text, err := l.quoted()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind = dotID
+		tok.text = text
+		tok.quoted = true
+	case c == '<':
+		text, err := l.html()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind = dotID
+		tok.text = text
+		tok.quoted = true
+	case c == '-' || c == '.' || isDOTDigit(c):
+		text, err := l.numeral()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind = dotID
+		tok.text = text
+	case isDOTIdentStart(c):
+		start := l.offset
+		for l.offset < len(l.src) && isDOTIdentPart(l.peekByte(0)) {
+			l.advance()
+		}
+		tok.kind = dotID
+		tok.text = string(l.src[start:l.offset])
+	default:
+		return tok, dotErrorf(l.line, l.column, "unexpected character %q", c)
+	}
+
+	return tok, nil
+}
+
+// quoted reads a double-quoted string. Only `\"`, `\\` and escaped newlines
+// are interpreted; other escape sequences are kept verbatim as Graphviz does.
+func (l *dotLexer) quoted() (string, error) {
+	line, column := l.line, l.column
+	l.advance()
+
+	var sb strings.Builder
+	for {
+		if l.offset >= len(l.src) {
+			return "", dotErrorf(line, column, "unterminated string")
+		}
+
+		c := l.advance()
+		switch {
+		case c == '"':
+			return sb.String(), nil
+		case c == '\\' && (l.peekByte(0) == '"' || l.peekByte(0) == '\\'):
+			sb.WriteByte(l.advance())
+		case c == '\\' && l.peekByte(0) == '\n':
+			l.advance()
+		case c == '\\' && l.peekByte(0) == '\r' && l.peekByte(1) == '\n':
+			l.advance()
+			l.advance()
+		default:
+			sb.WriteByte(c)
+		}
+	}
+}
+
+// html reads an HTML string delimited by balanced angle brackets and returns
+// its content without the outermost brackets.
+func (l *dotLexer) html() (string, error) {
+	line, column := l.line, l.column
+	l.advance()
+
+	start := l.offset
+	depth := 1
+	for {
+		if l.offset >= len(l.src) {
+			return "", dotErrorf(line, column, "unterminated HTML string")
+		}
+
+		switch l.advance() {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth == 0 {
+				return string(l.src[start : l.offset-1]), nil
+			}
+		}
+	}
+}
+
+// numeral reads a DOT numeral: [-]?(.[0-9]+ | [0-9]+(.[0-9]*)?).
+func (l *dotLexer) numeral() (string, error) {
+	line, column := l.line, l.column
+	start := l.offset
+
+	if l.peekByte(0) == '-' {
+		l.advance()
+	}
+
+	digits := 0
+	for isDOTDigit(l.peekByte(0)) {
+		l.advance()
+		digits++
+	}
+	if l.peekByte(0) == '.' {
+		l.advance()
+		for isDOTDigit(l.peekByte(0)) {
+			l.advance()
+			digits++
+		}
+	}
+
+	if digits == 0 {
+		return "", dotErrorf(line, column, "invalid numeral %q", string(l.src[start:l.offset]))
+	}
+	if isDOTIdentStart(l.peekByte(0)) {
+		return "", dotErrorf(l.line, l.column, "unexpected character %q after numeral", l.peekByte(0))
+	}
+
+	return string(l.src[start:l.offset]), nil
+}
+
+func isDOTDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isDOTIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isDOTIdentPart(c byte) bool {
+	return isDOTIdentStart(c) || isDOTDigit(c)
+}
+
+// dotScope holds the default node and edge attributes in effect for a graph
+// or subgraph body.
+type dotScope struct {
+	node map[string]string
+	edge map[string]string
+}
+
+// child returns a copy of the scope for a nested subgraph, so that attribute
+// statements inside the subgraph do not leak out of it.
+func (s *dotScope) child() *dotScope {
+	c := &dotScope{
+		node: make(map[string]string, len(s.node)),
+		edge: make(map[string]string, len(s.edge)),
+	}
+	for k, v := range s.node {
+		c.node[k] = v
+	}
+	for k, v := range s.edge {
+		c.edge[k] = v
+	}
+	return c
+}
+
+// dotParser is a recursive-descent parser that applies DOT statements to a
+// graph as they are parsed.
+type dotParser[K graph.Ordered, T any] struct {
+	lexer    *dotLexer
+	tok      dotToken
+	g        graph.Interface[K, T]
+	parse    func(id string) (T, error)
+	keys     map[string]K
+	values   map[string]T
+	directed bool
+	strict   bool
+}
+
+func (p *dotParser[K, T]) next() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *dotParser[K, T]) errorf(format string, args ...any) error {
+	return dotErrorf(p.tok.line, p.tok.column, format, args...)
+}
+
+// keyword reports whether the current token is the unquoted keyword kw.
+// DOT keywords are case-insensitive.
+func (p *dotParser[K, T]) keyword(kw string) bool {
+	return p.tok.kind == dotID && !p.tok.quoted && strings.EqualFold(p.tok.text, kw)
+}
+
+func (p *dotParser[K, T]) expect(kind dotTokenKind, what string) error {
+	if p.tok.kind != kind {
+		return p.errorf("expected %s, found %s", what, p.describe())
+	}
+	return p.next()
+}
+
+func (p *dotParser[K, T]) describe() string {
+	if p.tok.kind == dotEOF {
+		return "end of input"
+	}
+	return strconv.Quote(p.tok.text)
+}
+
+// id consumes an ID, joining quoted strings concatenated with '+'.
+func (p *dotParser[K, T]) id() (string, error) {
+	if p.tok.kind != dotID {
+		return "", p.errorf("expected identifier, found %s", p.describe())
+	}
+
+	text := p.tok.text
+	quoted := p.tok.quoted
+	if err := p.next(); err != nil {
+		return "", err
+	}
+
+	for quoted && p.tok.kind == dotPlus {
+		if err := p.next(); err != nil {
+			return "", err
+		}
+		if p.tok.kind != dotID || !p.tok.quoted {
+			return "", p.errorf("expected quoted string after '+', found %s", p.describe())
+		}
+		text += p.tok.text
+		if err := p.next(); err != nil {
+			return "", err
+		}
+	}
+
+	return text, nil
+}
+
+// parseGraph parses: [strict] (graph | digraph) [ID] '{' stmt_list '}'.
+func (p *dotParser[K, T]) parseGraph() error {
+	// Strict graphs forbid parallel edges, so repeated edges are merged even
+	// into a multigraph.
+	if p.keyword("strict") {
+		p.strict = true
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case p.keyword("graph"):
+		p.directed = false
+	case p.keyword("digraph"):
+		p.directed = true
+	default:
+		return p.errorf("expected \"graph\" or \"digraph\", found %s", p.describe())
+	}
+
+	if p.directed != p.g.Traits().IsDirected {
+		return p.errorf("%w: document is a %s", graph.ErrGraphTypeMismatch, p.tok.text)
+	}
+
+	if err := p.next(); err != nil {
+		return err
+	}
+
+	if p.tok.kind == dotID {
+		if _, err := p.id(); err != nil {
+			return err
+		}
+	}
+
+	if err := p.expect(dotLBrace, "'{'"); err != nil {
+		return err
+	}
+
+	scope := &dotScope{
+		node: make(map[string]string),
+		edge: make(map[string]string),
+	}
+	if _, err := p.parseStatements(scope); err != nil {
+		return err
+	}
+
+	if err := p.expect(dotRBrace, "'}'"); err != nil {
+		return err
+	}
+
+	if p.tok.kind != dotEOF {
+		return p.errorf("unexpected %s after graph", p.describe())
+	}
+
+	return nil
+}
+
+// parseStatements parses a statement list up to, but not including, the
+// closing brace and returns the IDs of all nodes referenced in it.
+func (p *dotParser[K, T]) parseStatements(scope *dotScope) ([]string, error) {
+	var nodes []string
+	seen := make(map[string]struct{})
+	add := func(ids []string) {
+		for _, id := range ids {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				nodes = append(nodes, id)
+			}
+		}
+	}
+
+	for p.tok.kind != dotRBrace {
+		if p.tok.kind == dotEOF {
+			return nil, p.errorf("expected '}', found end of input")
+		}
+
+		ids, err := p.parseStatement(scope)
+		if err != nil {
+			return nil, err
+		}
+		add(ids)
+
+		if p.tok.kind == dotSemicolon {
+			if err = p.next(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nodes, nil
+}
+
+// parseStatement parses a single statement and returns the IDs of the nodes
+// it references.
+func (p *dotParser[K, T]) parseStatement(scope *dotScope) ([]string, error) {
+	switch {
+	case p.keyword("graph"), p.keyword("node"), p.keyword("edge"):
+		kind := strings.ToLower(p.tok.text)
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		attributes, err := p.parseAttributeLists()
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case "node":
+			mergeAttributes(scope.node, attributes)
+		case "edge":
+			mergeAttributes(scope.edge, attributes)
+		}
+		return nil, nil
+
+	case p.keyword("subgraph"), p.tok.kind == dotLBrace:
+		ids, err := p.parseSubgraph(scope)
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind == dotEdgeOp {
+			return p.parseEdges(scope, ids)
+		}
+		return ids, nil
+
+	case p.tok.kind == dotID:
+		line, column := p.tok.line, p.tok.column
+		id, err := p.id()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind == dotEqual {
+			// Graph attribute assignment such as rankdir=LR.
+			if err = p.next(); err != nil {
+				return nil, err
+			}
+			_, err = p.id()
+			return nil, err
+		}
+
+		if err = p.parsePort(); err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind == dotEdgeOp {
+			return p.parseEdges(scope, []string{id})
+		}
+
+		attributes, err := p.parseAttributeLists()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.node(id, scope, attributes, line, column); err != nil {
+			return nil, err
+		}
+		return []string{id}, nil
+
+	default:
+		return nil, p.errorf("unexpected %s", p.describe())
+	}
+}
+
+// parseSubgraph parses: [subgraph [ID]] '{' stmt_list '}'.
+func (p *dotParser[K, T]) parseSubgraph(scope *dotScope) ([]string, error) {
+	if p.keyword("subgraph") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == dotID {
+			if _, err := p.id(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := p.expect(dotLBrace, "'{'"); err != nil {
+		return nil, err
+	}
+
+	ids, err := p.parseStatements(scope.child())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.expect(dotRBrace, "'}'"); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// parsePort skips an optional node port: ':' ID [':' ID].
+func (p *dotParser[K, T]) parsePort() error {
+	for i := 0; i < 2 && p.tok.kind == dotColon; i++ {
+		if err := p.next(); err != nil {
+			return err
+		}
+		if _, err := p.id(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseEdges parses the right-hand side of an edge statement whose first
+// operand has already been parsed, then creates an edge between every node of
+// each operand and every node of the following operand.
+func (p *dotParser[K, T]) parseEdges(scope *dotScope, first []string) ([]string, error) {
+	type operand struct {
+		ids          []string
+		line, column int
+	}
+
+	operands := []operand{{ids: first, line: p.tok.line, column: p.tok.column}}
+	referenced := append([]string(nil), first...)
+
+	for p.tok.kind == dotEdgeOp {
+		if p.directed && p.tok.text != "->" {
+			return nil, p.errorf("undirected edge operator \"--\" in digraph")
+		}
+		if !p.directed && p.tok.text != "--" {
+			return nil, p.errorf("directed edge operator \"->\" in graph")
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+
+		line, column := p.tok.line, p.tok.column
+		var ids []string
+		if p.keyword("subgraph") || p.tok.kind == dotLBrace {
+			var err error
+			if ids, err = p.parseSubgraph(scope); err != nil {
+				return nil, err
+			}
+		} else {
+			id, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.parsePort(); err != nil {
+				return nil, err
+			}
+			ids = []string{id}
+		}
+
+		operands = append(operands, operand{ids: ids, line: line, column: column})
+		referenced = append(referenced, ids...)
+	}
+
+	attributes, err := p.parseAttributeLists()
+	if err != nil {
+		return nil, err
+	}
+
+	edgeAttributes := make(map[string]string, len(scope.edge)+len(attributes))
+	mergeAttributes(edgeAttributes, scope.edge)
+	mergeAttributes(edgeAttributes, attributes)
+
+	for i := 0; i+1 < len(operands); i++ {
+		for _, source := range operands[i].ids {
+			if err = p.node(source, scope, nil, operands[i].line, operands[i].column); err != nil {
+				return nil, err
+			}
+			for _, target := range operands[i+1].ids {
+				if err = p.node(target, scope, nil, operands[i+1].line, operands[i+1].column); err != nil {
+					return nil, err
+				}
+				if err = p.edge(source, target, edgeAttributes, operands[i].line, operands[i].column); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return referenced, nil
+}
+
+// parseAttributeLists parses zero or more attribute lists:
+// '[' [ID '=' ID [(';' | ',')]]... ']'.
+func (p *dotParser[K, T]) parseAttributeLists() (map[string]string, error) {
+	attributes := make(map[string]string)
+
+	for p.tok.kind == dotLBracket {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+
+		for p.tok.kind != dotRBracket {
+			name, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+
+			if err = p.expect(dotEqual, "'='"); err != nil {
+				return nil, err
+			}
+
+			value, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+			attributes[name] = value
+
+			if p.tok.kind == dotComma || p.tok.kind == dotSemicolon {
+				if err = p.next(); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+
+	return attributes, nil
+}
+
+// node ensures a vertex exists for the DOT node id. New vertices receive the
+// scope's default node attributes; attributes are merged into the vertex.
+func (p *dotParser[K, T]) node(id string, scope *dotScope, attributes map[string]string, line, column int) error {
+	wrap := func(err error) error {
+		return &ParseError{Format: "dot", Line: line, Column: column, Err: err}
+	}
+
+	if _, ok := p.keys[id]; !ok {
+		value, err := p.parse(id)
+		if err != nil {
+			return wrap(fmt.Errorf("invalid node ID %q: %w", id, err))
+		}
+
+		key := p.g.Hash()(value)
+		p.keys[id] = key
+		p.values[id] = value
+
+		exists, err := p.g.HasVertex(key)
+		if err != nil {
+			return wrap(err)
+		}
+
+		if !exists {
+			merged := make(map[string]string, len(scope.node)+len(attributes))
+			mergeAttributes(merged, scope.node)
+			mergeAttributes(merged, attributes)
+
+			items, weight, hasWeight := dotProperties(merged)
+			options := []graph.VertexOption{simple.VertexItems(items)}
+			if hasWeight {
+				options = append(options, simple.VertexWeight(weight))
+			}
+
+			if err = p.g.AddVertexWithOptions(value, options...); err != nil {
+				return wrap(err)
+			}
+			return nil
+		}
+	}
+
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	items, weight, hasWeight := dotProperties(attributes)
+	options := make([]graph.VertexOption, 0, len(items)+1)
+	for k, v := range items {
+		options = append(options, simple.VertexItem(k, v))
+	}
+	if hasWeight {
+		options = append(options, simple.VertexWeight(weight))
+	}
+
+	if err := p.g.SetVertexWithOptions(p.values[id], options...); err != nil {
+		return wrap(err)
+	}
+
+	return nil
+}
+
+// edge adds an edge between two DOT nodes. If the graph already has one, the
+// attributes are merged into it, unless the graph is a multigraph and the
+// document is not strict, in which case a parallel edge is added.
+func (p *dotParser[K, T]) edge(source, target string, attributes map[string]string, line, column int) error {
+	wrap := func(err error) error {
+		return &ParseError{Format: "dot", Line: line, Column: column, Err: err}
+	}
+
+	s, t := p.keys[source], p.keys[target]
+	items, weight, hasWeight := dotProperties(attributes)
+
+	exists := false
+	if p.strict || !p.g.Traits().IsMultiGraph {
+		_, err := p.g.Edge(s, t)
+		if err != nil && !errors.Is(err, graph.ErrEdgeNotFound) {
+			return wrap(err)
+		}
+		exists = err == nil
+	}
+
+	if !exists {
+		options := []graph.EdgeOption{simple.EdgeItems(items)}
+		if hasWeight {
+			options = append(options, simple.EdgeWeight(weight))
+		}
+		if err := p.g.AddEdgeWithOptions(s, t, options...); err != nil {
+			return wrap(err)
+		}
+		return nil
+	}
+
+	options := make([]graph.EdgeOption, 0, len(items)+1)
+	for k, v := range items {
+		options = append(options, simple.EdgeItem(k, v))
+	}
+	if hasWeight {
+		options = append(options, simple.EdgeWeight(weight))
+	}
+
+	if err := p.g.SetEdgeWithOptions(s, t, options...); err != nil {
+		return wrap(err)
+	}
+
+	return nil
+}
+
+// dotProperties splits DOT attributes into string items and a numeric weight.
+func dotProperties(attributes map[string]string) (map[string]any, float64, bool) {
+	items := make(map[string]any, len(attributes))
+	var weight float64
+	var hasWeight bool
+
+	for k, v := range attributes {
+		if k == "weight" {
+			if w, err := strconv.ParseFloat(v, 64); err == nil {
+				weight, hasWeight = w, true
+				continue
+			}
+		}
+		items[k] = v
+	}
+
+	return items, weight, hasWeight
+}
+
+// mergeAttributes copies every attribute of src into dst.
+func mergeAttributes(dst, src map[string]string) {
+	for k, v := range src {
+		dst[k] = v
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseStringID(id string) (string, error) {
+	return id, nil
+}
+
+func TestDOTReader_Digraph(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := `
+// A small dependency graph.
+strict digraph "deps" {
+	rankdir=LR;
+	node [shape=box];
+	a [label="Alpha", weight=2];
+	a -> b -> c [weight=1.5, color=red];
+	c -> {d e}
+	/* Repeated edge merges attributes. */
+	a -> b [style=dashed]
+	"quoted " + "id" -> a
+}
+`
+	g, _ := simple.New(graph.StringHash, graph.Directed())
+	r := NewDOTReader[string, string](parseStringID)
+	is.NoError(r.ReadGraph(strings.NewReader(src), g))
+
+	order, _ := g.Order()
+	is.Equal(6, order)
+	size, _ := g.Size()
+	is.Equal(5, size)
+
+	a, err := g.Vertex("a")
+	is.NoError(err)
+	is.Equal("Alpha", a.Properties().Items()["label"])
+	is.Equal("box", a.Properties().Items()["shape"])
+	is.Equal(float64(2), a.Properties().Weight())
+
+	ab, err := g.Edge("a", "b")
+	is.NoError(err)
+	is.Equal(1.5, ab.Properties().Weight())
+	is.Equal("red", ab.Properties().Items()["color"])
+	is.Equal("dashed", ab.Properties().Items()["style"])
+
+	ok, _ := g.HasEdge("c", "e")
+	is.True(ok)
+	ok, _ = g.HasEdge("quoted id", "a")
+	is.True(ok)
+}
+
+func TestDOTReader_SubgraphScope(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := `graph {
+	subgraph cluster_0 {
+		node [color=blue];
+		x -- y;
+	}
+	z;
+	{x y} -- z;
+}`
+	g, _ := simple.New(graph.StringHash)
+	is.NoError(NewDOTReader[string, string](parseStringID).ReadGraph(strings.NewReader(src), g))
+
+	x, _ := g.Vertex("x")
+	is.Equal("blue", x.Properties().Items()["color"])
+	z, _ := g.Vertex("z")
+	is.NotContains(z.Properties().Items(), "color")
+
+	size, _ := g.Size()
+	is.Equal(3, size)
+}
+
+func TestDOTReader_ParseFunction(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.IntHash, graph.Directed())
+	r := NewDOTReader[int, int](strconv.Atoi)
+	is.NoError(r.ReadGraph(strings.NewReader("digraph { 1 -> 2 -> 3 }"), g))
+
+	ok, _ := g.HasEdge(2, 3)
+	is.True(ok)
+
+	err := r.ReadGraph(strings.NewReader("digraph {\n  1 -> x\n}"), g)
+	var perr *ParseError
+	is.True(errors.As(err, &perr))
+	is.Equal(2, perr.Line)
+}
+
+func TestDOTReader_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		src    string
+		line   int
+		column int
+	}{
+		{name: "missing brace", src: "digraph {\n  a -> b\n", line: 3, column: 1},
+		{name: "bad edge operator", src: "digraph {\n  a -- b\n}", line: 2, column: 5},
+		{name: "bad attribute", src: "digraph {\n  a [color]\n}", line: 2, column: 11},
+		{name: "unterminated string", src: "digraph {\n  \"a -> b\n}", line: 2, column: 3},
+		{name: "unexpected character", src: "digraph {\n  a -> b @\n}", line: 2, column: 10},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			g, _ := simple.New(graph.StringHash, graph.Directed())
+			err := NewDOTReader[string, string](parseStringID).ReadGraph(strings.NewReader(tc.src), g)
+
+			var perr *ParseError
+			is.True(errors.As(err, &perr), "expected a ParseError, got %v", err)
+			if perr != nil {
+				is.Equal(tc.line, perr.Line, err.Error())
+				is.Equal(tc.column, perr.Column, err.Error())
+			}
+		})
+	}
+}
+
+func TestDOTReader_TypeMismatch(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash)
+	err := NewDOTReader[string, string](parseStringID).ReadGraph(strings.NewReader("digraph { a -> b }"), g)
+	is.ErrorIs(err, graph.ErrGraphTypeMismatch)
+}
+
+func TestDOTReader_ParallelEdges(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	read := func(src string, options ...func(*graph.Traits)) graph.Interface[string, string] {
+		g, _ := simple.New(graph.StringHash, append(options, graph.Directed())...)
+		is.NoError(NewDOTReader[string, string](parseStringID).ReadGraph(strings.NewReader(src), g))
+		return g
+	}
+
+	// A multigraph keeps the parallel edges of a non-strict document.
+	g := read(`digraph { a -> b [color=red]; a -> b [weight=2] }`, graph.MultiGraph())
+	edges, err := g.(graph.Multigraph[string, string]).EdgesBetween("a", "b")
+	is.NoError(err)
+	is.Len(edges, 2)
+	is.Equal("red", edges[0].Properties().Items()["color"])
+	is.Equal(float64(2), edges[1].Properties().Weight())
+
+	// Strict documents and other graphs merge them.
+	for _, g := range []graph.Interface[string, string]{
+		read(`strict digraph { a -> b [color=red]; a -> b [weight=2] }`, graph.MultiGraph()),
+		read(`digraph { a -> b [color=red]; a -> b [weight=2] }`),
+	} {
+		size, _ := g.Size()
+		is.Equal(1, size)
+		e, err := g.Edge("a", "b")
+		is.NoError(err)
+		is.Equal("red", e.Properties().Items()["color"])
+		is.Equal(float64(2), e.Properties().Weight())
+	}
+}
+
+func TestDOTReader_RoundTrip(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+	is.NoError(g.AddVertexWithOptions("A", simple.VertexItem("color", "red")))
+	is.NoError(g.AddVertexWithOptions("B", simple.VertexItem("label", `say \"hi\" in C:\`)))
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(3), simple.EdgeItem("kind", "dep")))
+
+	var buf bytes.Buffer
+	is.NoError(NewDOTWriter[string, string]().WriteGraph(&buf, g))
+	is.Contains(buf.String(), `"B" ["label"="say \\\"hi\\\" in C:\\"];`)
+
+	h, _ := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+	is.NoError(NewDOTReader[string, string](parseStringID).ReadGraph(&buf, h))
+
+	e, err := h.Edge("A", "B")
+	is.NoError(err)
+	is.Equal(float64(3), e.Properties().Weight())
+	is.Equal("dep", e.Properties().Items()["kind"])
+
+	a, _ := h.Vertex("A")
+	is.Equal("red", a.Properties().Items()["color"])
+
+	b, _ := h.Vertex("B")
+	is.Equal(`say \"hi\" in C:\`, b.Properties().Items()["label"])
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sixafter/graph"
+)
+
+// DOTWriter writes graphs in the Graphviz DOT language. Directed graphs are
+// written as a `digraph` and undirected graphs as a `graph`, based on
+// Traits().IsDirected.
+//
+// By default, vertex and edge weights are written as a `weight` attribute and
+// every entry of Items() is written as an attribute of the same name. Labels
+// and attributes can be replaced with the DOTVertexLabel, DOTEdgeLabel,
+// DOTVertexAttributes and DOTEdgeAttributes options.
+//
+// The output is stable: vertices are written in key order, edges in (source,
+// target) order and attributes in name order, which makes DOTWriter suitable
+// for golden-file tests.
+//
+// Example:
+//
+//	w := io.NewDOTWriter[string, string](io.DOTGraphName[string, string]("deps"))
+//	if err := w.WriteGraph(os.Stdout, g); err != nil {
+//		log.Fatal(err)
+//	}
+type DOTWriter[K graph.Ordered, T any] struct {
+	name             string
+	graphAttributes  map[string]string
+	vertexID         func(K) string
+	vertexLabel      func(graph.Vertex[K, T]) string
+	edgeLabel        func(graph.Edge[K]) string
+	vertexAttributes func(graph.Vertex[K, T]) map[string]string
+	edgeAttributes   func(graph.Edge[K]) map[string]string
+}
+
+// DOTWriterOption configures a DOTWriter.
+type DOTWriterOption[K graph.Ordered, T any] func(*DOTWriter[K, T])
+
+// NewDOTWriter creates a DOTWriter configured with the given options.
+func NewDOTWriter[K graph.Ordered, T any](options ...DOTWriterOption[K, T]) *DOTWriter[K, T] {
+	w := &DOTWriter[K, T]{
+		vertexID:         func(k K) string { return fmt.Sprint(k) },
+		vertexAttributes: DefaultDOTVertexAttributes[K, T],
+		edgeAttributes:   DefaultDOTEdgeAttributes[K],
+	}
+
+	for _, option := range options {
+		option(w)
+	}
+
+	return w
+}
+
+// DOTGraphName sets the name written after the `graph` or `digraph` keyword.
+func DOTGraphName[K graph.Ordered, T any](name string) DOTWriterOption[K, T] {
+	return func(w *DOTWriter[K, T]) {
+		w.name = name
+	}
+}
+
+// DOTGraphAttributes sets graph-level attributes, such as `rankdir`.
+func DOTGraphAttributes[K graph.Ordered, T any](attributes map[string]string) DOTWriterOption[K, T] {
+	return func(w *DOTWriter[K, T]) {
+		w.graphAttributes = attributes
+	}
+}
+
+// DOTVertexID sets the function used to derive a DOT node ID from a vertex key.
+// The default formats the key with fmt.Sprint.
+func DOTVertexID[K graph.Ordered, T any](id func(K) string) DOTWriterOption[K, T] {
+	return func(w *DOTWriter[K, T]) {
+		w.vertexID = id
+	}
+}
+
+// DOTVertexLabel sets the function used to derive the `label` attribute of a
+// vertex. An empty label is omitted.
+func DOTVertexLabel[K graph.Ordered, T any](label func(graph.Vertex[K, T]) string) DOTWriterOption[K, T] {
+	return func(w *DOTWriter[K, T]) {
+		w.vertexLabel = label
+	}
+}
+
+// DOTEdgeLabel sets the function used to derive the `label` attribute of an
+// edge. An empty label is omitted.
+func DOTEdgeLabel[K graph.Ordered, T any](label func(graph.Edge[K]) string) DOTWriterOption[K, T] {
+	return func(w *DOTWriter[K, T]) {
+		w.edgeLabel = label
+	}
+}
+
+// DOTVertexAttributes sets the function used to derive the attributes of a
+// vertex. It replaces DefaultDOTVertexAttributes.
+func DOTVertexAttributes[K graph.Ordered, T any](attributes func(graph.Vertex[K, T]) map[string]string) DOTWriterOption[K, T] {
+	return func(w *DOTWriter[K, T]) {
+		w.vertexAttributes = attributes
+	}
+}
+
+// DOTEdgeAttributes sets the function used to derive the attributes of an
+// edge. It replaces DefaultDOTEdgeAttributes.
+func DOTEdgeAttributes[K graph.Ordered, T any](attributes func(graph.Edge[K]) map[string]string) DOTWriterOption[K, T] {
+	return func(w *DOTWriter[K, T]) {
+		w.edgeAttributes = attributes
+	}
+}
+
+// DefaultDOTVertexAttributes maps a non-zero vertex weight to a `weight`
+// attribute and every entry of Items() to an attribute of the same name.
+func DefaultDOTVertexAttributes[K graph.Ordered, T any](v graph.Vertex[K, T]) map[string]string {
+	p := v.Properties()
+	if p == nil {
+		return nil
+	}
+
+	return dotAttributes(p.Weight(), p.Items())
+}
+
+// DefaultDOTEdgeAttributes maps a non-zero edge weight to a `weight` attribute
+// and every entry of Items() to an attribute of the same name.
+func DefaultDOTEdgeAttributes[K graph.Ordered](e graph.Edge[K]) map[string]string {
+	p := e.Properties()
+	if p == nil {
+		return nil
+	}
+
+	return dotAttributes(p.Weight(), p.Items())
+}
+
+// WriteGraph writes g to w in the DOT language.
+func (d *DOTWriter[K, T]) WriteGraph(w io.Writer, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	vertices, err := g.Vertices()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	sort.SliceStable(vertices, func(i, j int) bool {
+		return vertices[i].ID() < vertices[j].ID()
+	})
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].Source() == edges[j].Source() {
+			return edges[i].Target() < edges[j].Target()
+		}
+		return edges[i].Source() < edges[j].Source()
+	})
+
+	keyword, operator := "graph", "--"
+	if g.Traits().IsDirected {
+		keyword, operator = "digraph", "->"
+	}
+
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString(keyword)
+	if d.name != "" {
+		bw.WriteByte(' ')
+		bw.WriteString(dotQuote(d.name))
+	}
+	bw.WriteString(" {\n")
+
+	for _, name := range sortedKeys(d.graphAttributes) {
+		fmt.Fprintf(bw, "\t%s=%s;\n", dotQuote(name), dotQuote(d.graphAttributes[name]))
+	}
+
+	for _, vertex := range vertices {
+		attributes := make(map[string]string)
+		if d.vertexAttributes != nil {
+			for k, v := range d.vertexAttributes(vertex) {
+				attributes[k] = v
+			}
+		}
+		if d.vertexLabel != nil {
+			if label := d.vertexLabel(vertex); label != "" {
+				attributes["label"] = label
+			}
+		}
+
+		fmt.Fprintf(bw, "\t%s%s;\n", dotQuote(d.vertexID(vertex.ID())), dotAttributeList(attributes))
+	}
+
+	for _, edge := range edges {
+		attributes := make(map[string]string)
+		if d.edgeAttributes != nil {
+			for k, v := range d.edgeAttributes(edge) {
+				attributes[k] = v
+			}
+		}
+		if d.edgeLabel != nil {
+			if label := d.edgeLabel(edge); label != "" {
+				attributes["label"] = label
+			}
+		}
+
+		fmt.Fprintf(bw, "\t%s %s %s%s;\n",
+			dotQuote(d.vertexID(edge.Source())),
+			operator,
+			dotQuote(d.vertexID(edge.Target())),
+			dotAttributeList(attributes),
+		)
+	}
+
+	bw.WriteString("}\n")
+
+	return bw.Flush()
+}
+
+// dotAttributes converts a weight and an item map into DOT attributes.
+func dotAttributes(weight float64, items map[string]any) map[string]string {
+	attributes := make(map[string]string, len(items)+1)
+	for k, v := range items {
+		attributes[k] = fmt.Sprint(v)
+	}
+
+	if weight != 0 {
+		attributes["weight"] = strconv.FormatFloat(weight, 'g', -1, 64)
+	}
+
+	return attributes
+}
+
+// dotAttributeList formats attributes as a DOT attribute list, sorted by name.
+// It returns an empty string when there are no attributes.
+func dotAttributeList(attributes map[string]string) string {
+	if len(attributes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(" [")
+	for i, name := range sortedKeys(attributes) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(dotQuote(name))
+		sb.WriteByte('=')
+		sb.WriteString(dotQuote(attributes[name]))
+	}
+	sb.WriteByte(']')
+
+	return sb.String()
+}
+
+// dotQuote returns s as a double-quoted DOT ID. Backslashes are escaped
+// before quotes, so that a value containing `\"` is not read back as an
+// escaped quote, and newlines are written as `\n`.
+func dotQuote(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s) + 2)
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '"':
+			sb.WriteString(`\"`)
+		case '\n':
+			sb.WriteString(`\n`)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
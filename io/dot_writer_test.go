@@ -0,0 +1,108 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDOTWriter_Directed(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+	is.NoError(err)
+	is.NoError(g.AddVertexWithOptions("B"))
+	is.NoError(g.AddVertexWithOptions("A", simple.VertexItem("color", "red")))
+	is.NoError(g.AddVertexWithOptions("C", simple.VertexWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "C", simple.EdgeWeight(1.5)))
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(3), simple.EdgeItem("kind", "dep")))
+
+	var buf bytes.Buffer
+	w := NewDOTWriter[string, string](DOTGraphName[string, string]("deps"))
+	is.NoError(w.WriteGraph(&buf, g))
+
+	expected := `digraph "deps" {
+	"A" ["color"="red"];
+	"B";
+	"C" ["weight"="2"];
+	"A" -> "B" ["kind"="dep", "weight"="3"];
+	"B" -> "C" ["weight"="1.5"];
+}
+`
+	is.Equal(expected, buf.String())
+}
+
+func TestDOTWriter_Undirected(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := simple.New(graph.IntHash)
+	is.NoError(err)
+	is.NoError(g.AddVertexWithOptions(1))
+	is.NoError(g.AddVertexWithOptions(2))
+	is.NoError(g.AddEdgeWithOptions(1, 2))
+
+	var buf bytes.Buffer
+	is.NoError(NewDOTWriter[int, int]().WriteGraph(&buf, g))
+
+	expected := `graph {
+	"1";
+	"2";
+	"1" -- "2";
+}
+`
+	is.Equal(expected, buf.String())
+}
+
+func TestDOTWriter_Options(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := simple.New(graph.StringHash, graph.Directed())
+	is.NoError(err)
+	is.NoError(g.AddVertexWithOptions(`say "hi"`))
+	is.NoError(g.AddVertexWithOptions("x"))
+	is.NoError(g.AddEdgeWithOptions(`say "hi"`, "x", simple.EdgeWeight(4)))
+
+	var buf bytes.Buffer
+	w := NewDOTWriter[string, string](
+		DOTGraphAttributes[string, string](map[string]string{"rankdir": "LR"}),
+		DOTVertexLabel[string, string](func(v graph.Vertex[string, string]) string {
+			return "v:" + v.Value()
+		}),
+		DOTEdgeLabel[string, string](func(e graph.Edge[string]) string {
+			return e.Source() + "->" + e.Target()
+		}),
+		DOTEdgeAttributes[string, string](func(graph.Edge[string]) map[string]string {
+			return map[string]string{"style": "dashed"}
+		}),
+	)
+	is.NoError(w.WriteGraph(&buf, g))
+
+	expected := `digraph {
+	"rankdir"="LR";
+	"say \"hi\"" ["label"="v:say \"hi\""];
+	"x" ["label"="v:x"];
+	"say \"hi\"" -> "x" ["label"="say \"hi\"->x", "style"="dashed"];
+}
+`
+	is.Equal(expected, buf.String())
+}
+
+func TestDOTWriter_NilGraph(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var buf bytes.Buffer
+	err := NewDOTWriter[int, int]().WriteGraph(&buf, nil)
+	is.ErrorIs(err, graph.ErrNilInputGraph)
+}
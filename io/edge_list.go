@@ -0,0 +1,491 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+)
+
+const (
+	// edgeListWeight is the column name that holds edge weights.
+	edgeListWeight = "weight"
+)
+
+// EdgeList reads and writes graphs as edge lists: one edge per line, made of
+// a source, a target, an optional weight and optional properties, as used by
+// SNAP datasets and spreadsheet exports.
+//
+// With the default whitespace delimiter a line looks like
+//
+//	a b 1.5 color=red kind=dep
+//
+// The third field is taken as the weight if it is a number; every other extra
+// field must have the form name=value and becomes an edge item. With a header
+// row, the first line names the columns instead: the first two are the source
+// and target, a column named "weight" holds the weight, and every other column
+// is mapped to an edge item of the same name. Empty cells are skipped.
+//
+// Without a delimiter, a field that is empty, contains whitespace or starts
+// with a quote or the comment character is written as a double-quoted Go
+// string literal, and such fields are unquoted when reading. This keeps the
+// columns of a header row aligned when an edge lacks an item. With a
+// delimiter set, lines are parsed as CSV records, so fields may be quoted.
+// Lines that start with the comment character are ignored. Item values are
+// read as strings.
+//
+// Vertices are created on first use by converting each source and target
+// field with the parse function. An edge that is listed more than once has its
+// properties merged, unless the graph is a multigraph, which gets a parallel
+// edge for every line. Vertices without edges are not written.
+//
+// Example:
+//
+//	el := io.NewEdgeList[int, int](strconv.Atoi, io.EdgeListComment[int, int]('#'))
+//	if err := el.ReadGraph(f, g); err != nil {
+//		log.Fatal(err)
+//	}
+type EdgeList[K graph.Ordered, T any] struct {
+	parse     func(field string) (T, error)
+	vertexID  func(K) string
+	delimiter rune
+	comment   rune
+	header    bool
+}
+
+// EdgeListOption configures an EdgeList reader/writer.
+type EdgeListOption[K graph.Ordered, T any] func(*EdgeList[K, T])
+
+// NewEdgeList creates a whitespace-delimited edge list reader/writer that uses
+// parse to convert source and target fields into vertex values when reading.
+// Lines starting with '#' are treated as comments.
+func NewEdgeList[K graph.Ordered, T any](parse func(field string) (T, error), options ...EdgeListOption[K, T]) *EdgeList[K, T] {
+	el := &EdgeList[K, T]{
+		parse:    parse,
+		vertexID: func(k K) string { return fmt.Sprint(k) },
+		comment:  '#',
+	}
+
+	for _, option := range options {
+		option(el)
+	}
+
+	return el
+}
+
+// NewCSV creates an edge list reader/writer for comma-separated files with a
+// header row. Additional options are applied after those defaults.
+func NewCSV[K graph.Ordered, T any](parse func(field string) (T, error), options ...EdgeListOption[K, T]) *EdgeList[K, T] {
+	defaults := []EdgeListOption[K, T]{EdgeListDelimiter[K, T](','), EdgeListHeader[K, T]()}
+	return NewEdgeList(parse, append(defaults, options...)...)
+}
+
+// EdgeListDelimiter sets the field delimiter. A delimiter of 0 splits fields
+// on runs of whitespace; any other delimiter parses lines as CSV records.
+func EdgeListDelimiter[K graph.Ordered, T any](delimiter rune) EdgeListOption[K, T] {
+	return func(el *EdgeList[K, T]) {
+		el.delimiter = delimiter
+	}
+}
+
+// EdgeListComment sets the character that starts a comment line. A comment of
+// 0 disables comments.
+func EdgeListComment[K graph.Ordered, T any](comment rune) EdgeListOption[K, T] {
+	return func(el *EdgeList[K, T]) {
+		el.comment = comment
+	}
+}
+
+// EdgeListHeader makes the reader treat the first line as a header row and the
+// writer emit one.
+func EdgeListHeader[K graph.Ordered, T any]() EdgeListOption[K, T] {
+	return func(el *EdgeList[K, T]) {
+		el.header = true
+	}
+}
+
+// EdgeListVertexID sets the function used to format a vertex key when writing.
+// The default formats the key with fmt.Sprint.
+func EdgeListVertexID[K graph.Ordered, T any](id func(K) string) EdgeListOption[K, T] {
+	return func(el *EdgeList[K, T]) {
+		el.vertexID = id
+	}
+}
+
+// WriteGraph writes the edges of g to w, one per line, ordered by source and
+// then target. A weight column is written if g is weighted or any edge has a
+// non-zero weight.
+func (el *EdgeList[K, T]) WriteGraph(w io.Writer, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].Source() == edges[j].Source() {
+			return edges[i].Target() < edges[j].Target()
+		}
+		return edges[i].Source() < edges[j].Source()
+	})
+
+	weighted := g.Traits().IsWeighted
+	names := make(map[string]bool)
+	for _, edge := range edges {
+		if p := edge.Properties(); p != nil {
+			weighted = weighted || p.Weight() != 0
+			for name := range p.Items() {
+				if name != edgeListWeight {
+					names[name] = true
+				}
+			}
+		}
+	}
+	columns := sortedKeys(names)
+
+	bw := bufio.NewWriter(w)
+	write := el.recordWriter(bw)
+
+	if el.header {
+		record := []string{"source", "target"}
+		if weighted {
+			record = append(record, edgeListWeight)
+		}
+		if err = write(append(record, columns...)); err != nil {
+			return err
+		}
+	}
+
+	for _, edge := range edges {
+		record := []string{el.vertexID(edge.Source()), el.vertexID(edge.Target())}
+
+		var (
+			weight float64
+			items  map[string]any
+		)
+		if p := edge.Properties(); p != nil {
+			weight, items = p.Weight(), p.Items()
+		}
+
+		if weighted {
+			record = append(record, strconv.FormatFloat(weight, 'g', -1, 64))
+		}
+
+		for _, name := range columns {
+			v, ok := items[name]
+			switch {
+			case el.header && ok:
+				record = append(record, fmt.Sprint(v))
+			case el.header:
+				record = append(record, "")
+			case ok:
+				record = append(record, name+"="+fmt.Sprint(v))
+			}
+		}
+
+		if err = write(record); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// recordWriter returns a function that writes one record to w using the
+// configured delimiter.
+func (el *EdgeList[K, T]) recordWriter(w *bufio.Writer) func([]string) error {
+	if el.delimiter == 0 {
+		return func(record []string) error {
+			for i, field := range record {
+				if i > 0 {
+					if err := w.WriteByte(' '); err != nil {
+						return err
+					}
+				}
+				if _, err := w.WriteString(el.quote(field)); err != nil {
+					return err
+				}
+			}
+			return w.WriteByte('\n')
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = el.delimiter
+	return func(record []string) error {
+		// csv.Writer does not quote a first field that starts with the
+		// comment character, which would make the line read as a comment.
+		if el.comment != 0 && len(record) > 0 && strings.HasPrefix(record[0], string(el.comment)) {
+			for i, field := range record {
+				if i > 0 {
+					if _, err := w.WriteRune(el.delimiter); err != nil {
+						return err
+					}
+				}
+				if _, err := w.WriteString(`"` + strings.ReplaceAll(field, `"`, `""`) + `"`); err != nil {
+					return err
+				}
+			}
+			return w.WriteByte('\n')
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+}
+
+// ReadGraph parses an edge list from r and adds its edges to g, creating
+// missing vertices as it goes. Errors are reported as a *ParseError carrying
+// the offending line number.
+func (el *EdgeList[K, T]) ReadGraph(r io.Reader, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	next := el.recordReader(r)
+
+	var columns []string
+	for {
+		record, line, err := next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return &ParseError{Format: "edgelist", Line: line, Err: err}
+		}
+
+		if el.header && columns == nil {
+			columns = record
+			continue
+		}
+
+		if err = el.edge(g, record, columns); err != nil {
+			return &ParseError{Format: "edgelist", Line: line, Err: err}
+		}
+	}
+}
+
+// recordReader returns a function that yields the fields of each non-comment,
+// non-blank line of r together with its line number.
+func (el *EdgeList[K, T]) recordReader(r io.Reader) func() ([]string, int, error) {
+	if el.delimiter != 0 {
+		cr := csv.NewReader(r)
+		cr.Comma = el.delimiter
+		cr.Comment = el.comment
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+
+		return func() ([]string, int, error) {
+			record, err := cr.Read()
+			if err != nil {
+				var csvErr *csv.ParseError
+				if errors.As(err, &csvErr) {
+					return nil, csvErr.Line, csvErr.Err
+				}
+				return nil, 0, err
+			}
+			line, _ := cr.FieldPos(0)
+			return record, line, nil
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	return func() ([]string, int, error) {
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || (el.comment != 0 && strings.HasPrefix(text, string(el.comment))) {
+				continue
+			}
+			fields, err := splitEdgeListFields(text)
+			return fields, line, err
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, line, err
+		}
+		return nil, line, io.EOF
+	}
+}
+
+// quote returns field as a quoted string literal if it would otherwise be
+// read back differently from a whitespace-delimited line.
+func (el *EdgeList[K, T]) quote(field string) string {
+	if field == "" || strings.HasPrefix(field, `"`) || strings.IndexFunc(field, unicode.IsSpace) >= 0 ||
+		(el.comment != 0 && strings.HasPrefix(field, string(el.comment))) {
+		return strconv.Quote(field)
+	}
+
+	return field
+}
+
+// splitEdgeListFields splits a whitespace-delimited line into fields,
+// unquoting those written as double-quoted string literals.
+func splitEdgeListFields(text string) ([]string, error) {
+	var fields []string
+	for {
+		text = strings.TrimLeftFunc(text, unicode.IsSpace)
+		if text == "" {
+			return fields, nil
+		}
+
+		if text[0] != '"' {
+			end := strings.IndexFunc(text, unicode.IsSpace)
+			if end < 0 {
+				end = len(text)
+			}
+			fields = append(fields, text[:end])
+			text = text[end:]
+			continue
+		}
+
+		quoted, err := strconv.QuotedPrefix(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quoted field %s", text)
+		}
+
+		text = text[len(quoted):]
+		if next, _ := utf8.DecodeRuneInString(text); text != "" && !unicode.IsSpace(next) {
+			return nil, fmt.Errorf("quoted field %s is not followed by whitespace", quoted)
+		}
+
+		field, _ := strconv.Unquote(quoted)
+		fields = append(fields, field)
+	}
+}
+
+// edge adds the edge described by record to g. columns holds the header row,
+// or nil if the input has none.
+func (el *EdgeList[K, T]) edge(g graph.Interface[K, T], record, columns []string) error {
+	if len(record) < 2 {
+		return fmt.Errorf("expected at least 2 fields, found %d", len(record))
+	}
+
+	items := make(map[string]any)
+	var (
+		weight    float64
+		hasWeight bool
+	)
+
+	for i, field := range record[2:] {
+		i += 2
+
+		var name, value string
+		switch {
+		case columns != nil:
+			if i >= len(columns) {
+				return fmt.Errorf("field %d has no header column", i+1)
+			}
+			name, value = columns[i], field
+
+		case i == 2 && isEdgeListNumber(field):
+			name, value = edgeListWeight, field
+
+		default:
+			var ok bool
+			if name, value, ok = strings.Cut(field, "="); !ok {
+				return fmt.Errorf("field %q is not of the form name=value", field)
+			}
+		}
+
+		if value == "" {
+			continue
+		}
+
+		if name == edgeListWeight {
+			w, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid weight %q: %w", value, err)
+			}
+			weight, hasWeight = w, true
+			continue
+		}
+
+		items[name] = value
+	}
+
+	source, err := el.vertex(g, record[0])
+	if err != nil {
+		return err
+	}
+	target, err := el.vertex(g, record[1])
+	if err != nil {
+		return err
+	}
+
+	// A multigraph gets a parallel edge for every line; other graphs merge
+	// the properties of a repeated edge into the existing one.
+	exists := false
+	if !g.Traits().IsMultiGraph {
+		_, err = g.Edge(source, target)
+		if err != nil && !errors.Is(err, graph.ErrEdgeNotFound) {
+			return err
+		}
+		exists = err == nil
+	}
+
+	if !exists {
+		options := []graph.EdgeOption{simple.EdgeItems(items)}
+		if hasWeight {
+			options = append(options, simple.EdgeWeight(weight))
+		}
+		return g.AddEdgeWithOptions(source, target, options...)
+	}
+
+	options := make([]graph.EdgeOption, 0, len(items)+1)
+	for k, v := range items {
+		options = append(options, simple.EdgeItem(k, v))
+	}
+	if hasWeight {
+		options = append(options, simple.EdgeWeight(weight))
+	}
+
+	return g.SetEdgeWithOptions(source, target, options...)
+}
+
+// vertex returns the key of the vertex named by field, adding the vertex to g
+// if it does not exist yet.
+func (el *EdgeList[K, T]) vertex(g graph.Interface[K, T], field string) (K, error) {
+	value, err := el.parse(field)
+	if err != nil {
+		var zero K
+		return zero, fmt.Errorf("invalid vertex %q: %w", field, err)
+	}
+
+	key := g.Hash()(value)
+	exists, err := g.HasVertex(key)
+	if err != nil {
+		return key, err
+	}
+	if !exists {
+		err = g.AddVertexWithOptions(value)
+	}
+
+	return key, err
+}
+
+// isEdgeListNumber reports whether field parses as a floating-point number.
+func isEdgeListNumber(field string) bool {
+	_, err := strconv.ParseFloat(field, 64)
+	return err == nil
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEdgeList_ReadSNAP(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := `# Directed graph: example.txt
+# FromNodeId	ToNodeId
+0	1
+0	2 2.5
+1	2 0.5 kind=dep
+
+2	0
+`
+	g, _ := simple.New(graph.IntHash, graph.Directed())
+	is.NoError(NewEdgeList[int, int](strconv.Atoi).ReadGraph(strings.NewReader(src), g))
+
+	order, _ := g.Order()
+	is.Equal(3, order)
+	size, _ := g.Size()
+	is.Equal(4, size)
+
+	e, _ := g.Edge(0, 2)
+	is.Equal(2.5, e.Properties().Weight())
+
+	e, _ = g.Edge(1, 2)
+	is.Equal(0.5, e.Properties().Weight())
+	is.Equal("dep", e.Properties().Items()["kind"])
+}
+
+func TestEdgeList_ReadCSVHeader(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := "from,to,weight,label\n" +
+		"a,b,1,\"first, edge\"\n" +
+		"; a comment\n" +
+		"b,c,,second\n"
+	g, _ := simple.New(graph.StringHash)
+	el := NewCSV[string, string](parseStringID, EdgeListComment[string, string](';'))
+	is.NoError(el.ReadGraph(strings.NewReader(src), g))
+
+	e, err := g.Edge("b", "a")
+	is.NoError(err)
+	is.Equal(float64(1), e.Properties().Weight())
+	is.Equal("first, edge", e.Properties().Items()["label"])
+
+	e, err = g.Edge("b", "c")
+	is.NoError(err)
+	is.Equal(float64(0), e.Properties().Weight())
+	is.Equal("second", e.Properties().Items()["label"])
+}
+
+func TestEdgeList_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, csv := range []bool{false, true} {
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+		is.NoError(g.AddVertexWithOptions("a"))
+		is.NoError(g.AddVertexWithOptions("b"))
+		is.NoError(g.AddVertexWithOptions("c"))
+		is.NoError(g.AddEdgeWithOptions("a", "b", simple.EdgeWeight(2), simple.EdgeItem("kind", "dep")))
+		is.NoError(g.AddEdgeWithOptions("b", "c", simple.EdgeWeight(0.5)))
+
+		el := NewEdgeList[string, string](parseStringID)
+		if csv {
+			el = NewCSV[string, string](parseStringID)
+		}
+
+		var buf bytes.Buffer
+		is.NoError(el.WriteGraph(&buf, g))
+		if csv {
+			is.Equal("source,target,weight,kind\na,b,2,dep\nb,c,0.5,\n", buf.String())
+		} else {
+			is.Equal("a b 2 kind=dep\nb c 0.5\n", buf.String())
+		}
+
+		h, _ := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+		is.NoError(el.ReadGraph(&buf, h))
+
+		e, err := h.Edge("a", "b")
+		is.NoError(err)
+		is.Equal(float64(2), e.Properties().Weight())
+		is.Equal("dep", e.Properties().Items()["kind"])
+		e, err = h.Edge("b", "c")
+		is.NoError(err)
+		is.Equal(0.5, e.Properties().Weight())
+	}
+}
+
+func TestEdgeList_RoundTripWhitespaceHeader(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash, graph.Directed())
+	is.NoError(g.AddVertexWithOptions("a"))
+	is.NoError(g.AddVertexWithOptions("b"))
+	is.NoError(g.AddVertexWithOptions("c d"))
+	is.NoError(g.AddEdgeWithOptions("a", "b", simple.EdgeItem("x", "1"), simple.EdgeItem("y", "two words")))
+	is.NoError(g.AddEdgeWithOptions("b", "c d", simple.EdgeItem("y", "3")))
+
+	el := NewEdgeList[string, string](parseStringID, EdgeListHeader[string, string]())
+
+	var buf bytes.Buffer
+	is.NoError(el.WriteGraph(&buf, g))
+	is.Equal("source target x y\na b 1 \"two words\"\nb \"c d\" \"\" 3\n", buf.String())
+
+	h, _ := simple.New(graph.StringHash, graph.Directed())
+	is.NoError(el.ReadGraph(&buf, h))
+
+	e, err := h.Edge("a", "b")
+	is.NoError(err)
+	is.Equal(map[string]any{"x": "1", "y": "two words"}, e.Properties().Items())
+
+	e, err = h.Edge("b", "c d")
+	is.NoError(err)
+	is.Equal(map[string]any{"y": "3"}, e.Properties().Items())
+}
+
+func TestEdgeList_CommentPrefixedVertex(t *testing.T) {
+	t.Parallel()
+
+	for _, csv := range []bool{false, true} {
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.StringHash, graph.Directed())
+		is.NoError(g.AddVertexWithOptions("#x"))
+		is.NoError(g.AddVertexWithOptions("y"))
+		is.NoError(g.AddEdgeWithOptions("#x", "y", simple.EdgeItem("note", "a \"b\"")))
+
+		el := NewEdgeList[string, string](parseStringID, EdgeListHeader[string, string]())
+		if csv {
+			el = NewCSV[string, string](parseStringID)
+		}
+
+		var buf bytes.Buffer
+		is.NoError(el.WriteGraph(&buf, g))
+		if csv {
+			is.Equal("source,target,note\n\"#x\",\"y\",\"a \"\"b\"\"\"\n", buf.String())
+		}
+
+		h, _ := simple.New(graph.StringHash, graph.Directed())
+		is.NoError(el.ReadGraph(&buf, h))
+
+		e, err := h.Edge("#x", "y")
+		is.NoError(err)
+		is.Equal(`a "b"`, e.Properties().Items()["note"])
+	}
+}
+
+func TestEdgeList_RepeatedEdges(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := "1 2 kind=a\n1 2 2.5\n"
+
+	// Repeated lines are merged into one edge.
+	g, _ := simple.New(graph.IntHash, graph.Directed())
+	is.NoError(NewEdgeList[int, int](strconv.Atoi).ReadGraph(strings.NewReader(src), g))
+	size, _ := g.Size()
+	is.Equal(1, size)
+	e, _ := g.Edge(1, 2)
+	is.Equal(2.5, e.Properties().Weight())
+	is.Equal("a", e.Properties().Items()["kind"])
+
+	// A multigraph gets a parallel edge for each line.
+	m, _ := simple.New(graph.IntHash, graph.Directed(), graph.MultiGraph())
+	is.NoError(NewEdgeList[int, int](strconv.Atoi).ReadGraph(strings.NewReader(src), m))
+	edges, err := m.(graph.Multigraph[int, int]).EdgesBetween(1, 2)
+	is.NoError(err)
+	is.Len(edges, 2)
+	is.Equal("a", edges[0].Properties().Items()["kind"])
+	is.Equal(2.5, edges[1].Properties().Weight())
+}
+
+func TestEdgeList_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		csv  bool
+		line int
+	}{
+		{name: "too few fields", src: "1 2\n3\n", line: 2},
+		{name: "bad key", src: "# c\n1 x\n", line: 2},
+		{name: "bad property", src: "1 2\n2 3 4 five\n", line: 2},
+		{name: "unterminated quote", src: "1 2\n2 \"3\n", line: 2},
+		{name: "text after quote", src: "1 \"2\"3\n", line: 1},
+		{name: "bad csv weight", src: "s,t,weight\n1,2,3\n2,3,x\n", csv: true, line: 3},
+		{name: "bad csv quote", src: "s,t\n1,\"2\n", csv: true, line: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			el := NewEdgeList[int, int](strconv.Atoi)
+			if tc.csv {
+				el = NewCSV[int, int](strconv.Atoi)
+			}
+
+			g, _ := simple.New(graph.IntHash, graph.Directed())
+			err := el.ReadGraph(strings.NewReader(tc.src), g)
+
+			var perr *ParseError
+			is.True(errors.As(err, &perr), "expected a ParseError, got %v", err)
+			if perr != nil {
+				is.Equal(tc.line, perr.Line, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrChecksumMismatch is returned when the checksum stored in a binary
+	// snapshot does not match its contents.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrReservedItem is returned when an item of a vertex or edge has a name
+	// that a format reserves for its own use.
+	ErrReservedItem = errors.New("item name is reserved")
+
+	// ErrUnsupportedVersion is returned when a binary snapshot was written by a
+	// newer, incompatible version of the format.
+	ErrUnsupportedVersion = errors.New("unsupported format version")
+)
+
+// ParseError describes a malformed input document. Line and Column are
+// 1-based; Column is 0 for formats that are processed line by line, and Line
+// is 0 for formats that only report a byte Offset.
+type ParseError struct {
+	// Format is the name of the format being read, such as "dot".
+	Format string
+
+	// Line is the line on which the error was detected.
+	Line int
+
+	// Column is the column on which the error was detected.
+	Column int
+
+	// Offset is the byte offset at which the error was detected.
+	Offset int64
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: offset %d: %v", e.Format, e.Offset, e.Err)
+	}
+
+	if e.Column > 0 {
+		return fmt.Sprintf("%s: line %d, column %d: %v", e.Format, e.Line, e.Column, e.Err)
+	}
+
+	return fmt.Sprintf("%s: line %d: %v", e.Format, e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,655 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+)
+
+const (
+	// gexfNamespace is the XML namespace of GEXF 1.3 documents.
+	gexfNamespace = "http://gexf.net/1.3"
+
+	// gexfLabel is the item name written as the label of a node or edge.
+	gexfLabel = "label"
+
+	// gexfWeight is the attribute title used for vertex weights. Edge weights
+	// use the weight attribute of the <edge> element.
+	gexfWeight = "weight"
+)
+
+// GEXF reads and writes graphs in the GEXF 1.3 format used by Gephi.
+//
+// Items() entries are written as typed attributes declared in an
+// <attributes> block, using the same type mapping as GraphML, except that int
+// is called integer. An item named "label" becomes the label of its node or
+// edge, and a label that is read always becomes an item named "label"; nodes
+// and edges without such an item are written without a label. Edge weights
+// are written as the weight of the <edge> element, and vertex weights as an
+// attribute titled "weight", so writing a vertex with an item named "weight"
+// fails with ErrReservedItem. Edges carry a weight when the graph is weighted
+// or the weight is non-zero, and defaultedgetype reflects
+// Traits().IsDirected.
+//
+// With GEXFSpells, the named items are written as start and end attributes of
+// nodes and edges and the graph is written in dynamic mode, so time-sliced
+// graphs can be explored in Gephi's timeline. Reading maps start and end back
+// onto the same items.
+//
+// Example:
+//
+//	gexf := io.NewGEXF[string, string](func(id string) (string, error) { return id, nil },
+//		io.GEXFSpells[string, string]("from", "until"))
+//	if err := gexf.WriteGraph(f, g); err != nil {
+//		log.Fatal(err)
+//	}
+type GEXF[K graph.Ordered, T any] struct {
+	parse      func(id string) (T, error)
+	vertexID   func(K) string
+	start      string
+	end        string
+	timeFormat string
+}
+
+// GEXFOption configures a GEXF reader/writer.
+type GEXFOption[K graph.Ordered, T any] func(*GEXF[K, T])
+
+// NewGEXF creates a GEXF reader/writer that uses parse to convert node IDs
+// into vertex values when reading.
+func NewGEXF[K graph.Ordered, T any](parse func(id string) (T, error), options ...GEXFOption[K, T]) *GEXF[K, T] {
+	g := &GEXF[K, T]{
+		parse:      parse,
+		vertexID:   func(k K) string { return fmt.Sprint(k) },
+		timeFormat: "double",
+	}
+
+	for _, option := range options {
+		option(g)
+	}
+
+	return g
+}
+
+// GEXFVertexID sets the function used to derive a node ID from a vertex key
+// when writing. The default formats the key with fmt.Sprint.
+func GEXFVertexID[K graph.Ordered, T any](id func(K) string) GEXFOption[K, T] {
+	return func(g *GEXF[K, T]) {
+		g.vertexID = id
+	}
+}
+
+// GEXFSpells maps the items named start and end onto the start and end spell
+// attributes of nodes and edges, and makes the writer emit a dynamic graph.
+func GEXFSpells[K graph.Ordered, T any](start, end string) GEXFOption[K, T] {
+	return func(g *GEXF[K, T]) {
+		g.start = start
+		g.end = end
+	}
+}
+
+// GEXFTimeFormat sets the timeformat of spells: "double" (the default), "date"
+// or "dateTime". Spells are read as float64 for "double" and as time.Time
+// otherwise; when writing, time.Time values are formatted to match.
+func GEXFTimeFormat[K graph.Ordered, T any](format string) GEXFOption[K, T] {
+	return func(g *GEXF[K, T]) {
+		g.timeFormat = format
+	}
+}
+
+type gexfAttribute struct {
+	ID      string  `xml:"id,attr"`
+	Title   string  `xml:"title,attr"`
+	Type    string  `xml:"type,attr"`
+	Default *string `xml:"default,omitempty"`
+}
+
+type gexfAttributes struct {
+	XMLName    xml.Name        `xml:"attributes"`
+	Class      string          `xml:"class,attr"`
+	Mode       string          `xml:"mode,attr,omitempty"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type gexfAttValues struct {
+	Values []gexfAttValue `xml:"attvalue"`
+}
+
+type gexfNode struct {
+	XMLName   xml.Name       `xml:"node"`
+	ID        string         `xml:"id,attr"`
+	Label     *string        `xml:"label,attr,omitempty"`
+	Start     string         `xml:"start,attr,omitempty"`
+	End       string         `xml:"end,attr,omitempty"`
+	AttValues *gexfAttValues `xml:"attvalues"`
+}
+
+type gexfEdge struct {
+	XMLName   xml.Name       `xml:"edge"`
+	ID        string         `xml:"id,attr,omitempty"`
+	Source    string         `xml:"source,attr"`
+	Target    string         `xml:"target,attr"`
+	Label     *string        `xml:"label,attr,omitempty"`
+	Weight    string         `xml:"weight,attr,omitempty"`
+	Start     string         `xml:"start,attr,omitempty"`
+	End       string         `xml:"end,attr,omitempty"`
+	AttValues *gexfAttValues `xml:"attvalues"`
+}
+
+// WriteGraph writes g to w as a GEXF 1.3 document.
+func (gx *GEXF[K, T]) WriteGraph(w io.Writer, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	vertices, err := g.Vertices()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	sort.SliceStable(vertices, func(i, j int) bool {
+		return vertices[i].ID() < vertices[j].ID()
+	})
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].Source() == edges[j].Source() {
+			return edges[i].Target() < edges[j].Target()
+		}
+		return edges[i].Source() < edges[j].Source()
+	})
+
+	nodeAttributes := newGEXFAttributeSet(gx.reserved())
+	edgeAttributes := newGEXFAttributeSet(gx.reserved())
+	for _, vertex := range vertices {
+		if p := vertex.Properties(); p != nil {
+			if _, ok := p.Items()[gexfWeight]; ok {
+				return fmt.Errorf("%w: vertex %v has an item named %q", ErrReservedItem, vertex.ID(), gexfWeight)
+			}
+		}
+		nodeAttributes.observe(gexfVertexItems(vertex.Properties()))
+	}
+	for _, edge := range edges {
+		if p := edge.Properties(); p != nil {
+			edgeAttributes.observe(p.Items())
+		}
+	}
+
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	root := xml.StartElement{
+		Name: xml.Name{Local: "gexf"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns"}, Value: gexfNamespace},
+			{Name: xml.Name{Local: "version"}, Value: "1.3"},
+		},
+	}
+	if err = enc.EncodeToken(root); err != nil {
+		return err
+	}
+
+	edgeType := "undirected"
+	if g.Traits().IsDirected {
+		edgeType = "directed"
+	}
+
+	graphElement := xml.StartElement{Name: xml.Name{Local: "graph"}}
+	if gx.dynamic() {
+		graphElement.Attr = append(graphElement.Attr,
+			xml.Attr{Name: xml.Name{Local: "mode"}, Value: "dynamic"},
+			xml.Attr{Name: xml.Name{Local: "timeformat"}, Value: gx.timeFormat})
+	} else {
+		graphElement.Attr = append(graphElement.Attr, xml.Attr{Name: xml.Name{Local: "mode"}, Value: "static"})
+	}
+	graphElement.Attr = append(graphElement.Attr, xml.Attr{Name: xml.Name{Local: "defaultedgetype"}, Value: edgeType})
+	if err = enc.EncodeToken(graphElement); err != nil {
+		return err
+	}
+
+	for _, attributes := range []gexfAttributes{nodeAttributes.declare("node"), edgeAttributes.declare("edge")} {
+		if len(attributes.Attributes) == 0 {
+			continue
+		}
+		if err = enc.Encode(attributes); err != nil {
+			return err
+		}
+	}
+
+	nodes := xml.StartElement{Name: xml.Name{Local: "nodes"}}
+	if err = enc.EncodeToken(nodes); err != nil {
+		return err
+	}
+	for _, vertex := range vertices {
+		items := gexfVertexItems(vertex.Properties())
+		node := gexfNode{
+			ID:        gx.vertexID(vertex.ID()),
+			Label:     gexfLabelOf(items),
+			AttValues: nodeAttributes.values(items),
+		}
+		node.Start, node.End = gx.spell(items)
+		if err = enc.Encode(node); err != nil {
+			return err
+		}
+	}
+	if err = enc.EncodeToken(nodes.End()); err != nil {
+		return err
+	}
+
+	edgesElement := xml.StartElement{Name: xml.Name{Local: "edges"}}
+	if err = enc.EncodeToken(edgesElement); err != nil {
+		return err
+	}
+	for i, edge := range edges {
+		e := gexfEdge{
+			ID:     strconv.Itoa(i),
+			Source: gx.vertexID(edge.Source()),
+			Target: gx.vertexID(edge.Target()),
+		}
+		if p := edge.Properties(); p != nil {
+			items := p.Items()
+			e.Label = gexfLabelOf(items)
+			if g.Traits().IsWeighted || p.Weight() != 0 {
+				e.Weight = strconv.FormatFloat(p.Weight(), 'g', -1, 64)
+			}
+			e.Start, e.End = gx.spell(items)
+			e.AttValues = edgeAttributes.values(items)
+		}
+		if err = enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	if err = enc.EncodeToken(edgesElement.End()); err != nil {
+		return err
+	}
+
+	if err = enc.EncodeToken(graphElement.End()); err != nil {
+		return err
+	}
+	if err = enc.EncodeToken(root.End()); err != nil {
+		return err
+	}
+	if err = enc.Flush(); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// ReadGraph parses a GEXF document from r and adds its vertices and edges to
+// g. It returns graph.ErrGraphTypeMismatch if the document's defaultedgetype
+// does not match Traits().IsDirected.
+func (gx *GEXF[K, T]) ReadGraph(r io.Reader, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	dec := xml.NewDecoder(r)
+	wrap := func(err error) error {
+		line, column := dec.InputPos()
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, column = syntaxErr.Line, 0
+		}
+		return &ParseError{Format: "gexf", Line: line, Column: column, Err: err}
+	}
+
+	attributes := map[string]map[string]gexfAttribute{
+		"node": make(map[string]gexfAttribute),
+		"edge": make(map[string]gexfAttribute),
+	}
+	ids := make(map[string]K)
+	timeFormat := gx.timeFormat
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return wrap(err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "graph":
+				edgeType := "undirected"
+				for _, attr := range t.Attr {
+					switch attr.Name.Local {
+					case "defaultedgetype":
+						edgeType = attr.Value
+					case "timeformat":
+						timeFormat = attr.Value
+					}
+				}
+				if (edgeType == "directed") != g.Traits().IsDirected {
+					return wrap(fmt.Errorf("%w: defaultedgetype is %s", graph.ErrGraphTypeMismatch, edgeType))
+				}
+
+			case "attributes":
+				var declared gexfAttributes
+				if err = dec.DecodeElement(&declared, &t); err != nil {
+					return wrap(err)
+				}
+				if _, ok := attributes[declared.Class]; !ok {
+					return wrap(fmt.Errorf("unknown attribute class %q", declared.Class))
+				}
+				for _, attribute := range declared.Attributes {
+					attributes[declared.Class][attribute.ID] = attribute
+				}
+
+			case "node":
+				line, column := dec.InputPos()
+				fail := func(err error) error {
+					return &ParseError{Format: "gexf", Line: line, Column: column, Err: err}
+				}
+
+				var node gexfNode
+				if err = dec.DecodeElement(&node, &t); err != nil {
+					return wrap(err)
+				}
+
+				items, err := gexfProperties(attributes["node"], node.AttValues)
+				if err != nil {
+					return fail(err)
+				}
+				if node.Label != nil {
+					items[gexfLabel] = *node.Label
+				}
+				if err = gx.readSpell(items, node.Start, node.End, timeFormat); err != nil {
+					return fail(err)
+				}
+
+				value, err := gx.parse(node.ID)
+				if err != nil {
+					return fail(fmt.Errorf("invalid node ID %q: %w", node.ID, err))
+				}
+
+				options := []graph.VertexOption{}
+				if weight, ok := graphmlFloat(items[gexfWeight]); ok {
+					delete(items, gexfWeight)
+					options = append(options, simple.VertexWeight(weight))
+				}
+				options = append(options, simple.VertexItems(items))
+
+				if err = g.AddVertexWithOptions(value, options...); err != nil {
+					return fail(err)
+				}
+				ids[node.ID] = g.Hash()(value)
+
+			case "edge":
+				line, column := dec.InputPos()
+				fail := func(err error) error {
+					return &ParseError{Format: "gexf", Line: line, Column: column, Err: err}
+				}
+
+				var edge gexfEdge
+				if err = dec.DecodeElement(&edge, &t); err != nil {
+					return wrap(err)
+				}
+
+				items, err := gexfProperties(attributes["edge"], edge.AttValues)
+				if err != nil {
+					return fail(err)
+				}
+				if edge.Label != nil {
+					items[gexfLabel] = *edge.Label
+				}
+				if err = gx.readSpell(items, edge.Start, edge.End, timeFormat); err != nil {
+					return fail(err)
+				}
+
+				source, ok := ids[edge.Source]
+				if !ok {
+					return fail(fmt.Errorf("%w: %s", graph.ErrVertexNotFound, edge.Source))
+				}
+				target, ok := ids[edge.Target]
+				if !ok {
+					return fail(fmt.Errorf("%w: %s", graph.ErrVertexNotFound, edge.Target))
+				}
+
+				options := []graph.EdgeOption{simple.EdgeItems(items)}
+				if edge.Weight != "" {
+					weight, err := strconv.ParseFloat(edge.Weight, 64)
+					if err != nil {
+						return fail(fmt.Errorf("invalid weight %q: %w", edge.Weight, err))
+					}
+					options = append(options, simple.EdgeWeight(weight))
+				}
+
+				if err = g.AddEdgeWithOptions(source, target, options...); err != nil {
+					return fail(err)
+				}
+			}
+
+		case xml.EndElement:
+			if t.Name.Local == "graph" {
+				return nil
+			}
+		}
+	}
+}
+
+// dynamic reports whether spells are enabled.
+func (gx *GEXF[K, T]) dynamic() bool {
+	return gx.start != "" || gx.end != ""
+}
+
+// reserved returns the item names that are not written as attributes.
+func (gx *GEXF[K, T]) reserved() map[string]bool {
+	reserved := map[string]bool{gexfLabel: true}
+	if gx.start != "" {
+		reserved[gx.start] = true
+	}
+	if gx.end != "" {
+		reserved[gx.end] = true
+	}
+	return reserved
+}
+
+// spell returns the formatted start and end spell values found in items.
+func (gx *GEXF[K, T]) spell(items map[string]any) (string, string) {
+	format := func(name string) string {
+		v, ok := items[name]
+		if name == "" || !ok {
+			return ""
+		}
+		switch x := v.(type) {
+		case time.Time:
+			if gx.timeFormat == "date" {
+				return x.Format(time.DateOnly)
+			}
+			return x.Format(time.RFC3339Nano)
+		default:
+			return formatGraphMLValue(v)
+		}
+	}
+
+	return format(gx.start), format(gx.end)
+}
+
+// readSpell stores the start and end spell values in items according to
+// timeFormat.
+func (gx *GEXF[K, T]) readSpell(items map[string]any, start, end, timeFormat string) error {
+	for _, spell := range []struct{ name, value string }{{gx.start, start}, {gx.end, end}} {
+		if spell.name == "" || spell.value == "" {
+			continue
+		}
+
+		var (
+			v   any
+			err error
+		)
+		switch timeFormat {
+		case "date":
+			v, err = time.Parse(time.DateOnly, spell.value)
+		case "dateTime":
+			v, err = time.Parse(time.RFC3339Nano, spell.value)
+		default:
+			v, err = strconv.ParseFloat(spell.value, 64)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid %s spell %q: %w", timeFormat, spell.value, err)
+		}
+
+		items[spell.name] = v
+	}
+
+	return nil
+}
+
+// gexfLabelOf returns the label written for the given items, or nil if they
+// have no item named "label".
+func gexfLabelOf(items map[string]any) *string {
+	label, ok := items[gexfLabel]
+	if !ok {
+		return nil
+	}
+
+	s := fmt.Sprint(label)
+	return &s
+}
+
+// gexfVertexItems returns the items of a vertex with its weight, if non-zero,
+// added under the reserved "weight" title.
+func gexfVertexItems(p graph.VertexProperties) map[string]any {
+	if p == nil {
+		return nil
+	}
+
+	items := make(map[string]any, len(p.Items())+1)
+	for k, v := range p.Items() {
+		items[k] = v
+	}
+	if p.Weight() != 0 {
+		items[gexfWeight] = p.Weight()
+	}
+
+	return items
+}
+
+// gexfAttributeSet accumulates the attribute types seen for one class ("node"
+// or "edge") while writing.
+type gexfAttributeSet struct {
+	reserved map[string]bool
+	types    map[string]string
+	ids      map[string]string
+}
+
+func newGEXFAttributeSet(reserved map[string]bool) *gexfAttributeSet {
+	return &gexfAttributeSet{
+		reserved: reserved,
+		types:    make(map[string]string),
+		ids:      make(map[string]string),
+	}
+}
+
+// observe records the types of the given items.
+func (s *gexfAttributeSet) observe(items map[string]any) {
+	for name, value := range items {
+		if !s.reserved[name] {
+			s.types[name] = widenGraphMLType(s.types[name], graphmlType(value))
+		}
+	}
+}
+
+// declare assigns attribute IDs in title order and returns the declarations.
+func (s *gexfAttributeSet) declare(class string) gexfAttributes {
+	attributes := gexfAttributes{Class: class, Mode: "static"}
+	for i, name := range sortedKeys(s.types) {
+		id := strconv.Itoa(i)
+		s.ids[name] = id
+		attributes.Attributes = append(attributes.Attributes, gexfAttribute{ID: id, Title: name, Type: gexfType(s.types[name])})
+	}
+	return attributes
+}
+
+// values returns the <attvalues> element for the given items, or nil if none
+// of them is declared.
+func (s *gexfAttributeSet) values(items map[string]any) *gexfAttValues {
+	var values []gexfAttValue
+	for _, name := range sortedKeys(items) {
+		if id, ok := s.ids[name]; ok {
+			values = append(values, gexfAttValue{For: id, Value: formatGraphMLValue(items[name])})
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return &gexfAttValues{Values: values}
+}
+
+// gexfType converts a GraphML attr.type into the equivalent GEXF type.
+func gexfType(typ string) string {
+	if typ == "int" {
+		return "integer"
+	}
+	return typ
+}
+
+// gexfProperties converts the <attvalue> elements of a node or edge, along
+// with the defaults declared for its class, into items.
+func gexfProperties(attributes map[string]gexfAttribute, values *gexfAttValues) (map[string]any, error) {
+	raw := make(map[string]string)
+	for id, attribute := range attributes {
+		if attribute.Default != nil {
+			raw[id] = *attribute.Default
+		}
+	}
+	if values != nil {
+		for _, v := range values.Values {
+			raw[v.For] = v.Value
+		}
+	}
+
+	items := make(map[string]any, len(raw))
+	for id, s := range raw {
+		attribute, ok := attributes[id]
+		if !ok {
+			return nil, fmt.Errorf("undeclared attribute %q", id)
+		}
+
+		typ := attribute.Type
+		if typ == "integer" {
+			typ = "int"
+		}
+
+		value, err := parseGraphMLValue(typ, s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for attribute %q: %w", s, id, err)
+		}
+
+		name := attribute.Title
+		if name == "" {
+			name = id
+		}
+		items[name] = value
+	}
+
+	return items, nil
+}
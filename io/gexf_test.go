@@ -0,0 +1,179 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/sets"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGEXF_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, directed := range []bool{true, false} {
+		options := []func(*graph.Traits){graph.Weighted()}
+		if directed {
+			options = append(options, graph.Directed())
+		}
+
+		g, _ := simple.New(graph.StringHash, options...)
+		is := assert.New(t)
+		is.NoError(g.AddVertexWithOptions("A", simple.VertexItems(map[string]any{
+			"label":  "Alpha",
+			"count":  3,
+			"ratio":  0.25,
+			"active": true,
+		})))
+		is.NoError(g.AddVertexWithOptions("B", simple.VertexWeight(1.5)))
+		is.NoError(g.AddVertexWithOptions("C", simple.VertexItem("label", "C")))
+		is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(2), simple.EdgeItem("kind", "dep")))
+		is.NoError(g.AddEdgeWithOptions("B", "C", simple.EdgeWeight(0.5), simple.EdgeItem("label", "bc")))
+
+		gexf := NewGEXF[string, string](parseStringID)
+
+		var buf bytes.Buffer
+		is.NoError(gexf.WriteGraph(&buf, g))
+
+		h, _ := simple.New(graph.StringHash, options...)
+		is.NoError(gexf.ReadGraph(&buf, h))
+
+		equal, err := sets.Equals(g, h)
+		is.NoError(err)
+		is.True(equal)
+
+		a, _ := h.Vertex("A")
+		is.Equal(map[string]any{"label": "Alpha", "count": 3, "ratio": 0.25, "active": true}, a.Properties().Items())
+
+		b, _ := h.Vertex("B")
+		is.Equal(1.5, b.Properties().Weight())
+		is.Empty(b.Properties().Items())
+
+		// A label equal to the node ID is kept.
+		c, _ := h.Vertex("C")
+		is.Equal(map[string]any{"label": "C"}, c.Properties().Items())
+
+		e, _ := h.Edge("B", "C")
+		is.Equal("bc", e.Properties().Items()["label"])
+	}
+}
+
+func TestGEXF_Write(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.IntHash, graph.Directed())
+	is.NoError(g.AddVertexWithOptions(1, simple.VertexItem("score", 1)))
+	is.NoError(g.AddVertexWithOptions(2))
+	is.NoError(g.AddEdgeWithOptions(1, 2))
+
+	var buf bytes.Buffer
+	is.NoError(NewGEXF[int, int](nil).WriteGraph(&buf, g))
+
+	is.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<gexf xmlns="http://gexf.net/1.3" version="1.3">
+  <graph mode="static" defaultedgetype="directed">
+    <attributes class="node" mode="static">
+      <attribute id="0" title="score" type="integer"></attribute>
+    </attributes>
+    <nodes>
+      <node id="1">
+        <attvalues>
+          <attvalue for="0" value="1"></attvalue>
+        </attvalues>
+      </node>
+      <node id="2"></node>
+    </nodes>
+    <edges>
+      <edge id="0" source="1" target="2"></edge>
+    </edges>
+  </graph>
+</gexf>
+`, buf.String())
+}
+
+func TestGEXF_ReservedWeightItem(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash)
+	is.NoError(g.AddVertexWithOptions("a", simple.VertexWeight(2), simple.VertexItem("weight", "heavy")))
+
+	var buf bytes.Buffer
+	err := NewGEXF[string, string](parseStringID).WriteGraph(&buf, g)
+	is.ErrorIs(err, ErrReservedItem)
+	is.Zero(buf.Len())
+}
+
+func TestGEXF_Spells(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash, graph.Directed())
+	is.NoError(g.AddVertexWithOptions("a", simple.VertexItem("from", 1.0), simple.VertexItem("until", 5.0)))
+	is.NoError(g.AddVertexWithOptions("b", simple.VertexItem("from", 2.0)))
+	is.NoError(g.AddEdgeWithOptions("a", "b", simple.EdgeItem("from", 3.0)))
+
+	gexf := NewGEXF(parseStringID, GEXFSpells[string, string]("from", "until"))
+
+	var buf bytes.Buffer
+	is.NoError(gexf.WriteGraph(&buf, g))
+	out := buf.String()
+	is.Contains(out, `<graph mode="dynamic" timeformat="double" defaultedgetype="directed">`)
+	is.Contains(out, `<node id="a" start="1" end="5"></node>`)
+	is.Contains(out, `<edge id="0" source="a" target="b" start="3"></edge>`)
+
+	h, _ := simple.New(graph.StringHash, graph.Directed())
+	is.NoError(gexf.ReadGraph(&buf, h))
+	a, _ := h.Vertex("a")
+	is.Equal(map[string]any{"from": 1.0, "until": 5.0}, a.Properties().Items())
+	e, _ := h.Edge("a", "b")
+	is.Equal(3.0, e.Properties().Items()["from"])
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	g, _ = simple.New(graph.StringHash)
+	is.NoError(g.AddVertexWithOptions("x", simple.VertexItem("start", day)))
+
+	gexf = NewGEXF(parseStringID, GEXFSpells[string, string]("start", "end"), GEXFTimeFormat[string, string]("date"))
+	buf.Reset()
+	is.NoError(gexf.WriteGraph(&buf, g))
+	is.Contains(buf.String(), `start="2024-03-01"`)
+
+	h, _ = simple.New(graph.StringHash)
+	is.NoError(gexf.ReadGraph(&buf, h))
+	x, _ := h.Vertex("x")
+	is.Equal(day, x.Properties().Items()["start"])
+}
+
+func TestGEXF_ReadErrors(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash, graph.Directed())
+	gexf := NewGEXF[string, string](parseStringID)
+
+	err := gexf.ReadGraph(strings.NewReader(`<gexf><graph defaultedgetype="undirected"></graph></gexf>`), g)
+	is.ErrorIs(err, graph.ErrGraphTypeMismatch)
+
+	err = gexf.ReadGraph(strings.NewReader(
+		"<gexf>\n<graph defaultedgetype=\"directed\">\n<nodes><node id=\"a\"/></nodes>\n<edges><edge source=\"a\" target=\"b\"/></edges>\n</graph></gexf>"), g)
+	var perr *ParseError
+	is.True(errors.As(err, &perr))
+	is.ErrorIs(err, graph.ErrVertexNotFound)
+	is.Equal(4, perr.Line)
+
+	err = gexf.ReadGraph(strings.NewReader(
+		"<gexf><graph defaultedgetype=\"directed\"><nodes>\n<node id=\"z\"><attvalues><attvalue for=\"9\" value=\"1\"/></attvalues></node>"), g)
+	is.True(errors.As(err, &perr))
+	is.Equal(2, perr.Line)
+}
@@ -0,0 +1,553 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+)
+
+const (
+	// graphmlNamespace is the XML namespace of GraphML documents.
+	graphmlNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+	// graphmlWeight is the attribute name used for vertex and edge weights.
+	graphmlWeight = "weight"
+)
+
+// GraphML reads and writes graphs in the GraphML format used by yEd, Gephi
+// and NetworkX.
+//
+// Every entry of a vertex or edge Items() map is written as a <data> element
+// with a typed <key> declaration. Go values map to GraphML types as follows:
+// bool to boolean, int, int8, int16, int32 and the unsigned types up to
+// uint16 to int, int64, uint32 and uint64 to long, float32 to float, float64
+// to double, and anything else to string. If a key holds values of different
+// types, the declared type is widened to long, double or string as needed.
+//
+// Reading maps the declared types back to boolean to bool, int to int, long
+// to int64 (uint64 if it does not fit), float to float32, double to float64
+// and string to string. A write/read cycle therefore preserves the values of
+// Items(), but only bool, int, int64, float32, float64 and string keep their
+// Go type: narrower integers widen to int or int64, and the values of a key
+// whose type was widened come back as that type.
+//
+// Weights are written under the reserved attribute name "weight", and
+// edgedefault reflects Traits().IsDirected. An item named "weight" is
+// shadowed by the weight itself.
+//
+// Example:
+//
+//	gml := io.NewGraphML[string, string](func(id string) (string, error) { return id, nil })
+//	if err := gml.WriteGraph(f, g); err != nil {
+//		log.Fatal(err)
+//	}
+type GraphML[K graph.Ordered, T any] struct {
+	parse    func(id string) (T, error)
+	vertexID func(K) string
+}
+
+// GraphMLOption configures a GraphML reader/writer.
+type GraphMLOption[K graph.Ordered, T any] func(*GraphML[K, T])
+
+// NewGraphML creates a GraphML reader/writer that uses parse to convert node
+// IDs into vertex values when reading.
+func NewGraphML[K graph.Ordered, T any](parse func(id string) (T, error), options ...GraphMLOption[K, T]) *GraphML[K, T] {
+	g := &GraphML[K, T]{
+		parse:    parse,
+		vertexID: func(k K) string { return fmt.Sprint(k) },
+	}
+
+	for _, option := range options {
+		option(g)
+	}
+
+	return g
+}
+
+// GraphMLVertexID sets the function used to derive a node ID from a vertex
+// key when writing. The default formats the key with fmt.Sprint.
+func GraphMLVertexID[K graph.Ordered, T any](id func(K) string) GraphMLOption[K, T] {
+	return func(g *GraphML[K, T]) {
+		g.vertexID = id
+	}
+}
+
+type graphmlKey struct {
+	XMLName xml.Name `xml:"key"`
+	ID      string   `xml:"id,attr"`
+	For     string   `xml:"for,attr"`
+	Name    string   `xml:"attr.name,attr"`
+	Type    string   `xml:"attr.type,attr"`
+	Default *string  `xml:"default,omitempty"`
+}
+
+type graphmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphmlNode struct {
+	XMLName xml.Name      `xml:"node"`
+	ID      string        `xml:"id,attr"`
+	Data    []graphmlData `xml:"data"`
+}
+
+type graphmlEdge struct {
+	XMLName xml.Name      `xml:"edge"`
+	ID      string        `xml:"id,attr,omitempty"`
+	Source  string        `xml:"source,attr"`
+	Target  string        `xml:"target,attr"`
+	Data    []graphmlData `xml:"data"`
+}
+
+// WriteGraph writes g to w as a GraphML document.
+func (gml *GraphML[K, T]) WriteGraph(w io.Writer, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	vertices, err := g.Vertices()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	sort.SliceStable(vertices, func(i, j int) bool {
+		return vertices[i].ID() < vertices[j].ID()
+	})
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].Source() == edges[j].Source() {
+			return edges[i].Target() < edges[j].Target()
+		}
+		return edges[i].Source() < edges[j].Source()
+	})
+
+	nodeKeys := newGraphMLKeySet("node")
+	edgeKeys := newGraphMLKeySet("edge")
+	for _, vertex := range vertices {
+		if p := vertex.Properties(); p != nil {
+			nodeKeys.observe(p.Weight(), p.Items())
+		}
+	}
+	for _, edge := range edges {
+		if p := edge.Properties(); p != nil {
+			edgeKeys.observe(p.Weight(), p.Items())
+		}
+	}
+	if g.Traits().IsWeighted {
+		edgeKeys.types[graphmlWeight] = "double"
+	}
+
+	keys := append(nodeKeys.declare("n"), edgeKeys.declare("e")...)
+
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	root := xml.StartElement{
+		Name: xml.Name{Local: "graphml"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns"}, Value: graphmlNamespace},
+		},
+	}
+	if err = enc.EncodeToken(root); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err = enc.Encode(key); err != nil {
+			return err
+		}
+	}
+
+	edgeDefault := "undirected"
+	if g.Traits().IsDirected {
+		edgeDefault = "directed"
+	}
+
+	graphElement := xml.StartElement{
+		Name: xml.Name{Local: "graph"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "id"}, Value: "G"},
+			{Name: xml.Name{Local: "edgedefault"}, Value: edgeDefault},
+		},
+	}
+	if err = enc.EncodeToken(graphElement); err != nil {
+		return err
+	}
+
+	for _, vertex := range vertices {
+		node := graphmlNode{ID: gml.vertexID(vertex.ID())}
+		if p := vertex.Properties(); p != nil {
+			node.Data = nodeKeys.data(p.Weight(), p.Items())
+		}
+		if err = enc.Encode(node); err != nil {
+			return err
+		}
+	}
+
+	for _, edge := range edges {
+		e := graphmlEdge{
+			Source: gml.vertexID(edge.Source()),
+			Target: gml.vertexID(edge.Target()),
+		}
+		if p := edge.Properties(); p != nil {
+			e.Data = edgeKeys.data(p.Weight(), p.Items())
+		}
+		if err = enc.Encode(e); err != nil {
+			return err
+		}
+	}
+
+	if err = enc.EncodeToken(graphElement.End()); err != nil {
+		return err
+	}
+	if err = enc.EncodeToken(root.End()); err != nil {
+		return err
+	}
+	if err = enc.Flush(); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// ReadGraph parses a GraphML document from r and adds the vertices and edges
+// of its first graph to g. It returns graph.ErrGraphTypeMismatch if the
+// document's edgedefault does not match Traits().IsDirected.
+func (gml *GraphML[K, T]) ReadGraph(r io.Reader, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	dec := xml.NewDecoder(r)
+	wrap := func(err error) error {
+		line, column := dec.InputPos()
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, column = syntaxErr.Line, 0
+		}
+		return &ParseError{Format: "graphml", Line: line, Column: column, Err: err}
+	}
+
+	keys := make(map[string]graphmlKey)
+	ids := make(map[string]K)
+	depth := 0
+	inGraph := false
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return wrap(err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+
+			switch {
+			case t.Name.Local == "key":
+				var key graphmlKey
+				if err = dec.DecodeElement(&key, &t); err != nil {
+					return wrap(err)
+				}
+				depth--
+				keys[key.ID] = key
+
+			case t.Name.Local == "graph" && !inGraph:
+				inGraph = true
+				edgeDefault := "undirected"
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "edgedefault" {
+						edgeDefault = attr.Value
+					}
+				}
+				if (edgeDefault == "directed") != g.Traits().IsDirected {
+					return wrap(fmt.Errorf("%w: edgedefault is %s", graph.ErrGraphTypeMismatch, edgeDefault))
+				}
+
+			case t.Name.Local == "node" && inGraph:
+				line, column := dec.InputPos()
+				var node graphmlNode
+				if err = dec.DecodeElement(&node, &t); err != nil {
+					return wrap(err)
+				}
+				depth--
+
+				items, weight, hasWeight, err := graphmlProperties(keys, "node", node.Data)
+				if err != nil {
+					return &ParseError{Format: "graphml", Line: line, Column: column, Err: err}
+				}
+
+				value, err := gml.parse(node.ID)
+				if err != nil {
+					return &ParseError{Format: "graphml", Line: line, Column: column, Err: fmt.Errorf("invalid node ID %q: %w", node.ID, err)}
+				}
+
+				options := []graph.VertexOption{simple.VertexItems(items)}
+				if hasWeight {
+					options = append(options, simple.VertexWeight(weight))
+				}
+				if err = g.AddVertexWithOptions(value, options...); err != nil {
+					return &ParseError{Format: "graphml", Line: line, Column: column, Err: err}
+				}
+				ids[node.ID] = g.Hash()(value)
+
+			case t.Name.Local == "edge" && inGraph:
+				line, column := dec.InputPos()
+				var edge graphmlEdge
+				if err = dec.DecodeElement(&edge, &t); err != nil {
+					return wrap(err)
+				}
+				depth--
+
+				items, weight, hasWeight, err := graphmlProperties(keys, "edge", edge.Data)
+				if err != nil {
+					return &ParseError{Format: "graphml", Line: line, Column: column, Err: err}
+				}
+
+				source, ok := ids[edge.Source]
+				if !ok {
+					return &ParseError{Format: "graphml", Line: line, Column: column, Err: fmt.Errorf("%w: %s", graph.ErrVertexNotFound, edge.Source)}
+				}
+				target, ok := ids[edge.Target]
+				if !ok {
+					return &ParseError{Format: "graphml", Line: line, Column: column, Err: fmt.Errorf("%w: %s", graph.ErrVertexNotFound, edge.Target)}
+				}
+
+				options := []graph.EdgeOption{simple.EdgeItems(items)}
+				if hasWeight {
+					options = append(options, simple.EdgeWeight(weight))
+				}
+				if err = g.AddEdgeWithOptions(source, target, options...); err != nil {
+					return &ParseError{Format: "graphml", Line: line, Column: column, Err: err}
+				}
+			}
+
+		case xml.EndElement:
+			depth--
+			if t.Name.Local == "graph" && inGraph && depth <= 1 {
+				// Only the first top-level graph is read.
+				return nil
+			}
+		}
+	}
+}
+
+// graphmlKeySet accumulates the attribute types seen for one element domain
+// ("node" or "edge") while writing.
+type graphmlKeySet struct {
+	domain string
+	types  map[string]string
+	ids    map[string]string
+}
+
+func newGraphMLKeySet(domain string) *graphmlKeySet {
+	return &graphmlKeySet{
+		domain: domain,
+		types:  make(map[string]string),
+		ids:    make(map[string]string),
+	}
+}
+
+// observe records the types of the given weight and items.
+func (s *graphmlKeySet) observe(weight float64, items map[string]any) {
+	for name, value := range items {
+		if name == graphmlWeight {
+			continue
+		}
+		s.types[name] = widenGraphMLType(s.types[name], graphmlType(value))
+	}
+
+	if weight != 0 {
+		s.types[graphmlWeight] = "double"
+	}
+}
+
+// declare assigns key IDs in name order and returns the key declarations.
+func (s *graphmlKeySet) declare(prefix string) []graphmlKey {
+	names := sortedKeys(s.types)
+	keys := make([]graphmlKey, 0, len(names))
+	for i, name := range names {
+		id := prefix + strconv.Itoa(i)
+		s.ids[name] = id
+		keys = append(keys, graphmlKey{ID: id, For: s.domain, Name: name, Type: s.types[name]})
+	}
+	return keys
+}
+
+// data returns the <data> elements for the given weight and items.
+func (s *graphmlKeySet) data(weight float64, items map[string]any) []graphmlData {
+	var data []graphmlData
+	for _, name := range sortedKeys(items) {
+		if name == graphmlWeight {
+			continue
+		}
+		data = append(data, graphmlData{Key: s.ids[name], Value: formatGraphMLValue(items[name])})
+	}
+
+	if id, ok := s.ids[graphmlWeight]; ok && (weight != 0 || s.domain == "edge") {
+		data = append(data, graphmlData{Key: id, Value: strconv.FormatFloat(weight, 'g', -1, 64)})
+	}
+
+	return data
+}
+
+// graphmlType returns the GraphML attr.type for a Go value.
+func graphmlType(v any) string {
+	switch v.(type) {
+	case bool:
+		return "boolean"
+	case int, int8, int16, int32, uint8, uint16:
+		return "int"
+	case int64, uint, uint32, uint64:
+		return "long"
+	case float32:
+		return "float"
+	case float64:
+		return "double"
+	default:
+		return "string"
+	}
+}
+
+// widenGraphMLType returns a type that can represent values of both a and b.
+func widenGraphMLType(a, b string) string {
+	if a == "" || a == b {
+		return b
+	}
+
+	numeric := map[string]int{"int": 1, "long": 2, "float": 3, "double": 4}
+	ra, aok := numeric[a]
+	rb, bok := numeric[b]
+	switch {
+	case !aok || !bok:
+		return "string"
+	case ra >= 3 || rb >= 3:
+		return "double"
+	default:
+		return "long"
+	}
+}
+
+// formatGraphMLValue formats a Go value as GraphML character data.
+func formatGraphMLValue(v any) string {
+	switch x := v.(type) {
+	case float32:
+		return strconv.FormatFloat(float64(x), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseGraphMLValue converts GraphML character data of the given attr.type
+// into a Go value.
+func parseGraphMLValue(typ, s string) (any, error) {
+	switch typ {
+	case "boolean":
+		return strconv.ParseBool(s)
+	case "int":
+		return strconv.Atoi(s)
+	case "long":
+		i, err := strconv.ParseInt(s, 10, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			if u, uerr := strconv.ParseUint(s, 10, 64); uerr == nil {
+				return u, nil
+			}
+		}
+		return i, err
+	case "float":
+		f, err := strconv.ParseFloat(s, 32)
+		return float32(f), err
+	case "double":
+		return strconv.ParseFloat(s, 64)
+	default:
+		return s, nil
+	}
+}
+
+// graphmlProperties converts the <data> elements of a node or edge, along with
+// the defaults declared for its domain, into items and a weight.
+func graphmlProperties(keys map[string]graphmlKey, domain string, data []graphmlData) (map[string]any, float64, bool, error) {
+	values := make(map[string]string)
+	for id, key := range keys {
+		if key.Default != nil && (key.For == domain || key.For == "all") {
+			values[id] = *key.Default
+		}
+	}
+	for _, d := range data {
+		values[d.Key] = d.Value
+	}
+
+	items := make(map[string]any, len(values))
+	var weight float64
+	var hasWeight bool
+
+	for id, raw := range values {
+		key, ok := keys[id]
+		if !ok {
+			return nil, 0, false, fmt.Errorf("undeclared key %q", id)
+		}
+
+		name := key.Name
+		if name == "" {
+			name = id
+		}
+
+		value, err := parseGraphMLValue(key.Type, raw)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("invalid value %q for key %q: %w", raw, id, err)
+		}
+
+		if name == graphmlWeight {
+			if f, ok := graphmlFloat(value); ok {
+				weight, hasWeight = f, true
+				continue
+			}
+		}
+
+		items[name] = value
+	}
+
+	return items, weight, hasWeight, nil
+}
+
+// graphmlFloat converts a parsed numeric GraphML value to a float64.
+func graphmlFloat(v any) (float64, bool) {
+	switch x := v.(type) {
+	case int:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	case float32:
+		return float64(x), true
+	case float64:
+		return x, true
+	default:
+		return 0, false
+	}
+}
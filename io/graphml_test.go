@@ -0,0 +1,167 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/sets"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphML_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, directed := range []bool{true, false} {
+		options := []func(*graph.Traits){graph.Weighted()}
+		if directed {
+			options = append(options, graph.Directed())
+		}
+
+		g, _ := simple.New(graph.StringHash, options...)
+		is := assert.New(t)
+		is.NoError(g.AddVertexWithOptions("A", simple.VertexItems(map[string]any{
+			"label":  "Alpha",
+			"count":  3,
+			"big":    int64(1) << 40,
+			"ratio":  0.25,
+			"active": true,
+		})))
+		is.NoError(g.AddVertexWithOptions("B", simple.VertexWeight(1.5)))
+		is.NoError(g.AddVertexWithOptions("C"))
+		is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(2), simple.EdgeItem("kind", "dep")))
+		is.NoError(g.AddEdgeWithOptions("B", "C", simple.EdgeWeight(0.5)))
+
+		gml := NewGraphML[string, string](parseStringID)
+
+		var buf bytes.Buffer
+		is.NoError(gml.WriteGraph(&buf, g))
+
+		h, _ := simple.New(graph.StringHash, options...)
+		is.NoError(gml.ReadGraph(&buf, h))
+
+		equal, err := sets.Equals(g, h)
+		is.NoError(err)
+		is.True(equal)
+
+		a, _ := h.Vertex("A")
+		is.Equal(map[string]any{
+			"label":  "Alpha",
+			"count":  3,
+			"big":    int64(1) << 40,
+			"ratio":  0.25,
+			"active": true,
+		}, a.Properties().Items())
+
+		b, _ := h.Vertex("B")
+		is.Equal(1.5, b.Properties().Weight())
+
+		e, _ := h.Edge("A", "B")
+		is.Equal("dep", e.Properties().Items()["kind"])
+	}
+}
+
+func TestGraphML_ReadTypes(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.IntHash, graph.Directed())
+	is.NoError(g.AddVertexWithOptions(1, simple.VertexItems(map[string]any{
+		"small": int8(4),
+		"wide":  uint32(7),
+		"huge":  uint64(1) << 63,
+		"mixed": 1,
+	})))
+	is.NoError(g.AddVertexWithOptions(2, simple.VertexItem("mixed", 2.5)))
+
+	gml := NewGraphML[int, int](func(id string) (int, error) { return strconv.Atoi(id) })
+
+	var buf bytes.Buffer
+	is.NoError(gml.WriteGraph(&buf, g))
+
+	h, _ := simple.New(graph.IntHash, graph.Directed())
+	is.NoError(gml.ReadGraph(&buf, h))
+
+	v, _ := h.Vertex(1)
+	is.Equal(map[string]any{
+		"small": 4,
+		"wide":  int64(7),
+		"huge":  uint64(1) << 63,
+		"mixed": 1.0,
+	}, v.Properties().Items())
+}
+
+func TestGraphML_WriteKeys(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.IntHash, graph.Directed())
+	is.NoError(g.AddVertexWithOptions(1, simple.VertexItem("score", 1)))
+	is.NoError(g.AddVertexWithOptions(2, simple.VertexItem("score", 2.5)))
+
+	var buf bytes.Buffer
+	is.NoError(NewGraphML[int, int](nil).WriteGraph(&buf, g))
+
+	out := buf.String()
+	is.Contains(out, `<key id="n0" for="node" attr.name="score" attr.type="double"></key>`)
+	is.Contains(out, `<graph id="G" edgedefault="directed">`)
+}
+
+func TestGraphML_ReadDefaults(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="color" attr.type="string">
+    <default>yellow</default>
+  </key>
+  <key id="d1" for="edge" attr.name="weight" attr.type="double"/>
+  <graph id="G" edgedefault="undirected">
+    <node id="n0"><data key="d0">green</data></node>
+    <node id="n1"/>
+    <edge id="e0" source="n0" target="n1"><data key="d1">1.0</data></edge>
+  </graph>
+</graphml>`
+
+	g, _ := simple.New(graph.StringHash)
+	is.NoError(NewGraphML[string, string](parseStringID).ReadGraph(strings.NewReader(src), g))
+
+	n0, _ := g.Vertex("n0")
+	is.Equal("green", n0.Properties().Items()["color"])
+	n1, _ := g.Vertex("n1")
+	is.Equal("yellow", n1.Properties().Items()["color"])
+
+	e, err := g.Edge("n1", "n0")
+	is.NoError(err)
+	is.Equal(1.0, e.Properties().Weight())
+}
+
+func TestGraphML_ReadErrors(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash, graph.Directed())
+	err := NewGraphML[string, string](parseStringID).ReadGraph(strings.NewReader(
+		`<graphml><graph edgedefault="undirected"></graph></graphml>`), g)
+	is.ErrorIs(err, graph.ErrGraphTypeMismatch)
+
+	err = NewGraphML[string, string](parseStringID).ReadGraph(strings.NewReader(
+		"<graphml>\n<graph edgedefault=\"directed\">\n<edge source=\"a\" target=\"b\"/>\n</graph></graphml>"), g)
+	var perr *ParseError
+	is.True(errors.As(err, &perr))
+	is.ErrorIs(err, graph.ErrVertexNotFound)
+	is.Equal(3, perr.Line)
+
+	err = NewGraphML[string, string](parseStringID).ReadGraph(strings.NewReader("<graphml>\n<graph"), g)
+	is.True(errors.As(err, &perr))
+}
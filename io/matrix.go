@@ -0,0 +1,106 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sixafter/graph"
+)
+
+// matrixOptions holds the settings shared by the matrix formats.
+type matrixOptions[K graph.Ordered, T any] struct {
+	vertex func(i int) (T, error)
+	index  func(K) (int, bool)
+}
+
+// MatrixOption configures a MatrixMarket or AdjacencyMatrix reader/writer.
+type MatrixOption[K graph.Ordered, T any] func(*matrixOptions[K, T])
+
+// MatrixIndex sets the function that maps a vertex key to its zero-based row
+// and column when writing. Every vertex must map to a distinct index below
+// Order(). By default vertices are numbered in ascending key order.
+func MatrixIndex[K graph.Ordered, T any](index func(K) (int, bool)) MatrixOption[K, T] {
+	return func(o *matrixOptions[K, T]) {
+		o.index = index
+	}
+}
+
+// newMatrixOptions applies options on top of the defaults. vertex converts a
+// zero-based index into a vertex value when reading.
+func newMatrixOptions[K graph.Ordered, T any](vertex func(i int) (T, error), options []MatrixOption[K, T]) matrixOptions[K, T] {
+	o := matrixOptions[K, T]{vertex: vertex}
+	for _, option := range options {
+		option(&o)
+	}
+	return o
+}
+
+// indices returns the zero-based index of every vertex of g, along with the
+// matrix dimension.
+func (o *matrixOptions[K, T]) indices(g graph.Interface[K, T]) (map[K]int, int, error) {
+	vertices, err := g.Vertices()
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	keys := make([]K, len(vertices))
+	for i, vertex := range vertices {
+		keys[i] = vertex.ID()
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	indices := make(map[K]int, len(keys))
+	used := make([]bool, len(keys))
+	for i, key := range keys {
+		if o.index != nil {
+			var ok bool
+			if i, ok = o.index(key); !ok {
+				return nil, 0, fmt.Errorf("no matrix index for vertex %v", key)
+			}
+			if i < 0 || i >= len(keys) {
+				return nil, 0, fmt.Errorf("matrix index %d of vertex %v is out of range [0, %d)", i, key, len(keys))
+			}
+			if used[i] {
+				return nil, 0, fmt.Errorf("matrix index %d of vertex %v is already in use", i, key)
+			}
+			used[i] = true
+		}
+		indices[key] = i
+	}
+
+	return indices, len(keys), nil
+}
+
+// addVertices adds the n vertices of a matrix to g, skipping those that
+// already exist, and returns their keys by index.
+func (o *matrixOptions[K, T]) addVertices(g graph.Interface[K, T], n int) ([]K, error) {
+	keys := make([]K, n)
+	for i := range keys {
+		value, err := o.vertex(i)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vertex for index %d: %w", i, err)
+		}
+
+		key := g.Hash()(value)
+		exists, err := g.HasVertex(key)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			if err = g.AddVertexWithOptions(value); err != nil {
+				return nil, err
+			}
+		}
+
+		keys[i] = key
+	}
+
+	return keys, nil
+}
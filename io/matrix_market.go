@@ -0,0 +1,245 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+)
+
+// MatrixMarket reads and writes graphs in the Matrix Market coordinate format
+// (.mtx) used by SciPy, MATLAB and the SuiteSparse collection. Each stored
+// entry (i, j) is an edge from the vertex at row i to the vertex at column j.
+//
+// Directed graphs map to general matrices and undirected graphs to symmetric
+// ones, of which only the lower triangle is stored. Weighted graphs are
+// written as real matrices holding the edge weights, and unweighted graphs as
+// pattern matrices. When reading, real, integer and pattern matrices are
+// accepted, and entry values become edge weights only if the target graph is
+// weighted.
+//
+// Matrix Market indices are 1-based; the index mapping seen by the vertex
+// function and MatrixIndex is zero-based.
+//
+// Example:
+//
+//	mm := io.NewMatrixMarket[int, int](func(i int) (int, error) { return i, nil })
+//	if err := mm.ReadGraph(f, g); err != nil {
+//		log.Fatal(err)
+//	}
+type MatrixMarket[K graph.Ordered, T any] struct {
+	matrixOptions[K, T]
+}
+
+// NewMatrixMarket creates a Matrix Market reader/writer that uses vertex to
+// convert a zero-based row or column index into a vertex value when reading.
+func NewMatrixMarket[K graph.Ordered, T any](vertex func(i int) (T, error), options ...MatrixOption[K, T]) *MatrixMarket[K, T] {
+	return &MatrixMarket[K, T]{matrixOptions: newMatrixOptions(vertex, options)}
+}
+
+// WriteGraph writes g to w as a Matrix Market coordinate matrix.
+func (mm *MatrixMarket[K, T]) WriteGraph(w io.Writer, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	indices, n, err := mm.indices(g)
+	if err != nil {
+		return err
+	}
+
+	edges, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	type entry struct {
+		row, column int
+		weight      float64
+	}
+
+	traits := g.Traits()
+	entries := make([]entry, 0, len(edges))
+	for _, edge := range edges {
+		e := entry{row: indices[edge.Source()], column: indices[edge.Target()]}
+		if !traits.IsDirected && e.row < e.column {
+			e.row, e.column = e.column, e.row
+		}
+		if p := edge.Properties(); p != nil {
+			e.weight = p.Weight()
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].row == entries[j].row {
+			return entries[i].column < entries[j].column
+		}
+		return entries[i].row < entries[j].row
+	})
+
+	field, symmetry := "pattern", "general"
+	if traits.IsWeighted {
+		field = "real"
+	}
+	if !traits.IsDirected {
+		symmetry = "symmetric"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%%%%MatrixMarket matrix coordinate %s %s\n", field, symmetry)
+	fmt.Fprintf(bw, "%d %d %d\n", n, n, len(entries))
+	for _, e := range entries {
+		if traits.IsWeighted {
+			fmt.Fprintf(bw, "%d %d %s\n", e.row+1, e.column+1, strconv.FormatFloat(e.weight, 'g', -1, 64))
+		} else {
+			fmt.Fprintf(bw, "%d %d\n", e.row+1, e.column+1)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ReadGraph parses a Matrix Market coordinate matrix from r and adds a vertex
+// for every row and an edge for every stored entry to g. It returns
+// graph.ErrGraphTypeMismatch if a symmetric matrix is read into a directed
+// graph or a general one into an undirected graph.
+func (mm *MatrixMarket[K, T]) ReadGraph(r io.Reader, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	fail := func(err error) error {
+		return &ParseError{Format: "mtx", Line: line, Err: err}
+	}
+
+	// next returns the fields of the next line that is neither blank nor a
+	// comment.
+	next := func() ([]string, error) {
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text != "" && !strings.HasPrefix(text, "%") {
+				return strings.Fields(text), nil
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	if !scanner.Scan() {
+		line++
+		if err := scanner.Err(); err != nil {
+			return fail(err)
+		}
+		return fail(errors.New("missing %%MatrixMarket header"))
+	}
+	line++
+
+	header := strings.Fields(strings.ToLower(scanner.Text()))
+	if len(header) != 5 || header[0] != "%%matrixmarket" || header[1] != "matrix" {
+		return fail(errors.New("missing %%MatrixMarket matrix header"))
+	}
+	if header[2] != "coordinate" {
+		return fail(fmt.Errorf("unsupported format %q", header[2]))
+	}
+
+	field, symmetry := header[3], header[4]
+	switch field {
+	case "real", "integer", "pattern":
+	default:
+		return fail(fmt.Errorf("unsupported field %q", field))
+	}
+	switch symmetry {
+	case "general", "symmetric":
+	default:
+		return fail(fmt.Errorf("unsupported symmetry %q", symmetry))
+	}
+	if (symmetry == "general") != g.Traits().IsDirected {
+		return fail(fmt.Errorf("%w: matrix is %s", graph.ErrGraphTypeMismatch, symmetry))
+	}
+
+	fields, err := next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = errors.New("missing size line")
+		}
+		return fail(err)
+	}
+	if len(fields) != 3 {
+		return fail(fmt.Errorf("expected 3 fields in size line, found %d", len(fields)))
+	}
+	size := make([]int, 3)
+	for i, f := range fields {
+		if size[i], err = strconv.Atoi(f); err != nil || size[i] < 0 {
+			return fail(fmt.Errorf("invalid size %q", f))
+		}
+	}
+	if size[0] != size[1] {
+		return fail(fmt.Errorf("matrix is %dx%d, not square", size[0], size[1]))
+	}
+
+	keys, err := mm.addVertices(g, size[0])
+	if err != nil {
+		return fail(err)
+	}
+
+	width := 3
+	if field == "pattern" {
+		width = 2
+	}
+
+	for count := 0; ; count++ {
+		fields, err = next()
+		if errors.Is(err, io.EOF) {
+			if count != size[2] {
+				return fail(fmt.Errorf("expected %d entries, found %d", size[2], count))
+			}
+			return nil
+		}
+		if err != nil {
+			return fail(err)
+		}
+		if count == size[2] {
+			return fail(fmt.Errorf("more than %d entries", size[2]))
+		}
+		if len(fields) != width {
+			return fail(fmt.Errorf("expected %d fields, found %d", width, len(fields)))
+		}
+
+		var ends [2]int
+		for i := range ends {
+			ends[i], err = strconv.Atoi(fields[i])
+			if err != nil || ends[i] < 1 || ends[i] > size[0] {
+				return fail(fmt.Errorf("index %q is out of range [1, %d]", fields[i], size[0]))
+			}
+		}
+
+		var options []graph.EdgeOption
+		if field != "pattern" && g.Traits().IsWeighted {
+			weight, err := strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return fail(fmt.Errorf("invalid value %q: %w", fields[2], err))
+			}
+			options = append(options, simple.EdgeWeight(weight))
+		}
+
+		if err = g.AddEdgeWithOptions(keys[ends[0]-1], keys[ends[1]-1], options...); err != nil {
+			return fail(err)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/sets"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func indexVertex(i int) (int, error) {
+	return i, nil
+}
+
+func TestMatrixMarket_Write(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash, graph.Weighted())
+	for _, v := range []string{"a", "b", "c"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("a", "b", simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("c", "b", simple.EdgeWeight(0.5)))
+
+	var buf bytes.Buffer
+	is.NoError(NewMatrixMarket[string, string](nil).WriteGraph(&buf, g))
+	is.Equal("%%MatrixMarket matrix coordinate real symmetric\n3 3 2\n2 1 2\n3 2 0.5\n", buf.String())
+
+	order := map[string]int{"c": 0, "b": 1, "a": 2}
+	index := MatrixIndex[string, string](func(k string) (int, bool) {
+		i, ok := order[k]
+		return i, ok
+	})
+
+	h, _ := simple.New(graph.StringHash, graph.Directed())
+	for _, v := range []string{"a", "b", "c"} {
+		is.NoError(h.AddVertexWithOptions(v))
+	}
+	is.NoError(h.AddEdgeWithOptions("a", "b"))
+
+	buf.Reset()
+	is.NoError(NewMatrixMarket(nil, index).WriteGraph(&buf, h))
+	is.Equal("%%MatrixMarket matrix coordinate pattern general\n3 3 1\n3 2\n", buf.String())
+}
+
+func TestMatrixMarket_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, directed := range []bool{true, false} {
+		is := assert.New(t)
+
+		options := []func(*graph.Traits){graph.Weighted()}
+		if directed {
+			options = append(options, graph.Directed())
+		}
+
+		g, _ := simple.New(graph.IntHash, options...)
+		for i := 0; i < 5; i++ {
+			is.NoError(g.AddVertexWithOptions(i))
+		}
+		is.NoError(g.AddEdgeWithOptions(0, 1, simple.EdgeWeight(1.5)))
+		is.NoError(g.AddEdgeWithOptions(3, 1, simple.EdgeWeight(-2)))
+		is.NoError(g.AddEdgeWithOptions(2, 2, simple.EdgeWeight(4)))
+
+		mm := NewMatrixMarket[int, int](indexVertex)
+
+		var buf bytes.Buffer
+		is.NoError(mm.WriteGraph(&buf, g))
+
+		h, _ := simple.New(graph.IntHash, options...)
+		is.NoError(mm.ReadGraph(&buf, h))
+
+		equal, err := sets.Equals(g, h)
+		is.NoError(err)
+		is.True(equal)
+	}
+}
+
+func TestMatrixMarket_Read(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := `%%MatrixMarket matrix coordinate integer general
+% a comment
+3 3 2
+1 2 7
+3 1 -1
+`
+	g, _ := simple.New(graph.StringHash, graph.Directed())
+	mm := NewMatrixMarket[string, string](func(i int) (string, error) { return "v" + strconv.Itoa(i), nil })
+	is.NoError(mm.ReadGraph(strings.NewReader(src), g))
+
+	order, _ := g.Order()
+	is.Equal(3, order)
+
+	e, err := g.Edge("v0", "v1")
+	is.NoError(err)
+	is.Equal(float64(0), e.Properties().Weight())
+
+	ok, _ := g.HasEdge("v2", "v0")
+	is.True(ok)
+}
+
+func TestMatrixMarket_ReadErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		src      string
+		directed bool
+		line     int
+		err      error
+	}{
+		{name: "missing header", src: "3 3 0\n", directed: true, line: 1},
+		{name: "array format", src: "%%MatrixMarket matrix array real general\n", directed: true, line: 1},
+		{name: "symmetric into directed", src: "%%MatrixMarket matrix coordinate real symmetric\n", directed: true, line: 1, err: graph.ErrGraphTypeMismatch},
+		{name: "not square", src: "%%MatrixMarket matrix coordinate pattern general\n2 3 0\n", directed: true, line: 2},
+		{name: "index out of range", src: "%%MatrixMarket matrix coordinate pattern general\n2 2 1\n1 3\n", directed: true, line: 3},
+		{name: "missing value", src: "%%MatrixMarket matrix coordinate real general\n2 2 1\n1 2\n", directed: true, line: 3},
+		{name: "too few entries", src: "%%MatrixMarket matrix coordinate pattern symmetric\n2 2 2\n2 1\n", line: 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			var options []func(*graph.Traits)
+			if tc.directed {
+				options = append(options, graph.Directed())
+			}
+			g, _ := simple.New(graph.IntHash, options...)
+			err := NewMatrixMarket[int, int](indexVertex).ReadGraph(strings.NewReader(tc.src), g)
+
+			var perr *ParseError
+			is.True(errors.As(err, &perr), "expected a ParseError, got %v", err)
+			if perr != nil {
+				is.Equal(tc.line, perr.Line, err.Error())
+			}
+			if tc.err != nil {
+				is.ErrorIs(err, tc.err)
+			}
+		})
+	}
+}
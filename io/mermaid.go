@@ -0,0 +1,97 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sixafter/graph"
+)
+
+// mermaidEscaper replaces the characters that would end or corrupt a quoted
+// Mermaid label with entity codes.
+var mermaidEscaper = strings.NewReplacer(
+	"#", "#35;",
+	`"`, "#quot;",
+	"<", "#lt;",
+	">", "#gt;",
+	"|", "#124;",
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+// MermaidWriter writes graphs as Mermaid flowcharts, which GitHub renders in
+// Markdown inside a ```mermaid fenced block. Directed edges are drawn as
+// arrows (-->) and undirected edges as lines (---).
+//
+// Vertices are given generated node IDs, so keys of any type and content are
+// safe; labels are quoted and escaped with Mermaid entity codes. With
+// DiagramPartition, each community is drawn as a subgraph. The output is
+// stable, with vertices and edges written in key order.
+//
+// Example:
+//
+//	w := io.NewMermaidWriter(io.DiagramDirection[string, string](io.LeftRight))
+//	if err := w.WriteGraph(os.Stdout, g); err != nil {
+//		log.Fatal(err)
+//	}
+type MermaidWriter[K graph.Ordered, T any] struct {
+	diagramOptions[K, T]
+}
+
+// NewMermaidWriter creates a MermaidWriter configured with the given options.
+func NewMermaidWriter[K graph.Ordered, T any](options ...DiagramOption[K, T]) *MermaidWriter[K, T] {
+	return &MermaidWriter[K, T]{diagramOptions: newDiagramOptions(options)}
+}
+
+// WriteGraph writes g to w as a Mermaid flowchart.
+func (m *MermaidWriter[K, T]) WriteGraph(w io.Writer, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	d, err := m.prepare(g)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("flowchart " + string(m.direction) + "\n")
+
+	node := func(indent string, vertex graph.Vertex[K, T]) {
+		bw.WriteString(indent + d.ids[vertex.ID()] + `["` + mermaidEscaper.Replace(m.vertexLabel(vertex)) + "\"]\n")
+	}
+
+	for i, community := range d.groups {
+		bw.WriteString("    subgraph g" + strconv.Itoa(i) + ` ["` + mermaidEscaper.Replace(m.partitionLabel(community)) + "\"]\n")
+		for _, vertex := range d.members[community] {
+			node("        ", vertex)
+		}
+		bw.WriteString("    end\n")
+	}
+
+	for _, vertex := range d.ungrouped {
+		node("    ", vertex)
+	}
+
+	link := "---"
+	if d.isDirected {
+		link = "-->"
+	}
+
+	for _, edge := range d.edges {
+		bw.WriteString("    " + d.ids[edge.Source()] + " " + link)
+		if label := m.edgeLabel(edge); label != "" {
+			bw.WriteString(`|"` + mermaidEscaper.Replace(label) + `"|`)
+		}
+		bw.WriteString(" " + d.ids[edge.Target()] + "\n")
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func diagramTestGraph(is *assert.Assertions, options ...func(*graph.Traits)) graph.Interface[string, string] {
+	g, _ := simple.New(graph.StringHash, options...)
+	is.NoError(g.AddVertexWithOptions("build"))
+	is.NoError(g.AddVertexWithOptions(`say "hi" <now>`))
+	is.NoError(g.AddVertexWithOptions("test"))
+	is.NoError(g.AddEdgeWithOptions("build", "test", simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions(`say "hi" <now>`, "build", simple.EdgeItem("label", "a|b")))
+	return g
+}
+
+func TestMermaidWriter_Flowchart(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var buf bytes.Buffer
+	is.NoError(NewMermaidWriter[string, string]().WriteGraph(&buf, diagramTestGraph(is, graph.Directed())))
+
+	is.Equal(`flowchart TD
+    n0["build"]
+    n1["say #quot;hi#quot; #lt;now#gt;"]
+    n2["test"]
+    n0 -->|"2"| n2
+    n1 -->|"a#124;b"| n0
+`, buf.String())
+}
+
+func TestMermaidWriter_Partition(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	w := NewMermaidWriter(
+		DiagramDirection[string, string](LeftRight),
+		DiagramPartition[string, string](map[string]int{"build": 1, "test": 1}),
+		DiagramPartitionLabel[string, string](func(c int) string { return "stage #1" }),
+		DiagramEdgeLabel[string, string](func(graph.Edge[string]) string { return "" }),
+	)
+
+	var buf bytes.Buffer
+	is.NoError(w.WriteGraph(&buf, diagramTestGraph(is)))
+
+	is.Equal(`flowchart LR
+    subgraph g0 ["stage #35;1"]
+        n0["build"]
+        n2["test"]
+    end
+    n1["say #quot;hi#quot; #lt;now#gt;"]
+    n0 --- n1
+    n0 --- n2
+`, buf.String())
+}
@@ -0,0 +1,605 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+)
+
+const (
+	// defaultNodeLinkBatchSize is the batch size used by streaming writes when
+	// none is given.
+	defaultNodeLinkBatchSize = 1024
+)
+
+// nodeLinkReserved lists the member names a node-link document uses for its
+// own purposes. Items with these names are not written.
+var nodeLinkReserved = map[string]bool{
+	"id":       true,
+	"value":    true,
+	"source":   true,
+	"target":   true,
+	"key":      true,
+	"weight":   true,
+	"metadata": true,
+}
+
+// NodeLink reads and writes graphs as node-link JSON documents, the format
+// produced by NetworkX's node_link_data and consumed by D3:
+//
+//	{
+//	  "directed": true,
+//	  "multigraph": false,
+//	  "graph": {"acyclic": false, "rooted": false, "weighted": true, "preventCycles": false},
+//	  "nodes": [{"id": "A", "value": "A", "color": "red"}],
+//	  "links": [{"source": "A", "target": "B", "weight": 2}]
+//	}
+//
+// Vertex values are encoded with the configured Codec, which must produce
+// JSON; the default is JSONCodec. Items() entries become members of their node
+// or link object, weights are written as "weight" and metadata as "metadata".
+// Items whose names collide with those members, "id", "value", "source",
+// "target" or "key" are skipped.
+//
+// When reading, a node without a "value" member is decoded from its "id", so
+// plain NetworkX documents can be loaded with the default codec. Links may be
+// held in either a "links" or an "edges" array, and must follow the nodes they
+// reference unless those vertices already exist in the target graph. Numeric
+// items are restored as int when they are integral and as float64 otherwise.
+//
+// Example:
+//
+//	nl := io.NewNodeLink[string, string]()
+//	if err := nl.WriteGraph(w, g); err != nil {
+//		log.Fatal(err)
+//	}
+type NodeLink[K graph.Ordered, T any] struct {
+	codec     Codec[T]
+	streaming bool
+	batchSize int
+}
+
+// NodeLinkOption configures a NodeLink reader/writer.
+type NodeLinkOption[K graph.Ordered, T any] func(*NodeLink[K, T])
+
+// NewNodeLink creates a node-link JSON reader/writer.
+func NewNodeLink[K graph.Ordered, T any](options ...NodeLinkOption[K, T]) *NodeLink[K, T] {
+	nl := &NodeLink[K, T]{
+		codec:     JSONCodec[T](),
+		batchSize: defaultNodeLinkBatchSize,
+	}
+
+	for _, option := range options {
+		option(nl)
+	}
+
+	return nl
+}
+
+// NodeLinkCodec sets the codec used for vertex values.
+func NodeLinkCodec[K graph.Ordered, T any](codec Codec[T]) NodeLinkOption[K, T] {
+	return func(nl *NodeLink[K, T]) {
+		nl.codec = codec
+	}
+}
+
+// NodeLinkStreaming makes writes pull vertices and edges through
+// StreamVerticesWithContext and StreamEdgesWithContext in batches of
+// batchSize, encoding each one as it arrives instead of listing the whole
+// graph first. Vertices and edges are then written in the order the graph
+// streams them rather than in key order. A batchSize of zero or less selects
+// a default.
+func NodeLinkStreaming[K graph.Ordered, T any](batchSize int) NodeLinkOption[K, T] {
+	return func(nl *NodeLink[K, T]) {
+		nl.streaming = true
+		if batchSize > 0 {
+			nl.batchSize = batchSize
+		}
+	}
+}
+
+// WriteGraph writes g to w as a node-link JSON document.
+func (nl *NodeLink[K, T]) WriteGraph(w io.Writer, g graph.Interface[K, T]) error {
+	return nl.WriteGraphWithContext(context.Background(), w, g)
+}
+
+// WriteGraphWithContext writes g to w as a node-link JSON document, stopping
+// early if ctx is canceled. Vertices and edges are written in key order,
+// unless NodeLinkStreaming is set.
+func (nl *NodeLink[K, T]) WriteGraphWithContext(ctx context.Context, w io.Writer, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	traits := g.Traits()
+	bw := bufio.NewWriter(w)
+
+	_, err := fmt.Fprintf(bw, `{"directed":%t,"multigraph":%t,"graph":{"acyclic":%t,"rooted":%t,"weighted":%t,"preventCycles":%t},"nodes":[`,
+		traits.IsDirected, traits.IsMultiGraph, traits.IsAcyclic, traits.IsRooted, traits.IsWeighted, traits.PreventCycles)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	sep := "\n"
+
+	err = nl.eachVertex(ctx, g, func(vertex graph.Vertex[K, T]) error {
+		buf.Reset()
+		buf.WriteString(sep)
+		if err := nl.encodeVertex(&buf, vertex); err != nil {
+			return err
+		}
+		sep = ",\n"
+		_, err := bw.Write(buf.Bytes())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err = bw.WriteString("\n],\"links\":["); err != nil {
+		return err
+	}
+
+	sep = "\n"
+	err = nl.eachEdge(ctx, g, func(edge graph.Edge[K]) error {
+		buf.Reset()
+		buf.WriteString(sep)
+		if err := encodeNodeLinkEdge(&buf, edge, traits.IsWeighted); err != nil {
+			return err
+		}
+		sep = ",\n"
+		_, err := bw.Write(buf.Bytes())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err = bw.WriteString("\n]}\n"); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// eachVertex calls fn for every vertex of g, in key order or, when the writer
+// is in streaming mode, in the order they are streamed.
+func (nl *NodeLink[K, T]) eachVertex(ctx context.Context, g graph.Interface[K, T], fn func(graph.Vertex[K, T]) error) error {
+	if !nl.streaming {
+		vertices, err := g.Vertices()
+		if err != nil {
+			return fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+		}
+		sort.SliceStable(vertices, func(i, j int) bool {
+			return vertices[i].ID() < vertices[j].ID()
+		})
+		for _, vertex := range vertices {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+			if err = fn(vertex); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch := make(chan []graph.Vertex[K, T])
+	errc := make(chan error, 1)
+	go func() {
+		_, err := g.StreamVerticesWithContext(ctx, simple.EmptyCursor(), nl.batchSize, ch)
+		errc <- err
+	}()
+
+	var err error
+	for batch := range ch {
+		for _, vertex := range batch {
+			if err != nil {
+				break
+			}
+			if err = fn(vertex); err != nil {
+				cancel()
+			}
+		}
+	}
+
+	if streamErr := <-errc; err == nil && streamErr != nil {
+		err = fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, streamErr)
+	}
+
+	return err
+}
+
+// eachEdge calls fn for every edge of g, streaming them when the writer is in
+// streaming mode.
+func (nl *NodeLink[K, T]) eachEdge(ctx context.Context, g graph.Interface[K, T], fn func(graph.Edge[K]) error) error {
+	if !nl.streaming {
+		edges, err := g.Edges()
+		if err != nil {
+			return fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+		}
+		sort.SliceStable(edges, func(i, j int) bool {
+			if edges[i].Source() == edges[j].Source() {
+				return edges[i].Target() < edges[j].Target()
+			}
+			return edges[i].Source() < edges[j].Source()
+		})
+		for _, edge := range edges {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+			if err = fn(edge); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch := make(chan graph.Edge[K])
+	errc := make(chan error, 1)
+	go func() {
+		_, err := g.StreamEdgesWithContext(ctx, simple.EmptyCursor(), nl.batchSize, ch)
+		errc <- err
+	}()
+
+	var err error
+	for edge := range ch {
+		if err != nil {
+			continue
+		}
+		if err = fn(edge); err != nil {
+			cancel()
+		}
+	}
+
+	if streamErr := <-errc; err == nil && streamErr != nil {
+		err = fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, streamErr)
+	}
+
+	return err
+}
+
+// encodeVertex appends the node object of vertex to buf.
+func (nl *NodeLink[K, T]) encodeVertex(buf *bytes.Buffer, vertex graph.Vertex[K, T]) error {
+	buf.WriteString(`{"id":`)
+	if err := writeJSON(buf, vertex.ID()); err != nil {
+		return err
+	}
+
+	value, err := nl.codec.Marshal(vertex.Value())
+	if err != nil {
+		return fmt.Errorf("failed to encode value of vertex %v: %w", vertex.ID(), err)
+	}
+	buf.WriteString(`,"value":`)
+	if err = json.Compact(buf, value); err != nil {
+		return fmt.Errorf("failed to encode value of vertex %v: %w", vertex.ID(), err)
+	}
+
+	if p := vertex.Properties(); p != nil {
+		if err = writeNodeLinkProperties(buf, p.Weight(), p.Weight() != 0, p.Metadata(), p.Items()); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteByte('}')
+	return nil
+}
+
+// encodeNodeLinkEdge appends the link object of edge to buf. The weight is
+// always written for weighted graphs.
+func encodeNodeLinkEdge[K graph.Ordered](buf *bytes.Buffer, edge graph.Edge[K], weighted bool) error {
+	buf.WriteString(`{"source":`)
+	if err := writeJSON(buf, edge.Source()); err != nil {
+		return err
+	}
+	buf.WriteString(`,"target":`)
+	if err := writeJSON(buf, edge.Target()); err != nil {
+		return err
+	}
+
+	if p := edge.Properties(); p != nil {
+		if err := writeNodeLinkProperties(buf, p.Weight(), weighted || p.Weight() != 0, p.Metadata(), p.Items()); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteByte('}')
+	return nil
+}
+
+// writeNodeLinkProperties appends the weight, metadata and items members of a
+// node or link object to buf.
+func writeNodeLinkProperties(buf *bytes.Buffer, weight float64, hasWeight bool, metadata any, items map[string]any) error {
+	if hasWeight {
+		buf.WriteString(`,"weight":`)
+		if err := writeJSON(buf, weight); err != nil {
+			return err
+		}
+	}
+
+	if metadata != nil {
+		buf.WriteString(`,"metadata":`)
+		if err := writeJSON(buf, metadata); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range sortedKeys(items) {
+		if nodeLinkReserved[name] {
+			continue
+		}
+		buf.WriteByte(',')
+		if err := writeJSON(buf, name); err != nil {
+			return err
+		}
+		buf.WriteByte(':')
+		if err := writeJSON(buf, items[name]); err != nil {
+			return fmt.Errorf("failed to encode item %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// writeJSON appends the JSON encoding of v to buf.
+func writeJSON(buf *bytes.Buffer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+// ReadGraph parses a node-link JSON document from r and adds its vertices and
+// edges to g. The document is decoded one node or link at a time, so it is
+// never held in memory as a whole. It returns graph.ErrGraphTypeMismatch if
+// the document's "directed" member does not match Traits().IsDirected.
+func (nl *NodeLink[K, T]) ReadGraph(r io.Reader, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var offset int64
+	wrap := func(err error) error {
+		return &ParseError{Format: "json", Offset: offset, Err: err}
+	}
+
+	delim := func(want json.Delim) error {
+		offset = dec.InputOffset()
+		tok, err := dec.Token()
+		if err != nil {
+			return wrap(err)
+		}
+		if d, ok := tok.(json.Delim); !ok || d != want {
+			return wrap(fmt.Errorf("expected %q, found %v", want, tok))
+		}
+		return nil
+	}
+
+	if err := delim('{'); err != nil {
+		return err
+	}
+
+	ids := make(map[string]K)
+
+	for dec.More() {
+		offset = dec.InputOffset()
+		tok, err := dec.Token()
+		if err != nil {
+			return wrap(err)
+		}
+		name, _ := tok.(string)
+
+		switch name {
+		case "directed":
+			offset = dec.InputOffset()
+			var directed bool
+			if err = dec.Decode(&directed); err != nil {
+				return wrap(err)
+			}
+			if directed != g.Traits().IsDirected {
+				return wrap(fmt.Errorf("%w: document has directed=%t", graph.ErrGraphTypeMismatch, directed))
+			}
+
+		case "nodes", "links", "edges":
+			if err = delim('['); err != nil {
+				return err
+			}
+			for dec.More() {
+				offset = dec.InputOffset()
+				var object map[string]json.RawMessage
+				if err = dec.Decode(&object); err != nil {
+					return wrap(err)
+				}
+				if name == "nodes" {
+					err = nl.readVertex(g, object, ids)
+				} else {
+					err = readNodeLinkEdge(g, object, ids)
+				}
+				if err != nil {
+					return wrap(err)
+				}
+			}
+			if err = delim(']'); err != nil {
+				return err
+			}
+
+		default:
+			// "multigraph", "graph" and unknown members carry nothing that
+			// can be applied to an existing graph.
+			offset = dec.InputOffset()
+			var skip json.RawMessage
+			if err = dec.Decode(&skip); err != nil {
+				return wrap(err)
+			}
+		}
+	}
+
+	return delim('}')
+}
+
+// readVertex adds the vertex described by a node object to g and records the
+// key its "id" resolves to.
+func (nl *NodeLink[K, T]) readVertex(g graph.Interface[K, T], object map[string]json.RawMessage, ids map[string]K) error {
+	id, ok := object["id"]
+	if !ok {
+		return errors.New("node has no id")
+	}
+
+	raw, ok := object["value"]
+	if !ok {
+		raw = id
+	}
+	value, err := nl.codec.Unmarshal(raw)
+	if err != nil {
+		return fmt.Errorf("invalid value for node %s: %w", id, err)
+	}
+
+	items, weight, hasWeight, metadata, err := nodeLinkProperties(object, "id", "value")
+	if err != nil {
+		return err
+	}
+
+	options := []graph.VertexOption{simple.VertexItems(items)}
+	if hasWeight {
+		options = append(options, simple.VertexWeight(weight))
+	}
+	if metadata != nil {
+		options = append(options, simple.VertexMetadata(metadata))
+	}
+
+	if err = g.AddVertexWithOptions(value, options...); err != nil {
+		return err
+	}
+
+	ids[string(id)] = g.Hash()(value)
+	return nil
+}
+
+// readNodeLinkEdge adds the edge described by a link object to g. Endpoints
+// that were not declared as nodes are decoded directly as keys.
+func readNodeLinkEdge[K graph.Ordered, T any](g graph.Interface[K, T], object map[string]json.RawMessage, ids map[string]K) error {
+	endpoint := func(name string) (K, error) {
+		var key K
+		id, ok := object[name]
+		if !ok {
+			return key, fmt.Errorf("link has no %s", name)
+		}
+		if key, ok = ids[string(id)]; ok {
+			return key, nil
+		}
+		if err := json.Unmarshal(id, &key); err != nil {
+			return key, fmt.Errorf("%w: %s", graph.ErrVertexNotFound, id)
+		}
+		return key, nil
+	}
+
+	source, err := endpoint("source")
+	if err != nil {
+		return err
+	}
+	target, err := endpoint("target")
+	if err != nil {
+		return err
+	}
+
+	items, weight, hasWeight, metadata, err := nodeLinkProperties(object, "source", "target", "key")
+	if err != nil {
+		return err
+	}
+
+	options := []graph.EdgeOption{simple.EdgeItems(items)}
+	if hasWeight {
+		options = append(options, simple.EdgeWeight(weight))
+	}
+	if metadata != nil {
+		options = append(options, simple.EdgeData(metadata))
+	}
+
+	return g.AddEdgeWithOptions(source, target, options...)
+}
+
+// nodeLinkProperties splits the members of a node or link object into items,
+// weight and metadata, ignoring the structural members named in skip.
+func nodeLinkProperties(object map[string]json.RawMessage, skip ...string) (map[string]any, float64, bool, any, error) {
+	var (
+		weight    float64
+		hasWeight bool
+		metadata  any
+	)
+
+	items := make(map[string]any, len(object))
+	for name, raw := range object {
+		switch name {
+		case "weight":
+			if err := json.Unmarshal(raw, &weight); err != nil {
+				return nil, 0, false, nil, fmt.Errorf("invalid weight %s: %w", raw, err)
+			}
+			hasWeight = true
+
+		case "metadata":
+			if err := json.Unmarshal(raw, &metadata); err != nil {
+				return nil, 0, false, nil, fmt.Errorf("invalid metadata: %w", err)
+			}
+
+		default:
+			items[name] = raw
+		}
+	}
+
+	for _, name := range skip {
+		delete(items, name)
+	}
+
+	for name, raw := range items {
+		value, err := nodeLinkValue(raw.(json.RawMessage))
+		if err != nil {
+			return nil, 0, false, nil, fmt.Errorf("invalid item %q: %w", name, err)
+		}
+		items[name] = value
+	}
+
+	return items, weight, hasWeight, metadata, nil
+}
+
+// nodeLinkValue decodes an item value. Integral numbers become int and other
+// numbers float64; everything else decodes as encoding/json would into an any.
+func nodeLinkValue(raw json.RawMessage) (any, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || (raw[0] != '-' && (raw[0] < '0' || raw[0] > '9')) {
+		var v any
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	}
+
+	if i, err := strconv.ParseInt(string(raw), 10, strconv.IntSize); err == nil {
+		return int(i), nil
+	}
+
+	return strconv.ParseFloat(string(raw), 64)
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/sets"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeLink_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, directed := range []bool{true, false} {
+		options := []func(*graph.Traits){graph.Weighted()}
+		if directed {
+			options = append(options, graph.Directed())
+		}
+
+		g, _ := simple.New(graph.StringHash, options...)
+		is := assert.New(t)
+		is.NoError(g.AddVertexWithOptions("A",
+			simple.VertexItems(map[string]any{"label": "Alpha", "count": 3, "ratio": 0.25}),
+			simple.VertexMetadata("meta")))
+		is.NoError(g.AddVertexWithOptions("B", simple.VertexWeight(1.5)))
+		is.NoError(g.AddVertexWithOptions("C"))
+		is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(2), simple.EdgeItem("kind", "dep")))
+		is.NoError(g.AddEdgeWithOptions("B", "C", simple.EdgeWeight(0.5), simple.EdgeData([]any{"x"})))
+
+		nl := NewNodeLink[string, string]()
+
+		var buf bytes.Buffer
+		is.NoError(nl.WriteGraph(&buf, g))
+		is.True(json.Valid(buf.Bytes()))
+
+		h, _ := simple.New(graph.StringHash, options...)
+		is.NoError(nl.ReadGraph(&buf, h))
+
+		equal, err := sets.Equals(g, h)
+		is.NoError(err)
+		is.True(equal)
+
+		a, _ := h.Vertex("A")
+		is.Equal(map[string]any{"label": "Alpha", "count": 3, "ratio": 0.25}, a.Properties().Items())
+		is.Equal("meta", a.Properties().Metadata())
+
+		b, _ := h.Vertex("B")
+		is.Equal(1.5, b.Properties().Weight())
+
+		e, _ := h.Edge("A", "B")
+		is.Equal("dep", e.Properties().Items()["kind"])
+
+		e, _ = h.Edge("B", "C")
+		is.Equal([]any{"x"}, e.Properties().Metadata())
+	}
+}
+
+func TestNodeLink_Streaming(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.IntHash, graph.Directed())
+	for i := 0; i < 50; i++ {
+		is.NoError(g.AddVertexWithOptions(i))
+	}
+	for i := 1; i < 50; i++ {
+		is.NoError(g.AddEdgeWithOptions(i-1, i, simple.EdgeWeight(float64(i))))
+	}
+
+	var plain, streamed bytes.Buffer
+	is.NoError(NewNodeLink[int, int]().WriteGraph(&plain, g))
+	is.NoError(NewNodeLink(NodeLinkStreaming[int, int](7)).WriteGraph(&streamed, g))
+	is.Equal(plain.String(), streamed.String())
+
+	h, _ := simple.New(graph.IntHash, graph.Directed())
+	is.NoError(NewNodeLink[int, int]().ReadGraph(&streamed, h))
+
+	size, _ := h.Size()
+	is.Equal(49, size)
+}
+
+type person struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestNodeLink_Codec(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	hash := func(p person) string { return p.Name }
+	g, _ := simple.New(hash, graph.Directed())
+	is.NoError(g.AddVertexWithOptions(person{Name: "ada", Age: 36}))
+	is.NoError(g.AddVertexWithOptions(person{Name: "alan", Age: 41}))
+	is.NoError(g.AddEdgeWithOptions("ada", "alan"))
+
+	var buf bytes.Buffer
+	nl := NewNodeLink[string, person]()
+	is.NoError(nl.WriteGraph(&buf, g))
+	is.Contains(buf.String(), `{"id":"ada","value":{"name":"ada","age":36}}`)
+
+	h, _ := simple.New(hash, graph.Directed())
+	is.NoError(nl.ReadGraph(&buf, h))
+
+	v, err := h.Vertex("alan")
+	is.NoError(err)
+	is.Equal(41, v.Value().Age)
+}
+
+func TestNodeLink_ReadNetworkX(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	src := `{
+  "directed": false,
+  "multigraph": false,
+  "graph": {"name": "karate"},
+  "nodes": [{"club": "Mr. Hi", "id": 0}, {"club": "Officer", "id": 1}, {"id": 2}],
+  "edges": [{"weight": 4, "source": 0, "target": 1}, {"source": 1, "target": 2}]
+}`
+	g, _ := simple.New(graph.IntHash)
+	is.NoError(NewNodeLink[int, int]().ReadGraph(strings.NewReader(src), g))
+
+	v, _ := g.Vertex(0)
+	is.Equal("Mr. Hi", v.Properties().Items()["club"])
+
+	e, err := g.Edge(1, 0)
+	is.NoError(err)
+	is.Equal(float64(4), e.Properties().Weight())
+
+	ok, _ := g.HasEdge(2, 1)
+	is.True(ok)
+}
+
+func TestNodeLink_ReadErrors(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash, graph.Directed())
+	nl := NewNodeLink[string, string]()
+
+	err := nl.ReadGraph(strings.NewReader(`{"directed": false, "nodes": []}`), g)
+	is.ErrorIs(err, graph.ErrGraphTypeMismatch)
+
+	err = nl.ReadGraph(strings.NewReader(`{"directed": true, "nodes": [{"id": "a"}], "links": [{"source": "a", "target": "b"}]}`), g)
+	is.ErrorIs(err, graph.ErrVertexNotFound)
+
+	var perr *ParseError
+	err = nl.ReadGraph(strings.NewReader(`{"directed": true, "nodes": [{"id": "x"} {"id": "y"}]}`), g)
+	is.True(errors.As(err, &perr))
+	is.Equal("json", perr.Format)
+	is.Positive(perr.Offset)
+
+	err = nl.ReadGraph(strings.NewReader(`[]`), g)
+	is.True(errors.As(err, &perr))
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/sixafter/graph"
+)
+
+// plantUMLEscaper replaces the characters that would end a quoted PlantUML
+// name or label, or be taken for Creole markup, with numeric character
+// references, and line breaks with \n.
+var plantUMLEscaper = strings.NewReplacer(
+	`"`, "&#34;",
+	`\`, "&#92;",
+	"<", "&#60;",
+	">", "&#62;",
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// PlantUMLWriter writes graphs as PlantUML diagrams, with each vertex drawn
+// as a rectangle. Directed edges are drawn as arrows (-->) and undirected
+// edges as lines (--). TopDown and LeftRight map to PlantUML's "top to
+// bottom direction" and "left to right direction".
+//
+// Vertices are given generated aliases, so keys of any type and content are
+// safe; labels are quoted and escaped. With DiagramPartition, each community
+// is drawn as a package. The output is stable, with vertices and edges
+// written in key order.
+//
+// Example:
+//
+//	w := io.NewPlantUMLWriter[string, string]()
+//	if err := w.WriteGraph(os.Stdout, g); err != nil {
+//		log.Fatal(err)
+//	}
+type PlantUMLWriter[K graph.Ordered, T any] struct {
+	diagramOptions[K, T]
+}
+
+// NewPlantUMLWriter creates a PlantUMLWriter configured with the given options.
+func NewPlantUMLWriter[K graph.Ordered, T any](options ...DiagramOption[K, T]) *PlantUMLWriter[K, T] {
+	return &PlantUMLWriter[K, T]{diagramOptions: newDiagramOptions(options)}
+}
+
+// WriteGraph writes g to w as a PlantUML diagram.
+func (p *PlantUMLWriter[K, T]) WriteGraph(w io.Writer, g graph.Interface[K, T]) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	d, err := p.prepare(g)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("@startuml\n")
+	if p.direction == LeftRight {
+		bw.WriteString("left to right direction\n")
+	} else {
+		bw.WriteString("top to bottom direction\n")
+	}
+
+	node := func(indent string, vertex graph.Vertex[K, T]) {
+		bw.WriteString(indent + `rectangle "` + plantUMLEscaper.Replace(p.vertexLabel(vertex)) + `" as ` + d.ids[vertex.ID()] + "\n")
+	}
+
+	for _, community := range d.groups {
+		bw.WriteString(`package "` + plantUMLEscaper.Replace(p.partitionLabel(community)) + "\" {\n")
+		for _, vertex := range d.members[community] {
+			node("  ", vertex)
+		}
+		bw.WriteString("}\n")
+	}
+
+	for _, vertex := range d.ungrouped {
+		node("", vertex)
+	}
+
+	link := "--"
+	if d.isDirected {
+		link = "-->"
+	}
+
+	for _, edge := range d.edges {
+		bw.WriteString(d.ids[edge.Source()] + " " + link + " " + d.ids[edge.Target()])
+		if label := p.edgeLabel(edge); label != "" {
+			bw.WriteString(" : " + plantUMLEscaper.Replace(label))
+		}
+		bw.WriteString("\n")
+	}
+
+	bw.WriteString("@enduml\n")
+	return bw.Flush()
+}
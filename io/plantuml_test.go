@@ -0,0 +1,60 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package io
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlantUMLWriter_Directed(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var buf bytes.Buffer
+	is.NoError(NewPlantUMLWriter[string, string]().WriteGraph(&buf, diagramTestGraph(is, graph.Directed())))
+
+	is.Equal(`@startuml
+top to bottom direction
+rectangle "build" as n0
+rectangle "say &#34;hi&#34; &#60;now&#62;" as n1
+rectangle "test" as n2
+n0 --> n2 : 2
+n1 --> n0 : a|b
+@enduml
+`, buf.String())
+}
+
+func TestPlantUMLWriter_Partition(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	w := NewPlantUMLWriter(
+		DiagramDirection[string, string](LeftRight),
+		DiagramPartition[string, string](map[string]int{"build": 0, `say "hi" <now>`: 1}),
+		DiagramVertexLabel[string, string](func(v graph.Vertex[string, string]) string { return v.Value() + "\nstep" }),
+	)
+
+	var buf bytes.Buffer
+	is.NoError(w.WriteGraph(&buf, diagramTestGraph(is)))
+
+	is.Equal(`@startuml
+left to right direction
+package "0" {
+  rectangle "build\nstep" as n0
+}
+package "1" {
+  rectangle "say &#34;hi&#34; &#60;now&#62;\nstep" as n1
+}
+rectangle "test\nstep" as n2
+n0 -- n1 : a|b
+n0 -- n2 : 2
+@enduml
+`, buf.String())
+}
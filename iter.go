@@ -0,0 +1,57 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package graph
+
+import "iter"
+
+// Iterable is implemented by graphs that enumerate their vertices, edges and
+// neighbors as range-over-func sequences. Unlike Vertices, Edges and
+// Neighbors, the sequences read the graph as they go, so a loop that breaks
+// early does not pay for the whole graph. Algorithms check for it to walk
+// the graph without building an adjacency map.
+//
+// The sequences do not lock the graph while the loop body runs, so the graph
+// may be changed during a loop; changes are seen or not depending on whether
+// the sequence has already read the part of the graph they affect. A sequence
+// ends early if the graph cannot be read, for example once a snapshot has
+// been released.
+//
+// Example:
+//
+//	if it, ok := g.(graph.Iterable[string, string]); ok {
+//		for target := range it.Successors("A") {
+//			if target == "B" {
+//				break
+//			}
+//		}
+//	}
+type Iterable[K Ordered, T any] interface {
+	// AllVertices returns the keys and values of the vertices in ascending
+	// order of their keys.
+	AllVertices() iter.Seq2[K, T]
+
+	// AllEdges returns the edges in the order of Edges. An edge of an
+	// undirected graph is returned once.
+	AllEdges() iter.Seq[Edge[K]]
+
+	// Successors returns the keys of the targets of the edges leaving the
+	// vertex in ascending order, once each however many parallel edges lead
+	// to them. In an undirected graph, these are the neighbors of the vertex.
+	// The sequence is empty if the vertex does not exist.
+	Successors(hash K) iter.Seq[K]
+
+	// Predecessors returns the keys of the sources of the edges entering the
+	// vertex in ascending order, once each. In an undirected graph, these are
+	// the neighbors of the vertex. The sequence is empty if the vertex does
+	// not exist.
+	Predecessors(hash K) iter.Seq[K]
+
+	// IncidentEdges returns every edge that leaves or enters the vertex,
+	// including parallel edges and self-loops, which are returned once. The
+	// edges of an undirected graph have the vertex as their source. The
+	// sequence is empty if the vertex does not exist.
+	IncidentEdges(hash K) iter.Seq[Edge[K]]
+}
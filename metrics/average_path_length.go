@@ -6,7 +6,6 @@
 package metrics
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/sixafter/graph"
@@ -16,8 +15,12 @@ import (
 // AveragePathLength calculates the average shortest path length in the given graph.
 //
 // It computes the average of the shortest path lengths between all pairs of vertices
-// in the graph. If the graph is disconnected, the function returns an error as the
-// calculation is not well-defined in such cases.
+// in the graph, counted in edges. For a weighted graph, the paths are the ones of
+// least total weight. If the graph is disconnected, the function returns an error
+// wrapping graph.ErrTargetNotReachable as the calculation is not well-defined in
+// such cases.
+//
+// One shortest path tree is computed per vertex, with paths.ShortestPathTreeFrom.
 //
 // Parameters:
 //   - g: A graph.Interface representing the graph. The graph must implement the
@@ -49,30 +52,27 @@ func AveragePathLength[K graph.Ordered, T any](g graph.Interface[K, T]) (float64
 	var totalPathLength float64
 	var pathCount int
 
-	hashFunc := g.Hash()
+	// Find the shortest paths from each vertex with a single search
+	for _, vertex := range vertices {
+		src := vertex.ID()
 
-	// Iterate over all pairs of vertices
-	for i := 0; i < vertexCount; i++ {
-		src := hashFunc(vertices[i].Value())
+		tree, err := paths.ShortestPathTreeFrom(g, src)
+		if err != nil {
+			return 0, fmt.Errorf("failed to calculate shortest paths from %v: %w", src, err)
+		}
 
-		for j := 0; j < vertexCount; j++ {
-			if i == j {
+		for _, other := range vertices {
+			target := other.ID()
+			if target == src {
 				continue // Skip self-loops
 			}
 
-			target := hashFunc(vertices[j].Value())
-
-			// Find the shortest path using DijkstraFrom
-			path, err := paths.DijkstraFrom(g, src, target)
-			if err != nil {
-				if errors.Is(err, graph.ErrTargetNotReachable) {
-					return 0, err
-				}
-				return 0, fmt.Errorf("failed to calculate shortest path from %v to %v: %w", src, target, err)
+			if !tree.HasPathTo(target) {
+				return 0, fmt.Errorf("%w: from %v to %v", graph.ErrTargetNotReachable, src, target)
 			}
 
 			// Add path length to total
-			totalPathLength += float64(len(path) - 1) // Number of edges in the path
+			totalPathLength += float64(len(tree.PathTo(target)) - 1) // Number of edges in the path
 			pathCount++
 		}
 	}
@@ -8,8 +8,10 @@ package metrics
 import (
 	"fmt"
 	"math"
+	"slices"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/iterate"
 	"github.com/sixafter/graph/internal/queue"
 )
 
@@ -51,28 +53,27 @@ func BrandesFrom[K graph.Ordered, T any](g graph.Interface[K, T]) (map[K]float64
 
 // brandesUnweighted computes betweenness centrality for unweighted graphs.
 func brandesUnweighted[K graph.Ordered, T any](g graph.Interface[K, T]) (map[K]float64, error) {
-	// Fetch adjacency for quick neighbor lookups
-	adj, err := g.AdjacencyMap()
+	// List the successors of each vertex as the search reaches it.
+	successors, err := iterate.Successors(g)
 	if err != nil {
 		return nil, fmt.Errorf("brandes: failed to retrieve adjacency map: %w", err)
 	}
 
 	// Get all vertices in the graph.
-	vertices, err := g.Vertices()
+	keys, err := iterate.Vertices(g)
 	if err != nil {
 		return nil, fmt.Errorf("brandes: failed to retrieve vertices: %w", err)
 	}
+	vertices := slices.Collect(keys)
 
 	// Initialize betweenness centrality scores to 0.
 	BC := make(map[K]float64, len(vertices))
-	for _, v := range vertices {
-		k := g.Hash()(v.Value()) // Derive key using the hash function
+	for _, k := range vertices {
 		BC[k] = 0.0
 	}
 
 	// Iterate over each vertex as the source.
-	for _, sourceVertex := range vertices {
-		s := g.Hash()(sourceVertex.Value())
+	for _, s := range vertices {
 
 		// Stack S
 		S := queue.NewStack[K]()
@@ -87,8 +88,7 @@ func brandesUnweighted[K graph.Ordered, T any](g graph.Interface[K, T]) (map[K]f
 		sigma := make(map[K]float64, len(vertices))
 
 		// Initialize distances and sigma
-		for _, v := range vertices {
-			k := g.Hash()(v.Value())
+		for _, k := range vertices {
 			dist[k] = math.Inf(1) // Initialize to infinity
 			sigma[k] = 0.0
 			P[k] = []K{}
@@ -105,7 +105,7 @@ func brandesUnweighted[K graph.Ordered, T any](g graph.Interface[K, T]) (map[K]f
 			v := vAny.(K)
 			S.Push(v)
 
-			for wK := range adj[v] { // adj[v] is map[K]Edge[K]
+			for wK := range successors(v) {
 				if dist[wK] == math.Inf(1) {
 					dist[wK] = dist[v] + 1.0
 					q.Enqueue(wK)
@@ -119,8 +119,7 @@ func brandesUnweighted[K graph.Ordered, T any](g graph.Interface[K, T]) (map[K]f
 
 		// Accumulation phase
 		delta := make(map[K]float64, len(vertices))
-		for _, v := range vertices {
-			k := g.Hash()(v.Value())
+		for _, k := range vertices {
 			delta[k] = 0.0
 		}
 
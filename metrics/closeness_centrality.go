@@ -23,6 +23,10 @@ import (
 //
 //	C(v) = (number of reachable vertices - 1) / sum of shortest path distances from v to all reachable vertices
 //
+// The distance between two vertices is the number of edges on a shortest path between them, found
+// by breadth-first search. Edge weights are ignored, so weighted graphs, including those with
+// negative weights, are measured in hops.
+//
 // If a vertex has no reachable vertices (isolated vertex), its closeness centrality is defined as 0.
 //
 // The function returns a map where each key corresponds to a vertex in the graph,
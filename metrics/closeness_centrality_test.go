@@ -235,3 +235,42 @@ func TestClosenessCentralityTriangle(t *testing.T) {
 		is.True(floatEquals(expected[i], centrality[i]), fmt.Sprintf("Vertex %d should have a closeness centrality of %.4f", i, expected[i]))
 	}
 }
+
+func TestClosenessCentralityWeightedGraph(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	// Create a weighted path 1 - 2 - 3 with a heavy shortcut 1 - 3 and a
+	// negative edge 3 - 4
+	g, err := simple.New(graph.IntHash, graph.Weighted())
+	is.NoError(err)
+
+	for i := 1; i <= 4; i++ {
+		is.NoError(g.AddVertexWithOptions(i))
+	}
+	is.NoError(g.AddEdgeWithOptions(1, 2, simple.EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions(2, 3, simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions(1, 3, simple.EdgeWeight(5)))
+	is.NoError(g.AddEdgeWithOptions(3, 4, simple.EdgeWeight(-1)))
+
+	// Compute closeness centrality
+	centrality, err := ClosenessCentrality(g)
+	is.NoError(err)
+
+	// Distances count edges, whatever their weights:
+	// - Vertex 1: 1 + 1 + 2 = 4, C(v1) = 3 / 4 = 0.75
+	// - Vertex 2: 1 + 1 + 2 = 4, C(v2) = 3 / 4 = 0.75
+	// - Vertex 3: 1 + 1 + 1 = 3, C(v3) = 3 / 3 = 1
+	// - Vertex 4: 2 + 2 + 1 = 5, C(v4) = 3 / 5 = 0.6
+	expected := map[int]float64{
+		1: 0.75,
+		2: 0.75,
+		3: 1,
+		4: 0.6,
+	}
+
+	for i := 1; i <= 4; i++ {
+		is.True(floatEquals(expected[i], centrality[i]),
+			fmt.Sprintf("Vertex %d should have a closeness centrality of %.4f, got %.4f", i, expected[i], centrality[i]))
+	}
+}
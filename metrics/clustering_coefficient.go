@@ -7,8 +7,10 @@ package metrics
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/iterate"
 )
 
 // ClusteringCoefficient calculates the clustering coefficient for each vertex in the provided graph g.
@@ -112,46 +114,36 @@ func ClusteringCoefficient[K graph.Ordered, T any](g graph.Interface[K, T]) (map
 // clusteringCoefficientUndirected computes clustering coefficients for undirected graphs.
 func clusteringCoefficientUndirected[K graph.Ordered, T any](g graph.Interface[K, T]) (map[K]float64, error) {
 	// Retrieve all vertices in the graph
-	vertices, err := g.Vertices()
+	vertices, err := iterate.Vertices(g)
 	if err != nil {
 		return nil, fmt.Errorf("clustering_coefficient_undirected: failed to retrieve vertices: %w", err)
 	}
 
-	// Retrieve the adjacency map for quick neighbor lookups
-	adjacency, err := g.AdjacencyMap()
+	// List neighbors and look up edges between them as they are needed
+	successors, err := iterate.Successors(g)
+	if err != nil {
+		return nil, fmt.Errorf("clustering_coefficient_undirected: failed to retrieve adjacency map: %w", err)
+	}
+	adjacent, err := iterate.Adjacent(g)
 	if err != nil {
 		return nil, fmt.Errorf("clustering_coefficient_undirected: failed to retrieve adjacency map: %w", err)
 	}
 
 	// Initialize the clustering coefficient map
-	clustering := make(map[K]float64, len(vertices))
-
-	hashFunc := g.Hash()
+	clustering := make(map[K]float64)
 
 	// Iterate over each vertex to compute its clustering coefficient
-	for _, vertex := range vertices {
-		vKey := hashFunc(vertex.Value())
-
-		neighborsMap, exists := adjacency[vKey]
-		if !exists {
-			// If the vertex has no neighbors, its clustering coefficient is 0
-			clustering[vKey] = 0.0
-			continue
-		}
+	for vKey := range vertices {
+		// Collect all neighbor keys into a slice for easy iteration
+		neighborKeys := slices.Collect(successors(vKey))
 
-		degree := len(neighborsMap)
+		degree := len(neighborKeys)
 		if degree < 2 {
 			// Clustering coefficient is 0 for vertices with fewer than two neighbors
 			clustering[vKey] = 0.0
 			continue
 		}
 
-		// Collect all neighbor keys into a slice for easy iteration
-		neighborKeys := make([]K, 0, degree)
-		for neighborKey := range neighborsMap {
-			neighborKeys = append(neighborKeys, neighborKey)
-		}
-
 		// Count the number of edges between neighbors
 		edgeCount := 0
 		for i := 0; i < len(neighborKeys); i++ {
@@ -159,7 +151,7 @@ func clusteringCoefficientUndirected[K graph.Ordered, T any](g graph.Interface[K
 				u := neighborKeys[i]
 				w := neighborKeys[j]
 
-				if _, connected := adjacency[u][w]; connected {
+				if adjacent(u, w) {
 					edgeCount++
 				}
 			}
@@ -176,47 +168,38 @@ func clusteringCoefficientUndirected[K graph.Ordered, T any](g graph.Interface[K
 // clusteringCoefficientDirected computes clustering coefficients for directed graphs.
 func clusteringCoefficientDirected[K graph.Ordered, T any](g graph.Interface[K, T]) (map[K]float64, error) {
 	// Retrieve all vertices in the graph
-	vertices, err := g.Vertices()
+	vertices, err := iterate.Vertices(g)
 	if err != nil {
 		return nil, fmt.Errorf("clustering_coefficient_directed: failed to retrieve vertices: %w", err)
 	}
 
-	// Retrieve the adjacency map (outgoing edges)
-	adjacency, err := g.AdjacencyMap()
+	// List out-neighbors and look up edges between them as they are needed
+	successors, err := iterate.Successors(g)
+	if err != nil {
+		return nil, fmt.Errorf("clustering_coefficient_directed: failed to retrieve adjacency map: %w", err)
+	}
+	adjacent, err := iterate.Adjacent(g)
 	if err != nil {
 		return nil, fmt.Errorf("clustering_coefficient_directed: failed to retrieve adjacency map: %w", err)
 	}
 
 	// Initialize the clustering coefficient map
-	clustering := make(map[K]float64, len(vertices))
-
-	hashFunc := g.Hash()
+	clustering := make(map[K]float64)
 
 	// Iterate over each vertex to compute its clustering coefficient
-	for _, vertex := range vertices {
-		vKey := hashFunc(vertex.Value())
+	for vKey := range vertices {
+		// Collect all out-neighbor keys into a slice for easy iteration. They
+		// are in ascending order, so each pair is checked the same way on
+		// every run.
+		neighborKeys := slices.Collect(successors(vKey))
 
-		// Retrieve out-neighbors
-		outNeighborsMap, exists := adjacency[vKey]
-		if !exists {
-			// If the vertex has no out-neighbors, its clustering coefficient is 0
-			clustering[vKey] = 0.0
-			continue
-		}
-
-		degree := len(outNeighborsMap)
+		degree := len(neighborKeys)
 		if degree < 2 {
 			// Clustering coefficient is 0 for vertices with fewer than two out-neighbors
 			clustering[vKey] = 0.0
 			continue
 		}
 
-		// Collect all out-neighbor keys into a slice for easy iteration
-		neighborKeys := make([]K, 0, degree)
-		for neighborKey := range outNeighborsMap {
-			neighborKeys = append(neighborKeys, neighborKey)
-		}
-
 		// Count the number of edges between out-neighbors
 		edgeCount := 0
 		for i := 0; i < len(neighborKeys); i++ {
@@ -225,7 +208,7 @@ func clusteringCoefficientDirected[K graph.Ordered, T any](g graph.Interface[K,
 				w := neighborKeys[j]
 
 				// Check if there's an edge from u to w
-				if _, connected := adjacency[u][w]; connected {
+				if adjacent(u, w) {
 					edgeCount++
 				}
 			}
@@ -6,7 +6,6 @@
 package metrics
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/sixafter/graph"
@@ -16,8 +15,12 @@ import (
 // Diameter calculates the diameter of the given graph.
 //
 // The diameter of a graph is the length of the longest shortest path between any
-// pair of vertices in the graph. If the graph is disconnected, the function returns
-// an error since the diameter is not well-defined in such cases.
+// pair of vertices in the graph, counted in edges. For a weighted graph, the paths
+// are the ones of least total weight. If the graph is disconnected, the function
+// returns an error wrapping graph.ErrTargetNotReachable since the diameter is not
+// well-defined in such cases.
+//
+// One shortest path tree is computed per vertex, with paths.ShortestPathTreeFrom.
 //
 // Parameters:
 //   - g: A graph.Interface representing the graph. The graph must support shortest
@@ -48,28 +51,23 @@ func Diameter[K graph.Ordered, T any](g graph.Interface[K, T]) (int, error) {
 
 	maxShortestPath := 0
 
-	// Iterate over all pairs of vertices
-	for i := 0; i < vertexCount; i++ {
-		src := vertices[i].ID()
-
-		for j := 0; j < vertexCount; j++ {
-			if i == j {
-				continue // Skip self-loops
-			}
-			target := vertices[j].ID()
+	// Find the shortest paths from each vertex with a single search
+	for _, vertex := range vertices {
+		src := vertex.ID()
 
-			// Find the shortest path using DijkstraFrom
-			path, err := paths.DijkstraFrom(g, src, target)
-			if err != nil {
-				if errors.Is(err, graph.ErrTargetNotReachable) {
-					return 0, err
-				}
+		tree, err := paths.ShortestPathTreeFrom(g, src)
+		if err != nil {
+			return 0, fmt.Errorf("failed to calculate shortest paths from %v: %w", src, err)
+		}
 
-				return 0, fmt.Errorf("failed to calculate shortest path from %v to %v: %w", src, target, err)
+		for _, other := range vertices {
+			target := other.ID()
+			if !tree.HasPathTo(target) {
+				return 0, fmt.Errorf("%w: from %v to %v", graph.ErrTargetNotReachable, src, target)
 			}
 
 			// Calculate the length of the path
-			pathLength := len(path) - 1 // Number of edges in the path
+			pathLength := len(tree.PathTo(target)) - 1 // Number of edges in the path
 			if pathLength > maxShortestPath {
 				maxShortestPath = pathLength
 			}
@@ -86,7 +86,7 @@ func TestDiameterDisconnectedGraph(t *testing.T) {
 
 	// Compute diameter
 	_, err = Diameter(g)
-	is.Error(err, "Disconnected graph should result in an error for diameter calculation")
+	is.ErrorIs(err, graph.ErrTargetNotReachable, "Disconnected graph should result in an error for diameter calculation")
 }
 
 func TestDiameterSingleVertex(t *testing.T) {
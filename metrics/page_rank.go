@@ -83,38 +83,27 @@ func PageRank[K graph.Ordered, T any](
 	}
 
 	// Retrieve vertices and initialize PageRank scores
-	vertices, err := g.Vertices()
+	vertexIDs, links, err := pageRankLinks(g)
 	if err != nil {
-		return nil, fmt.Errorf("pagerank: failed to retrieve vertices: %w", err)
+		return nil, err
 	}
 
-	N := len(vertices)
+	N := len(vertexIDs)
 	if N == 0 {
 		return nil, errors.New("pagerank: graph has no vertices")
 	}
 
-	pr := make([]float64, N)        // Current PageRank values
-	prNew := make([]float64, N)     // Temporary storage for updates
-	vertexIDs := make([]K, N)       // Vertex IDs
-	idToIndex := make(map[K]int, N) // Map of vertex ID to index
+	pr := make([]float64, N)    // Current PageRank values
+	prNew := make([]float64, N) // Temporary storage for updates
 
 	// Initialize PageRank values uniformly
-	for i, vertex := range vertices {
-		id := g.Hash()(vertex.Value())
-		vertexIDs[i] = id
-		idToIndex[id] = i
+	for i := range pr {
 		pr[i] = 1.0 / float64(N)
 	}
 
 	// Precompute teleportation factor
 	teleport := (1.0 - dampingFactor) / float64(N)
 
-	// Retrieve the predecessor map for directed graphs
-	predecessors, err := g.PredecessorMap()
-	if err != nil {
-		return nil, fmt.Errorf("pagerank: failed to retrieve predecessor map: %w", err)
-	}
-
 	// Main iteration loop
 	for iter := 0; iter < maxIterations; iter++ {
 		// Reset new PageRank values to the teleportation contribution
@@ -123,32 +112,16 @@ func PageRank[K graph.Ordered, T any](
 		}
 
 		// Calculate contributions from predecessors
-		for i, id := range vertexIDs {
-			neighbors, exists := predecessors[id]
-			if !exists {
-				continue
-			}
-
-			for neighborID, edge := range neighbors {
-				neighborIdx, exists := idToIndex[neighborID]
-				if !exists {
-					continue
-				}
-
+		for i, incoming := range links {
+			for _, link := range incoming {
 				// Calculate the weight of the edge
 				weight := 1.0
 				if traits.IsWeighted {
-					weight = edge.Properties().Weight()
-				}
-
-				// Get the out-degree of the neighbor
-				outDegree, err := g.OutDegree(neighborID)
-				if err != nil || outDegree == 0 {
-					continue
+					weight = link.weight
 				}
 
 				// Distribute the neighbor's contribution
-				contribution := (pr[neighborIdx] * weight) / float64(outDegree)
+				contribution := (pr[link.from] * weight) / float64(link.outDegree)
 				prNew[i] += dampingFactor * contribution
 			}
 		}
@@ -178,3 +151,81 @@ func PageRank[K graph.Ordered, T any](
 
 	return result, nil
 }
+
+// pageRankLink is an edge into a vertex, seen from its source.
+type pageRankLink struct {
+	from      int     // Index of the source vertex
+	weight    float64 // Weight of the lightest edge from the source
+	outDegree int     // Out-degree of the source vertex
+}
+
+// pageRankLinks numbers the vertices of g and lists the links into each of
+// them. Sources without outgoing edges are left out. A graph implementing
+// graph.Indexed is read through its indices instead of a predecessor map.
+func pageRankLinks[K graph.Ordered, T any](g graph.Interface[K, T]) ([]K, [][]pageRankLink, error) {
+	if ix, ok := g.(graph.Indexed[K]); ok {
+		n, err := g.Order()
+		if err != nil {
+			return nil, nil, fmt.Errorf("pagerank: failed to retrieve vertices: %w", err)
+		}
+
+		vertexIDs := make([]K, n)
+		links := make([][]pageRankLink, n)
+		for i := range n {
+			vertexIDs[i] = ix.VertexKey(i)
+
+			sources, weights := ix.Predecessors(i)
+			for j, source := range sources {
+				// Parallel edges are adjacent; keep the lightest.
+				if last := len(links[i]) - 1; last >= 0 && links[i][last].from == source {
+					links[i][last].weight = math.Min(links[i][last].weight, weights[j])
+					continue
+				}
+
+				targets, _ := ix.Successors(source)
+				links[i] = append(links[i], pageRankLink{from: source, weight: weights[j], outDegree: len(targets)})
+			}
+		}
+
+		return vertexIDs, links, nil
+	}
+
+	vertices, err := g.Vertices()
+	if err != nil {
+		return nil, nil, fmt.Errorf("pagerank: failed to retrieve vertices: %w", err)
+	}
+
+	vertexIDs := make([]K, len(vertices))
+	idToIndex := make(map[K]int, len(vertices)) // Map of vertex ID to index
+	for i, vertex := range vertices {
+		id := g.Hash()(vertex.Value())
+		vertexIDs[i] = id
+		idToIndex[id] = i
+	}
+
+	// Retrieve the predecessor map for directed graphs
+	predecessors, err := g.PredecessorMap()
+	if err != nil {
+		return nil, nil, fmt.Errorf("pagerank: failed to retrieve predecessor map: %w", err)
+	}
+
+	links := make([][]pageRankLink, len(vertices))
+	for i, id := range vertexIDs {
+		for neighborID, edge := range predecessors[id] {
+			neighborIdx, exists := idToIndex[neighborID]
+			if !exists {
+				continue
+			}
+
+			// Get the out-degree of the neighbor
+			outDegree, err := g.OutDegree(neighborID)
+			if err != nil || outDegree == 0 {
+				continue
+			}
+
+			links[i] = append(links[i], pageRankLink{from: neighborIdx, weight: edge.Properties().Weight(), outDegree: outDegree})
+		}
+	}
+
+	return vertexIDs, links, nil
+}
@@ -7,8 +7,10 @@ package metrics
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/iterate"
 )
 
 // Transitivity calculates the transitivity of the given graph.
@@ -35,8 +37,16 @@ func Transitivity[K graph.Ordered, T any](g graph.Interface[K, T]) (float64, err
 		return 0, graph.ErrNilInputGraph
 	}
 
-	// Retrieve the adjacency map
-	adjMap, err := g.AdjacencyMap()
+	// List the vertices and their neighbors, and look up edges between them
+	vertices, err := iterate.Vertices(g)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve vertices: %w", err)
+	}
+	successors, err := iterate.Successors(g)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve adjacency map: %w", err)
+	}
+	adjacent, err := iterate.Adjacent(g)
 	if err != nil {
 		return 0, fmt.Errorf("failed to retrieve adjacency map: %w", err)
 	}
@@ -45,11 +55,8 @@ func Transitivity[K graph.Ordered, T any](g graph.Interface[K, T]) (float64, err
 	var triangles, triplets float64
 
 	// Iterate over all vertices
-	for v, neighbors := range adjMap {
-		neighborList := make([]K, 0, len(neighbors))
-		for neighbor := range neighbors {
-			neighborList = append(neighborList, neighbor)
-		}
+	for v := range vertices {
+		neighborList := slices.Collect(successors(v))
 
 		// Count triplets and triangles for this vertex
 		for i := 0; i < len(neighborList); i++ {
@@ -61,12 +68,12 @@ func Transitivity[K graph.Ordered, T any](g graph.Interface[K, T]) (float64, err
 				// Check for triangles
 				if traits.IsDirected {
 					// Directed graph: check edge directions
-					if adjMap[n1][n2] != nil && adjMap[n2][v] != nil && adjMap[v][n1] != nil {
+					if adjacent(n1, n2) && adjacent(n2, v) && adjacent(v, n1) {
 						triangles++
 					}
 				} else {
 					// Undirected graph: ensure consistent counting
-					if _, exists := adjMap[n1][n2]; exists {
+					if adjacent(n1, n2) {
 						triangles += 1.0 / 3.0 // Avoid triple counting
 					}
 				}
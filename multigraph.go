@@ -0,0 +1,67 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package graph
+
+// EdgeID identifies an edge within a graph. IDs are assigned when an edge is
+// added, are never reused by the same graph, and are preserved by Clone. The
+// zero value means "no ID".
+type EdgeID uint64
+
+// IdentifiedEdge is an edge that carries the ID assigned to it by its graph.
+// Edges returned by Edges, EdgesBetween and EdgeByID implement it.
+//
+// Example:
+//
+//	if e, ok := edge.(graph.IdentifiedEdge[string]); ok {
+//		fmt.Printf("Edge %d from %v to %v\n", e.ID(), e.Source(), e.Target())
+//	}
+type IdentifiedEdge[K any] interface {
+	Edge[K]
+
+	// ID returns the ID of the edge.
+	ID() EdgeID
+}
+
+// Multigraph is implemented by graphs that can hold several parallel edges
+// between the same pair of vertices. A graph created with the MultiGraph
+// trait accepts parallel edges in AddEdge and AddEdgeWithOptions; the methods
+// below address the individual edges.
+//
+// Where Interface methods take a source and target, they act on parallel edges
+// as follows: Edge and SetEdgeWithOptions use the edge added first, RemoveEdge
+// removes every edge between the pair, AdjacencyMap and PredecessorMap hold the
+// edge with the lowest weight, and Size and the degree methods count every
+// edge.
+//
+// Example:
+//
+//	g, _ := simple.New(graph.StringHash, graph.Directed(), graph.MultiGraph())
+//	m := g.(graph.Multigraph[string, string])
+//	id, err := m.InsertEdge("A", "B", simple.EdgeWeight(3))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = m.RemoveEdgeByID(id)
+type Multigraph[K Ordered, T any] interface {
+	Interface[K, T]
+
+	// InsertEdge adds an edge like AddEdgeWithOptions and returns the ID
+	// assigned to it.
+	InsertEdge(source, target K, options ...EdgeOption) (EdgeID, error)
+
+	// EdgesBetween returns every edge from source to target, in the order
+	// they were added. For undirected graphs the edges in either direction
+	// are returned, oriented from source to target. It returns
+	// ErrVertexNotFound if either vertex does not exist.
+	EdgesBetween(source, target K) ([]Edge[K], error)
+
+	// EdgeByID returns the edge with the given ID, or ErrEdgeNotFound.
+	EdgeByID(id EdgeID) (Edge[K], error)
+
+	// RemoveEdgeByID removes the edge with the given ID, leaving any parallel
+	// edges in place. It returns ErrEdgeNotFound if there is no such edge.
+	RemoveEdgeByID(id EdgeID) error
+}
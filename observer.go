@@ -0,0 +1,174 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package graph
+
+// EventType identifies the kind of change described by an Event.
+type EventType int
+
+const (
+	// VertexAdded is emitted when a vertex is added.
+	VertexAdded EventType = iota + 1
+
+	// VertexModified is emitted when the properties of a vertex are changed.
+	VertexModified
+
+	// VertexRemoved is emitted when a vertex is removed.
+	VertexRemoved
+
+	// EdgeAdded is emitted when an edge is added.
+	EdgeAdded
+
+	// EdgeModified is emitted when the properties of an edge are changed.
+	EdgeModified
+
+	// EdgeRemoved is emitted when an edge is removed. Removing every edge
+	// between a pair of vertices emits one event per edge.
+	EdgeRemoved
+)
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case VertexAdded:
+		return "VertexAdded"
+	case VertexModified:
+		return "VertexModified"
+	case VertexRemoved:
+		return "VertexRemoved"
+	case EdgeAdded:
+		return "EdgeAdded"
+	case EdgeModified:
+		return "EdgeModified"
+	case EdgeRemoved:
+		return "EdgeRemoved"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event describes a change to a graph. Vertex events set Vertex and the
+// vertex properties; edge events set Source, Target, EdgeID and the edge
+// properties. Before is nil for additions and After is nil for removals.
+type Event[K Ordered] struct {
+	// Type is the kind of change.
+	Type EventType
+
+	// Vertex is the key of the vertex that changed.
+	Vertex K
+
+	// Source and Target are the keys of the vertices of the edge that
+	// changed.
+	Source, Target K
+
+	// EdgeID is the ID of the edge that changed.
+	EdgeID EdgeID
+
+	// VertexBefore and VertexAfter are the properties of the vertex before
+	// and after the change.
+	VertexBefore, VertexAfter VertexProperties
+
+	// EdgeBefore and EdgeAfter are the properties of the edge before and
+	// after the change.
+	EdgeBefore, EdgeAfter EdgeProperties
+}
+
+// Backpressure selects what happens to an event when the channel of a
+// subscription is full.
+type Backpressure int
+
+const (
+	// BackpressureBlock makes the change wait until the subscriber has
+	// received the event. No events are lost, but a slow subscriber slows
+	// down every change to the graph.
+	BackpressureBlock Backpressure = iota
+
+	// BackpressureDropNewest discards the new event.
+	BackpressureDropNewest
+
+	// BackpressureDropOldest discards the oldest event waiting in the channel
+	// to make room for the new one.
+	BackpressureDropOldest
+)
+
+// SubscriptionOptions configures a subscription created by Subscribe.
+type SubscriptionOptions struct {
+	// Buffer is the capacity of the event channel.
+	Buffer int
+
+	// Backpressure is the policy applied when the channel is full.
+	Backpressure Backpressure
+}
+
+// SubscriptionBuffer sets the capacity of the event channel of a
+// subscription. The default is unbuffered.
+func SubscriptionBuffer(size int) func(*SubscriptionOptions) {
+	return func(o *SubscriptionOptions) {
+		o.Buffer = size
+	}
+}
+
+// SubscriptionBackpressure sets the policy applied when the event channel of
+// a subscription is full. The default is BackpressureBlock.
+func SubscriptionBackpressure(policy Backpressure) func(*SubscriptionOptions) {
+	return func(o *SubscriptionOptions) {
+		o.Backpressure = policy
+	}
+}
+
+// Subscription receives the events of a graph after each change.
+type Subscription[K Ordered] interface {
+	// Events returns the channel the events are delivered on, in the order
+	// of the changes. It is closed by Close.
+	Events() <-chan Event[K]
+
+	// Dropped returns the number of events discarded by the backpressure
+	// policy.
+	Dropped() uint64
+
+	// Close ends the subscription. Changes waiting to deliver an event to it
+	// are released.
+	Close()
+}
+
+// Observable is implemented by graphs that report their changes. Hooks run
+// synchronously before a change and may veto it; subscriptions receive
+// events asynchronously after it.
+//
+// Changes made in a Batch are checked by the hooks as they are made, but
+// delivered to subscriptions only once the batch is committed. A rolled back
+// batch delivers nothing.
+//
+// Example:
+//
+//	o := g.(graph.Observable[string])
+//	remove := o.Hook(func(e graph.Event[string]) error {
+//		if e.Type == graph.VertexRemoved && e.Vertex == "root" {
+//			return errors.New("the root is permanent")
+//		}
+//		return nil
+//	})
+//	defer remove()
+//
+//	sub := o.Subscribe(graph.SubscriptionBuffer(64), graph.SubscriptionBackpressure(graph.BackpressureDropOldest))
+//	defer sub.Close()
+//	go func() {
+//		for e := range sub.Events() {
+//			index.Apply(e)
+//		}
+//	}()
+type Observable[K Ordered] interface {
+	// Hook registers a function that is called before every change with the
+	// event describing it. If it returns an error, the change is not made and
+	// the error is returned wrapped in ErrChangeVetoed. A change accepted by
+	// the hooks may still fail, for example if the vertex already exists.
+	// Hooks must not change the graph. The returned function removes the
+	// hook.
+	Hook(hook func(Event[K]) error) (remove func())
+
+	// Subscribe creates a subscription that receives an event after every
+	// change.
+	Subscribe(options ...func(*SubscriptionOptions)) Subscription[K]
+}
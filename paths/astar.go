@@ -0,0 +1,191 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/queue"
+)
+
+var (
+	// ErrInconsistentHeuristic is returned by AStar with the CheckConsistency
+	// option when the heuristic overestimates the cost of an edge or does not
+	// estimate 0 for the target.
+	ErrInconsistentHeuristic = errors.New("heuristic is not consistent")
+)
+
+// consistencyTolerance is the relative amount by which an estimate may exceed
+// the weight of an edge plus the next estimate in the consistency check, to
+// allow for rounding in heuristics computed with floating point numbers.
+const consistencyTolerance = 1e-9
+
+// AStarResult is the outcome of a search with AStar.
+type AStarResult[K graph.Ordered] struct {
+	// Path holds the vertices of the path found, including the source and
+	// the target.
+	Path []K
+
+	// Cost is the total weight of the path.
+	Cost float64
+
+	// Expanded is the number of vertices whose edges were examined before
+	// the target was reached.
+	Expanded int
+}
+
+// AStarOption configures a search with AStar.
+type AStarOption func(*astarOptions)
+
+type astarOptions struct {
+	checkConsistency bool
+}
+
+// CheckConsistency makes AStar verify that the heuristic is consistent as it
+// searches: for every edge from u to v that is examined, h(u) must not exceed
+// the weight of the edge plus h(v), and h(target) must be 0. The first
+// violation ends the search with an error wrapping ErrInconsistentHeuristic.
+// The check costs one extra heuristic call per edge and is meant for
+// debugging heuristics.
+func CheckConsistency() AStarOption {
+	return func(o *astarOptions) {
+		o.checkConsistency = true
+	}
+}
+
+// AStar computes a shortest path between a source and a target vertex,
+// guided by a heuristic that estimates the cost of the cheapest path from a
+// vertex to the target. Vertices are expanded in the order of their distance
+// from the source plus their estimate, so a good heuristic leads the search
+// towards the target and expands far fewer vertices than DijkstraFrom. A nil
+// heuristic estimates 0 everywhere, which makes the search Dijkstra's.
+//
+// The path found is a shortest one if the heuristic is admissible, that is,
+// it never overestimates the cost to the target. ManhattanHeuristic,
+// EuclideanHeuristic and ChebyshevHeuristic provide admissible heuristics for
+// vertices placed on a grid. Vertices are expanded at most once if the
+// heuristic is also consistent; CheckConsistency verifies this.
+//
+// Edges of an unweighted graph have weight 1. In a multigraph, the lightest
+// of several parallel edges is used. If an edge with a negative weight is
+// examined, an error wrapping ErrNegativeWeight is returned. If the target is
+// not reachable from the source, ErrTargetNotReachable is returned.
+//
+// Example:
+//
+//	h, err := ManhattanHeuristic(g, "goal", "x", "y")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	result, err := AStar(g, "start", "goal", h)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	fmt.Printf("Path %v costs %v, %d vertices expanded\n", result.Path, result.Cost, result.Expanded)
+func AStar[K graph.Ordered, T any](g graph.Interface[K, T], source, target K, heuristic func(K) float64, options ...AStarOption) (*AStarResult[K], error) {
+	if g == nil {
+		return nil, graph.ErrNilInputGraph
+	}
+
+	var opts astarOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	if heuristic == nil {
+		heuristic = func(K) float64 {
+			return 0
+		}
+	}
+
+	for _, hash := range []K{source, target} {
+		exists, err := g.HasVertex(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, graph.ErrVertexNotFound
+		}
+	}
+
+	if opts.checkConsistency {
+		if h := heuristic(target); h != 0 {
+			return nil, fmt.Errorf("%w: estimate for the target %v is %v", ErrInconsistentHeuristic, target, h)
+		}
+	}
+
+	outEdges, err := outWeights(g)
+	if err != nil {
+		return nil, err
+	}
+
+	costs := map[K]float64{source: 0}
+	predecessors := make(map[K]K)
+	open := map[K]bool{source: true}
+	expanded := 0
+
+	q := queue.NewPriorityQueue[K]()
+	q.Enqueue(source, heuristic(source))
+
+	for q.Len() > 0 {
+		vertex, _ := q.Dequeue()
+		open[vertex] = false
+
+		if vertex == target {
+			path := []K{target}
+			for current := target; current != source; {
+				current = predecessors[current]
+				path = append(path, current)
+			}
+			slices.Reverse(path)
+
+			return &AStarResult[K]{Path: path, Cost: costs[target], Expanded: expanded}, nil
+		}
+
+		expanded++
+		estimate := heuristic(vertex)
+
+		for adjacency, weight := range outEdges(vertex) {
+			if weight < 0 {
+				return nil, fmt.Errorf("%w: edge from %v to %v has weight %v", ErrNegativeWeight, vertex, adjacency, weight)
+			}
+
+			if opts.checkConsistency {
+				if next := heuristic(adjacency); estimate-(weight+next) > consistencyTolerance*max(1, math.Abs(estimate)) {
+					return nil, fmt.Errorf("%w: estimate %v for %v exceeds the weight %v of the edge to %v plus its estimate %v",
+						ErrInconsistentHeuristic, estimate, vertex, weight, adjacency, next)
+				}
+			}
+
+			cost := costs[vertex] + weight
+			if current, reached := costs[adjacency]; reached && cost >= current {
+				continue
+			}
+
+			costs[adjacency] = cost
+			predecessors[adjacency] = vertex
+
+			// A vertex is queued again if a cheaper path to it is found after
+			// it has been expanded, which only happens with an inconsistent
+			// heuristic.
+			if open[adjacency] {
+				q.SetPriority(adjacency, cost+heuristic(adjacency))
+			} else {
+				open[adjacency] = true
+				q.Enqueue(adjacency, cost+heuristic(adjacency))
+			}
+		}
+	}
+
+	return nil, graph.ErrTargetNotReachable
+}
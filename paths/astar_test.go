@@ -0,0 +1,213 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package paths
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+// newGrid creates an undirected grid graph of size × size cells. The key of
+// the cell in column x and row y is y*size + x, and its coordinates are
+// stored in the items "x" and "y". Cells for which wall returns true are left
+// out. With diagonal set, cells are also joined diagonally by edges of weight
+// √2.
+func newGrid(t *testing.T, size int, diagonal bool, wall func(x, y int) bool) graph.Interface[int, int] {
+	is := assert.New(t)
+
+	g, err := simple.New(graph.IntHash, graph.Weighted())
+	is.NoError(err)
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if !wall(x, y) {
+				is.NoError(g.AddVertexWithOptions(y*size+x, simple.VertexItem("x", x), simple.VertexItem("y", y)))
+			}
+		}
+	}
+
+	connect := func(x, y, dx, dy int, weight float64) {
+		nx, ny := x+dx, y+dy
+		if nx < 0 || nx >= size || ny >= size || wall(x, y) || wall(nx, ny) {
+			return
+		}
+		is.NoError(g.AddEdgeWithOptions(y*size+x, ny*size+nx, simple.EdgeWeight(weight)))
+	}
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			connect(x, y, 1, 0, 1)
+			connect(x, y, 0, 1, 1)
+			if diagonal {
+				connect(x, y, 1, 1, math.Sqrt2)
+				connect(x, y, -1, 1, math.Sqrt2)
+			}
+		}
+	}
+
+	return g
+}
+
+func TestAStar(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Expands fewer vertices than Dijkstra on a grid", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		// A wall in column 5 with a gap in the bottom row.
+		g := newGrid(t, 10, false, func(x, y int) bool {
+			return x == 5 && y < 9
+		})
+		source, target := 0, 9
+
+		h, err := ManhattanHeuristic(g, target, "x", "y")
+		is.NoError(err)
+
+		guided, err := AStar(g, source, target, h, CheckConsistency())
+		is.NoError(err)
+
+		blind, err := AStar(g, source, target, nil)
+		is.NoError(err)
+
+		tree, err := ShortestPathTreeFrom(g, source)
+		is.NoError(err)
+
+		is.Equal(tree.DistTo(target), guided.Cost)
+		is.Equal(tree.DistTo(target), blind.Cost)
+		is.Equal(27.0, guided.Cost)
+		is.Len(guided.Path, 28)
+		is.Equal(source, guided.Path[0])
+		is.Equal(target, guided.Path[len(guided.Path)-1])
+		is.Less(guided.Expanded, blind.Expanded)
+	})
+
+	t.Run("Uses diagonal heuristics", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g := newGrid(t, 8, true, func(int, int) bool {
+			return false
+		})
+		source, target := 0, 63
+
+		euclidean, err := EuclideanHeuristic(g, target, "x", "y")
+		is.NoError(err)
+		result, err := AStar(g, source, target, euclidean, CheckConsistency())
+		is.NoError(err)
+		is.InDelta(7*math.Sqrt2, result.Cost, 1e-9)
+		is.Len(result.Path, 8)
+
+		// Diagonal moves cost more than 1, so the Chebyshev distance is
+		// admissible too.
+		chebyshev, err := ChebyshevHeuristic(g, target, "x", "y")
+		is.NoError(err)
+		result, err = AStar(g, source, target, chebyshev, CheckConsistency())
+		is.NoError(err)
+		is.InDelta(7*math.Sqrt2, result.Cost, 1e-9)
+	})
+
+	t.Run("Reports an inconsistent heuristic", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g := newGrid(t, 3, false, func(int, int) bool {
+			return false
+		})
+
+		overestimate := func(hash int) float64 {
+			if hash == 0 {
+				return 10
+			}
+			return 0
+		}
+
+		_, err := AStar(g, 0, 8, overestimate, CheckConsistency())
+		is.ErrorIs(err, ErrInconsistentHeuristic)
+
+		// Without the check, the search completes.
+		result, err := AStar(g, 0, 8, overestimate)
+		is.NoError(err)
+		is.Equal(4.0, result.Cost)
+
+		_, err = AStar(g, 0, 8, func(int) float64 { return 1 }, CheckConsistency())
+		is.ErrorIs(err, ErrInconsistentHeuristic)
+	})
+
+	t.Run("Handles trivial and failing searches", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g := newGrid(t, 3, false, func(x, y int) bool {
+			return x == 1
+		})
+
+		result, err := AStar(g, 0, 0, nil)
+		is.NoError(err)
+		is.Equal([]int{0}, result.Path)
+		is.Equal(0.0, result.Cost)
+		is.Zero(result.Expanded)
+
+		_, err = AStar(g, 0, 2, nil)
+		is.ErrorIs(err, graph.ErrTargetNotReachable)
+
+		_, err = AStar(g, 0, 1, nil)
+		is.ErrorIs(err, graph.ErrVertexNotFound)
+
+		_, err = AStar[int, int](nil, 0, 1, nil)
+		is.ErrorIs(err, graph.ErrNilInputGraph)
+	})
+
+	t.Run("Rejects negative weights", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.IntHash, graph.Directed(), graph.Weighted())
+		is.NoError(g.AddVertexWithOptions(1))
+		is.NoError(g.AddVertexWithOptions(2))
+		is.NoError(g.AddEdgeWithOptions(1, 2, simple.EdgeWeight(-1)))
+
+		_, err := AStar(g, 1, 2, nil)
+		is.ErrorIs(err, ErrNegativeWeight)
+	})
+}
+
+func TestGridHeuristics(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash)
+	is.NoError(g.AddVertexWithOptions("A", simple.VertexItem("x", 0), simple.VertexItem("y", 0)))
+	is.NoError(g.AddVertexWithOptions("B", simple.VertexItem("x", 3.0), simple.VertexItem("y", int64(4))))
+	is.NoError(g.AddVertexWithOptions("C"))
+
+	manhattan, err := ManhattanHeuristic(g, "B", "x", "y")
+	is.NoError(err)
+	is.Equal(7.0, manhattan("A"))
+	is.Equal(0.0, manhattan("B"))
+
+	euclidean, err := EuclideanHeuristic(g, "B", "x", "y")
+	is.NoError(err)
+	is.Equal(5.0, euclidean("A"))
+
+	chebyshev, err := ChebyshevHeuristic(g, "B", "x", "y")
+	is.NoError(err)
+	is.Equal(4.0, chebyshev("A"))
+
+	// Vertices without coordinates are estimated at 0.
+	is.Equal(0.0, manhattan("C"))
+	is.Equal(0.0, manhattan("missing"))
+
+	_, err = ManhattanHeuristic(g, "C", "x", "y")
+	is.ErrorIs(err, ErrMissingCoordinates)
+
+	_, err = ManhattanHeuristic(g, "missing", "x", "y")
+	is.ErrorIs(err, graph.ErrVertexNotFound)
+}
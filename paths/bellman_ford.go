@@ -0,0 +1,186 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/iterate"
+)
+
+var (
+	// ErrNegativeCycle is matched by a NegativeCycleError with errors.Is.
+	ErrNegativeCycle = errors.New("negative cycle")
+)
+
+// NegativeCycleError is returned by BellmanFordFrom when a cycle of negative
+// total weight can be reached from the source, so that no shortest paths
+// exist.
+type NegativeCycleError[K graph.Ordered] struct {
+	// Cycle holds the vertices of the cycle in the order of its edges,
+	// starting with the smallest key. The last vertex has an edge to the
+	// first.
+	Cycle []K
+}
+
+// Error implements the error interface.
+func (e *NegativeCycleError[K]) Error() string {
+	vertices := make([]string, len(e.Cycle), len(e.Cycle)+1)
+	for i, vertex := range e.Cycle {
+		vertices[i] = fmt.Sprint(vertex)
+	}
+	if len(vertices) > 0 {
+		vertices = append(vertices, vertices[0])
+	}
+
+	return fmt.Sprintf("%v: %s", ErrNegativeCycle, strings.Join(vertices, " -> "))
+}
+
+// Unwrap returns ErrNegativeCycle.
+func (e *NegativeCycleError[K]) Unwrap() error {
+	return ErrNegativeCycle
+}
+
+// BellmanFordFrom computes the shortest paths from a source vertex to every
+// vertex of the graph. Unlike DijkstraFrom, it accepts negative edge weights.
+// Edges of an unweighted graph have weight 1, and an undirected edge can be
+// used in both directions, so an undirected edge with a negative weight forms
+// a negative cycle.
+//
+// It returns the distance of every vertex from the source, which is +Inf for
+// vertices that cannot be reached, and the predecessor of every reached
+// vertex other than the source on a shortest path to it. Following the
+// predecessors from a vertex leads back to the source.
+//
+// The shortest path faster algorithm (SPFA) is used: only the vertices whose
+// distance has just decreased are queued for relaxing their edges. If a cycle
+// of negative weight can be reached from the source, a *NegativeCycleError
+// holding the cycle is returned.
+//
+// Example:
+//
+//	distances, predecessors, err := BellmanFordFrom(g, "A")
+//	var cycle *NegativeCycleError[string]
+//	if errors.As(err, &cycle) {
+//		fmt.Printf("Negative cycle: %v\n", cycle.Cycle)
+//	} else if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	fmt.Printf("Distance to D: %v, reached from %v\n", distances["D"], predecessors["D"])
+func BellmanFordFrom[K graph.Ordered, T any](g graph.Interface[K, T], source K) (map[K]float64, map[K]K, error) {
+	if g == nil {
+		return nil, nil, graph.ErrNilInputGraph
+	}
+
+	exists, err := g.HasVertex(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !exists {
+		return nil, nil, graph.ErrVertexNotFound
+	}
+
+	vertices, err := iterate.Vertices(g)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	outEdges, err := outWeights(g)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	distances := make(map[K]float64)
+	for hash := range vertices {
+		distances[hash] = math.Inf(1)
+	}
+	distances[source] = 0
+
+	predecessors := make(map[K]K)
+	queued := map[K]bool{source: true}
+	queue := []K{source}
+	relaxations := 0
+
+	for len(queue) > 0 {
+		vertex := queue[0]
+		queue = queue[1:]
+		queued[vertex] = false
+
+		for adjacency, weight := range outEdges(vertex) {
+			distance := distances[vertex] + weight
+			if distance >= distances[adjacency] {
+				continue
+			}
+
+			distances[adjacency] = distance
+			predecessors[adjacency] = vertex
+
+			// Any cycle among the predecessors has a negative weight, and
+			// one forms if a negative cycle can be reached. Looking for it
+			// once per len(distances) relaxations keeps the cost linear.
+			relaxations++
+			if relaxations%len(distances) == 0 {
+				if cycle := predecessorCycle(predecessors); cycle != nil {
+					return nil, nil, &NegativeCycleError[K]{Cycle: cycle}
+				}
+			}
+
+			if !queued[adjacency] {
+				queued[adjacency] = true
+				queue = append(queue, adjacency)
+			}
+		}
+	}
+
+	return distances, predecessors, nil
+}
+
+// predecessorCycle returns a cycle formed by the predecessors, or nil if
+// there is none. The cycle starts with its smallest key and follows the
+// edges from each vertex to the next.
+func predecessorCycle[K graph.Ordered](predecessors map[K]K) []K {
+	const (
+		unvisited = iota
+		walking
+		done
+	)
+
+	state := make(map[K]int, len(predecessors))
+	for _, start := range slices.Sorted(maps.Keys(predecessors)) {
+		// Walk the predecessors from start until a vertex without one, a
+		// vertex of an earlier walk, or a vertex of this walk is found.
+		var walk []K
+		vertex, ok := start, true
+		for ok && state[vertex] == unvisited {
+			state[vertex] = walking
+			walk = append(walk, vertex)
+			vertex, ok = predecessors[vertex]
+		}
+
+		if ok && state[vertex] == walking {
+			// The walk has returned to vertex: the cycle is the part of the
+			// walk from vertex on, in the opposite direction to the edges.
+			cycle := walk[slices.Index(walk, vertex):]
+			slices.Reverse(cycle)
+			first := slices.Index(cycle, slices.Min(cycle))
+			return slices.Concat(cycle[first:], cycle[:first])
+		}
+
+		for _, visited := range walk {
+			state[visited] = done
+		}
+	}
+
+	return nil
+}
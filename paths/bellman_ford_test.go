@@ -0,0 +1,140 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package paths
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBellmanFordFrom(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Finds shortest paths with negative weights", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+		for _, v := range []string{"A", "B", "C", "D", "E"} {
+			is.NoError(g.AddVertexWithOptions(v))
+		}
+		is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(4)))
+		is.NoError(g.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+		is.NoError(g.AddEdgeWithOptions("B", "D", simple.EdgeWeight(-3)))
+		is.NoError(g.AddEdgeWithOptions("C", "B", simple.EdgeWeight(-1)))
+		is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(5)))
+
+		distances, predecessors, err := BellmanFordFrom(g, "A")
+		is.NoError(err)
+		is.Equal(map[string]float64{"A": 0, "B": 1, "C": 2, "D": -2, "E": math.Inf(1)}, distances)
+		is.Equal(map[string]string{"B": "C", "C": "A", "D": "B"}, predecessors)
+	})
+
+	t.Run("Counts edges of unweighted graphs", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.IntHash)
+		for i := 1; i <= 4; i++ {
+			is.NoError(g.AddVertexWithOptions(i))
+		}
+		is.NoError(g.AddEdgeWithOptions(1, 2, simple.EdgeWeight(-5)))
+		is.NoError(g.AddEdgeWithOptions(2, 3))
+		is.NoError(g.AddEdgeWithOptions(3, 4))
+		is.NoError(g.AddEdgeWithOptions(4, 1))
+
+		distances, _, err := BellmanFordFrom(g, 1)
+		is.NoError(err)
+		is.Equal(map[int]float64{1: 0, 2: 1, 3: 2, 4: 1}, distances)
+	})
+
+	t.Run("Reports a reachable negative cycle", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+		for _, v := range []string{"S", "A", "B", "C", "D"} {
+			is.NoError(g.AddVertexWithOptions(v))
+		}
+		is.NoError(g.AddEdgeWithOptions("S", "A", simple.EdgeWeight(1)))
+		is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+		is.NoError(g.AddEdgeWithOptions("B", "C", simple.EdgeWeight(-4)))
+		is.NoError(g.AddEdgeWithOptions("C", "A", simple.EdgeWeight(2)))
+		is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(1)))
+
+		_, _, err := BellmanFordFrom(g, "S")
+		is.ErrorIs(err, ErrNegativeCycle)
+
+		var cycle *NegativeCycleError[string]
+		is.True(errors.As(err, &cycle))
+		is.Equal([]string{"A", "B", "C"}, cycle.Cycle)
+		is.Equal("negative cycle: A -> B -> C -> A", err.Error())
+	})
+
+	t.Run("Ignores an unreachable negative cycle", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.IntHash, graph.Directed(), graph.Weighted())
+		for i := 1; i <= 4; i++ {
+			is.NoError(g.AddVertexWithOptions(i))
+		}
+		is.NoError(g.AddEdgeWithOptions(1, 2, simple.EdgeWeight(3)))
+		is.NoError(g.AddEdgeWithOptions(3, 4, simple.EdgeWeight(-2)))
+		is.NoError(g.AddEdgeWithOptions(4, 3, simple.EdgeWeight(1)))
+
+		distances, _, err := BellmanFordFrom(g, 1)
+		is.NoError(err)
+		is.Equal(3.0, distances[2])
+		is.True(math.IsInf(distances[3], 1))
+	})
+
+	t.Run("Treats a negative undirected edge as a cycle", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.IntHash, graph.Weighted())
+		is.NoError(g.AddVertexWithOptions(1))
+		is.NoError(g.AddVertexWithOptions(2))
+		is.NoError(g.AddEdgeWithOptions(1, 2, simple.EdgeWeight(-1)))
+
+		_, _, err := BellmanFordFrom(g, 1)
+		var cycle *NegativeCycleError[int]
+		is.True(errors.As(err, &cycle))
+		is.Equal([]int{1, 2}, cycle.Cycle)
+	})
+
+	t.Run("Reports a negative self-loop", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.IntHash, graph.Directed(), graph.Weighted())
+		is.NoError(g.AddVertexWithOptions(1))
+		is.NoError(g.AddEdgeWithOptions(1, 1, simple.EdgeWeight(-1)))
+
+		_, _, err := BellmanFordFrom(g, 1)
+		var cycle *NegativeCycleError[int]
+		is.True(errors.As(err, &cycle))
+		is.Equal([]int{1}, cycle.Cycle)
+	})
+
+	t.Run("Returns error for missing source", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.IntHash, graph.Directed())
+		_, _, err := BellmanFordFrom(g, 1)
+		is.ErrorIs(err, graph.ErrVertexNotFound)
+
+		_, _, err = BellmanFordFrom[int, int](nil, 1)
+		is.ErrorIs(err, graph.ErrNilInputGraph)
+	})
+}
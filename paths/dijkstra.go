@@ -6,11 +6,18 @@
 package paths
 
 import (
+	"errors"
 	"fmt"
-	"math"
+	"iter"
 
 	"github.com/sixafter/graph"
-	"github.com/sixafter/graph/internal/queue"
+	"github.com/sixafter/graph/internal/iterate"
+)
+
+var (
+	// ErrNegativeWeight is returned by DijkstraFrom when the graph has an edge
+	// with a negative weight. Use BellmanFordFrom for such graphs.
+	ErrNegativeWeight = errors.New("negative edge weight")
 )
 
 // DijkstraFrom computes the shortest path between a source and a target vertex
@@ -19,6 +26,14 @@ import (
 //
 // If the target is not reachable from the source, ErrTargetNotReachable is returned.
 // If there are multiple shortest paths, an arbitrary one will be returned.
+// In a multigraph, the lightest of several parallel edges is used.
+//
+// The path is read from the tree that ShortestPathTreeFrom computes for the
+// source; use ShortestPathTreeFrom directly to find the paths to several
+// targets. Dijkstra's algorithm requires non-negative weights. If an edge
+// that can be reached from the source has a negative weight, an error
+// wrapping ErrNegativeWeight is returned; BellmanFordFrom handles negative
+// weights.
 //
 // Example:
 //
@@ -41,7 +56,7 @@ func DijkstraFrom[K graph.Ordered, T any](g graph.Interface[K, T], source, targe
 		return []K{source}, nil
 	}
 
-	exists, err := g.HasVertex(source)
+	exists, err := g.HasVertex(target)
 	if err != nil {
 		return nil, err
 	}
@@ -50,69 +65,46 @@ func DijkstraFrom[K graph.Ordered, T any](g graph.Interface[K, T], source, targe
 		return nil, graph.ErrVertexNotFound
 	}
 
-	exists, err = g.HasVertex(target)
+	tree, err := ShortestPathTreeFrom(g, source)
 	if err != nil {
 		return nil, err
 	}
 
-	if !exists {
-		return nil, graph.ErrVertexNotFound
+	if !tree.HasPathTo(target) {
+		return nil, graph.ErrTargetNotReachable
 	}
 
-	weights := make(map[K]float64)
-	visited := make(map[K]bool)
-
-	weights[source] = 0
-	visited[target] = true
+	return tree.PathTo(target), nil
+}
 
-	q := queue.NewPriorityQueue[K]()
-	adjacencyMap, err := g.AdjacencyMap()
-	if err != nil {
-		return nil, fmt.Errorf("%s: %v", graph.ErrAdjacencyMap, err)
+// weightOf returns the weight of an edge for the shortest path algorithms:
+// its weight in a weighted graph, and 1 otherwise.
+func weightOf[K graph.Ordered, T any](g graph.Interface[K, T], edge graph.Edge[K]) float64 {
+	if !g.Traits().IsWeighted {
+		return 1
 	}
 
-	for hash := range adjacencyMap {
-		if hash != source {
-			weights[hash] = math.Inf(1)
-			visited[hash] = false
-		}
+	return edge.Properties().Weight()
+}
 
-		q.Enqueue(hash, weights[hash])
+// outWeights returns a function that lists the edges leaving a vertex of g,
+// each as the key of the vertex it leads to and its weight for the shortest
+// path algorithms: its weight in a weighted graph, and 1 otherwise. Parallel
+// edges are listed once each, so the lightest of them determines the weight
+// of a path along them.
+func outWeights[K graph.Ordered, T any](g graph.Interface[K, T]) (func(K) iter.Seq2[K, float64], error) {
+	outEdges, err := iterate.OutEdges(g)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrAdjacencyMap, err)
 	}
 
-	bestPredecessors := make(map[K]K)
-
-	for q.Len() > 0 {
-		vertex, _ := q.Dequeue()
-		hasInfiniteWeight := math.IsInf(weights[vertex], 1)
-
-		for adjacency, edge := range adjacencyMap[vertex] {
-			edgeWeight := edge.Properties().Weight()
-
-			if !g.Traits().IsWeighted {
-				edgeWeight = 1
-			}
-
-			weight := weights[vertex] + edgeWeight
-
-			if weight < weights[adjacency] && !hasInfiniteWeight {
-				weights[adjacency] = weight
-				bestPredecessors[adjacency] = vertex
-				q.SetPriority(adjacency, weight)
+	return func(hash K) iter.Seq2[K, float64] {
+		return func(yield func(K, float64) bool) {
+			for adjacency, edge := range outEdges(hash) {
+				if !yield(adjacency, weightOf(g, edge)) {
+					return
+				}
 			}
 		}
-	}
-
-	path := []K{target}
-	current := target
-
-	for current != source {
-		if _, ok := bestPredecessors[current]; !ok {
-			return nil, graph.ErrTargetNotReachable
-		}
-		current = bestPredecessors[current]
-		path = append([]K{current}, path...)
-	}
-
-	return path, nil
+	}, nil
 }
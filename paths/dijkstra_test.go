@@ -45,4 +45,39 @@ func TestDijkstraFrom(t *testing.T) {
 		is.Nil(p, "Path should be nil if target is unreachable")
 		is.ErrorIs(err, graph.ErrTargetNotReachable, "Error should be ErrTargetNotReachable")
 	})
+
+	t.Run("Uses the lightest parallel edge in a multigraph", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.IntHash, graph.Directed(), graph.Weighted(), graph.MultiGraph())
+		is.NoError(g.AddVertexWithOptions(1))
+		is.NoError(g.AddVertexWithOptions(2))
+		is.NoError(g.AddVertexWithOptions(3))
+		is.NoError(g.AddEdgeWithOptions(1, 3, simple.EdgeWeight(10)))
+		is.NoError(g.AddEdgeWithOptions(1, 3, simple.EdgeWeight(1)))
+		is.NoError(g.AddEdgeWithOptions(1, 2, simple.EdgeWeight(1)))
+		is.NoError(g.AddEdgeWithOptions(2, 3, simple.EdgeWeight(1)))
+
+		p, err := DijkstraFrom(g, 1, 3)
+		is.NoError(err)
+		is.Equal([]int{1, 3}, p, "The parallel edge of weight 1 should be used")
+	})
+
+	t.Run("Rejects negative weights", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.IntHash, graph.Directed(), graph.Weighted())
+		is.NoError(g.AddVertexWithOptions(1))
+		is.NoError(g.AddVertexWithOptions(2))
+		is.NoError(g.AddVertexWithOptions(3))
+		is.NoError(g.AddEdgeWithOptions(1, 2, simple.EdgeWeight(4)))
+		is.NoError(g.AddEdgeWithOptions(1, 3, simple.EdgeWeight(2)))
+		is.NoError(g.AddEdgeWithOptions(2, 3, simple.EdgeWeight(-3)))
+
+		p, err := DijkstraFrom(g, 1, 3)
+		is.ErrorIs(err, ErrNegativeWeight)
+		is.Nil(p)
+	})
 }
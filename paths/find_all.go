@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/iterate"
 	"github.com/sixafter/graph/internal/queue"
 )
 
@@ -69,7 +70,7 @@ import (
 //
 //	// Output: [[1, 2, 4], [1, 3, 4]]
 func FindAllPaths[K graph.Ordered, T any](g graph.Interface[K, T], start, end K) ([][]K, error) {
-	adjacencyMap, err := g.AdjacencyMap()
+	successors, err := iterate.Successors(g)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +89,7 @@ func FindAllPaths[K graph.Ordered, T any](g graph.Interface[K, T], start, end K)
 		mainStack.Push(element)
 		newElements := queue.NewStack[K]()
 
-		for e := range adjacencyMap[element] {
+		for e := range successors(element) {
 			var contains bool
 			mainStack.ForEach(func(k K) {
 				if e == k {
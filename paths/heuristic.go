@@ -0,0 +1,149 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/sixafter/graph"
+)
+
+var (
+	// ErrMissingCoordinates is returned when a grid heuristic is created for
+	// a target whose vertex has no numeric coordinates in its items.
+	ErrMissingCoordinates = errors.New("vertex has no coordinates")
+)
+
+// ManhattanHeuristic returns a heuristic for AStar that estimates the cost
+// from a vertex to the target as the Manhattan distance |dx| + |dy| between
+// their grid coordinates. The coordinates of a vertex are the numeric values
+// of its items xKey and yKey.
+//
+// The heuristic is admissible and consistent for grids where moving to an
+// adjacent cell horizontally or vertically costs at least 1 and there are no
+// diagonal moves. A vertex without coordinates is estimated at 0, which keeps
+// the heuristic admissible.
+//
+// It returns graph.ErrVertexNotFound if the target does not exist, and
+// ErrMissingCoordinates if it has no coordinates.
+//
+// Example:
+//
+//	_ = g.AddVertexWithOptions("r1c2", simple.VertexItem("x", 2), simple.VertexItem("y", 1))
+//
+//	h, err := ManhattanHeuristic(g, "r9c9", "x", "y")
+func ManhattanHeuristic[K graph.Ordered, T any](g graph.Interface[K, T], target K, xKey, yKey string) (func(K) float64, error) {
+	return gridHeuristic(g, target, xKey, yKey, func(dx, dy float64) float64 {
+		return dx + dy
+	})
+}
+
+// EuclideanHeuristic returns a heuristic for AStar that estimates the cost
+// from a vertex to the target as the straight-line distance between their
+// grid coordinates, read from the items xKey and yKey as for
+// ManhattanHeuristic.
+//
+// The heuristic is admissible and consistent whenever the weight of every
+// edge is at least the straight-line distance between its vertices, such as
+// on grids with diagonal moves costing √2, or road networks weighted by
+// length.
+func EuclideanHeuristic[K graph.Ordered, T any](g graph.Interface[K, T], target K, xKey, yKey string) (func(K) float64, error) {
+	return gridHeuristic(g, target, xKey, yKey, math.Hypot)
+}
+
+// ChebyshevHeuristic returns a heuristic for AStar that estimates the cost
+// from a vertex to the target as the Chebyshev distance max(|dx|, |dy|)
+// between their grid coordinates, read from the items xKey and yKey as for
+// ManhattanHeuristic.
+//
+// The heuristic is admissible and consistent for grids where moving to any of
+// the eight adjacent cells, including diagonally, costs at least 1.
+func ChebyshevHeuristic[K graph.Ordered, T any](g graph.Interface[K, T], target K, xKey, yKey string) (func(K) float64, error) {
+	return gridHeuristic(g, target, xKey, yKey, math.Max)
+}
+
+// gridHeuristic returns a heuristic that applies distance to the absolute
+// differences between the coordinates of a vertex and those of the target.
+func gridHeuristic[K graph.Ordered, T any](g graph.Interface[K, T], target K, xKey, yKey string, distance func(dx, dy float64) float64) (func(K) float64, error) {
+	if g == nil {
+		return nil, graph.ErrNilInputGraph
+	}
+
+	vertex, err := g.Vertex(target)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, ty, ok := coordinates(vertex, xKey, yKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: target %v has no numeric items %q and %q", ErrMissingCoordinates, target, xKey, yKey)
+	}
+
+	return func(hash K) float64 {
+		vertex, err := g.Vertex(hash)
+		if err != nil {
+			return 0
+		}
+
+		x, y, ok := coordinates(vertex, xKey, yKey)
+		if !ok {
+			return 0
+		}
+
+		return distance(math.Abs(x-tx), math.Abs(y-ty))
+	}, nil
+}
+
+// coordinates returns the values of the items xKey and yKey of a vertex as
+// numbers.
+func coordinates[K comparable, T any](vertex graph.Vertex[K, T], xKey, yKey string) (float64, float64, bool) {
+	properties := vertex.Properties()
+	if properties == nil {
+		return 0, 0, false
+	}
+
+	x, ok := number(properties.Items()[xKey])
+	if !ok {
+		return 0, 0, false
+	}
+
+	y, ok := number(properties.Items()[yKey])
+	return x, y, ok
+}
+
+// number converts a numeric item value to a float64.
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
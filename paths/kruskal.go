@@ -11,6 +11,7 @@ import (
 	"sort"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/iterate"
 	"github.com/sixafter/graph/simple"
 )
 
@@ -79,12 +80,18 @@ func spanningTree[K graph.Ordered, T any](g graph.Interface[K, T], maximum bool)
 		return nil, ErrDirectedGraph
 	}
 
-	adjacencyMap, err := g.AdjacencyMap()
+	vertices, err := iterate.Vertices(g)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get adjacency map: %w", err)
+		return nil, fmt.Errorf("failed to get vertices: %w", err)
+	}
+
+	// The edges are taken from Edges rather than the adjacency map, which
+	// holds only one of several parallel edges in a multigraph.
+	edges, err := g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edges: %w", err)
 	}
 
-	edges := make([]graph.Edge[K], 0)
 	subtrees := newUnionFind[K]()
 
 	mst, err := simple.NewLike(g)
@@ -92,7 +99,7 @@ func spanningTree[K graph.Ordered, T any](g graph.Interface[K, T], maximum bool)
 		return nil, fmt.Errorf("failed to create new graph: %w", err)
 	}
 
-	for v, adjacency := range adjacencyMap {
+	for v := range vertices {
 		var vertex graph.Vertex[K, T]
 		vertex, err = g.Vertex(v)
 		if err != nil {
@@ -105,10 +112,6 @@ func spanningTree[K graph.Ordered, T any](g graph.Interface[K, T], maximum bool)
 		}
 
 		subtrees.Add(v)
-
-		for _, edge := range adjacency {
-			edges = append(edges, edge)
-		}
 	}
 
 	if maximum {
@@ -224,3 +224,36 @@ func TestSpanningTreeDisconnectedGraph(t *testing.T) {
 	mstEdges, _ := mst.Edges()
 	is.LessOrEqual(len(mstEdges), len(vertices)-1, "MST should span connected components")
 }
+
+func TestSpanningTreeMultigraph(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := simple.New(graph.StringHash, graph.Weighted(), graph.MultiGraph())
+	for _, v := range []string{"A", "B", "C"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	for _, edge := range []testEdge{
+		{"A", "B", 5},
+		{"A", "B", 1},
+		{"B", "A", 9},
+		{"B", "C", 2},
+	} {
+		is.NoError(g.AddEdgeWithOptions(edge.Source, edge.Target, simple.EdgeWeight(float64(edge.Weight))))
+	}
+
+	mst, err := MinimumSpanningTree(g)
+	is.NoError(err)
+	e, err := mst.Edge("A", "B")
+	is.NoError(err)
+	is.Equal(float64(1), e.Properties().Weight(), "The lightest parallel edge should be chosen")
+
+	maxST, err := MaximumSpanningTree(g)
+	is.NoError(err)
+	e, err = maxST.Edge("A", "B")
+	is.NoError(err)
+	is.Equal(float64(9), e.Properties().Weight(), "The heaviest parallel edge should be chosen")
+
+	size, _ := maxST.Size()
+	is.Equal(2, size)
+}
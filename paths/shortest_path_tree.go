@@ -0,0 +1,156 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package paths
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/queue"
+)
+
+// ShortestPathTree holds the shortest paths from a source vertex to every
+// vertex that can be reached from it, as computed by ShortestPathTreeFrom.
+// Each reached vertex other than the source has a predecessor, the vertex
+// before it on its shortest path.
+type ShortestPathTree[K graph.Ordered] struct {
+	source       K
+	distances    map[K]float64
+	predecessors map[K]K
+}
+
+// ShortestPathTreeFrom computes the shortest paths from a source vertex to
+// every other vertex with a single run of Dijkstra's algorithm. Edges of an
+// unweighted graph have weight 1. In a multigraph, the lightest of several
+// parallel edges is used. If there are multiple shortest paths to a vertex,
+// an arbitrary one is kept.
+//
+// Vertices are queued when they are first reached, and their priority is
+// decreased in place when a shorter path to them is found, so each vertex is
+// dequeued once.
+//
+// Dijkstra's algorithm requires non-negative weights. If an edge that can be
+// reached from the source has a negative weight, an error wrapping
+// ErrNegativeWeight is returned; BellmanFordFrom handles negative weights.
+//
+// Example:
+//
+//	tree, err := ShortestPathTreeFrom(g, "A")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	if tree.HasPathTo("D") {
+//		fmt.Printf("Path to D: %v, distance %v\n", tree.PathTo("D"), tree.DistTo("D"))
+//	}
+func ShortestPathTreeFrom[K graph.Ordered, T any](g graph.Interface[K, T], source K) (*ShortestPathTree[K], error) {
+	if g == nil {
+		return nil, graph.ErrNilInputGraph
+	}
+
+	exists, err := g.HasVertex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, graph.ErrVertexNotFound
+	}
+
+	outEdges, err := outWeights(g)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &ShortestPathTree[K]{
+		source:       source,
+		distances:    map[K]float64{source: 0},
+		predecessors: make(map[K]K),
+	}
+
+	settled := make(map[K]bool)
+	q := queue.NewPriorityQueue[K]()
+	q.Enqueue(source, 0)
+
+	for q.Len() > 0 {
+		vertex, _ := q.Dequeue()
+		settled[vertex] = true
+
+		for adjacency, weight := range outEdges(vertex) {
+			if weight < 0 {
+				return nil, fmt.Errorf("%w: edge from %v to %v has weight %v", ErrNegativeWeight, vertex, adjacency, weight)
+			}
+
+			if settled[adjacency] {
+				continue
+			}
+
+			distance := tree.distances[vertex] + weight
+			current, reached := tree.distances[adjacency]
+
+			switch {
+			case !reached:
+				tree.distances[adjacency] = distance
+				tree.predecessors[adjacency] = vertex
+				q.Enqueue(adjacency, distance)
+			case distance < current:
+				tree.distances[adjacency] = distance
+				tree.predecessors[adjacency] = vertex
+				q.SetPriority(adjacency, distance)
+			}
+		}
+	}
+
+	return tree, nil
+}
+
+// Source returns the source vertex of the tree.
+func (t *ShortestPathTree[K]) Source() K {
+	return t.source
+}
+
+// DistTo returns the total weight of the shortest path from the source to the
+// vertex, or +Inf if the vertex cannot be reached.
+func (t *ShortestPathTree[K]) DistTo(hash K) float64 {
+	distance, ok := t.distances[hash]
+	if !ok {
+		return math.Inf(1)
+	}
+
+	return distance
+}
+
+// HasPathTo reports whether the vertex can be reached from the source.
+func (t *ShortestPathTree[K]) HasPathTo(hash K) bool {
+	_, ok := t.distances[hash]
+	return ok
+}
+
+// PathTo returns the vertices of the shortest path from the source to the
+// vertex, including both, or nil if the vertex cannot be reached.
+func (t *ShortestPathTree[K]) PathTo(hash K) []K {
+	if !t.HasPathTo(hash) {
+		return nil
+	}
+
+	path := []K{hash}
+	for current := hash; current != t.source; {
+		current = t.predecessors[current]
+		path = append(path, current)
+	}
+	slices.Reverse(path)
+
+	return path
+}
+
+// Predecessors returns the predecessor of every reached vertex other than
+// the source. The map is a copy and may be modified.
+func (t *ShortestPathTree[K]) Predecessors() map[K]K {
+	return maps.Clone(t.predecessors)
+}
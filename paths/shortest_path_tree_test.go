@@ -0,0 +1,107 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package paths
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortestPathTreeFrom(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Finds the paths to every vertex", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+		for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+			is.NoError(g.AddVertexWithOptions(v))
+		}
+		is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(7)))
+		is.NoError(g.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+		is.NoError(g.AddEdgeWithOptions("C", "B", simple.EdgeWeight(3)))
+		is.NoError(g.AddEdgeWithOptions("B", "D", simple.EdgeWeight(1)))
+		is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(8)))
+		is.NoError(g.AddEdgeWithOptions("F", "A", simple.EdgeWeight(1)))
+
+		tree, err := ShortestPathTreeFrom(g, "A")
+		is.NoError(err)
+		is.Equal("A", tree.Source())
+
+		is.Equal(0.0, tree.DistTo("A"))
+		is.Equal(5.0, tree.DistTo("B"))
+		is.Equal(6.0, tree.DistTo("D"))
+		is.Equal([]string{"A", "C", "B", "D"}, tree.PathTo("D"))
+		is.Equal([]string{"A"}, tree.PathTo("A"))
+
+		// Unreachable vertices have no path.
+		is.False(tree.HasPathTo("E"))
+		is.False(tree.HasPathTo("F"))
+		is.True(math.IsInf(tree.DistTo("E"), 1))
+		is.Nil(tree.PathTo("F"))
+
+		predecessors := tree.Predecessors()
+		is.Equal(map[string]string{"B": "C", "C": "A", "D": "B"}, predecessors)
+		delete(predecessors, "D")
+		is.Equal([]string{"A", "C", "B", "D"}, tree.PathTo("D"))
+	})
+
+	t.Run("Counts edges of unweighted graphs", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.IntHash)
+		for i := 1; i <= 5; i++ {
+			is.NoError(g.AddVertexWithOptions(i))
+		}
+		is.NoError(g.AddEdgeWithOptions(1, 2, simple.EdgeWeight(10)))
+		is.NoError(g.AddEdgeWithOptions(2, 3))
+		is.NoError(g.AddEdgeWithOptions(3, 4))
+		is.NoError(g.AddEdgeWithOptions(4, 5))
+		is.NoError(g.AddEdgeWithOptions(5, 1))
+
+		tree, err := ShortestPathTreeFrom(g, 1)
+		is.NoError(err)
+		is.Equal(1.0, tree.DistTo(2))
+		is.Equal(2.0, tree.DistTo(3))
+		is.Equal(2.0, tree.DistTo(4))
+		is.Equal([]int{1, 5, 4}, tree.PathTo(4))
+	})
+
+	t.Run("Rejects negative weights", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.IntHash, graph.Directed(), graph.Weighted())
+		is.NoError(g.AddVertexWithOptions(1))
+		is.NoError(g.AddVertexWithOptions(2))
+		is.NoError(g.AddEdgeWithOptions(1, 2, simple.EdgeWeight(-1)))
+
+		_, err := ShortestPathTreeFrom(g, 1)
+		is.ErrorIs(err, ErrNegativeWeight)
+
+		// Edges that cannot be reached are not used.
+		_, err = ShortestPathTreeFrom(g, 2)
+		is.NoError(err)
+	})
+
+	t.Run("Returns error for missing source", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		g, _ := simple.New(graph.IntHash, graph.Directed())
+		_, err := ShortestPathTreeFrom(g, 1)
+		is.ErrorIs(err, graph.ErrVertexNotFound)
+
+		_, err = ShortestPathTreeFrom[int, int](nil, 1)
+		is.ErrorIs(err, graph.ErrNilInputGraph)
+	})
+}
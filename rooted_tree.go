@@ -0,0 +1,63 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package graph
+
+// RootedTree is implemented by graphs created with the Rooted or Tree trait.
+// Such graphs are kept a tree grown from a single root:
+//
+//   - The first vertex added becomes the root.
+//   - An edge must connect a vertex of the tree to a vertex that is not yet
+//     part of it, which becomes its child. In a directed tree edges point
+//     from parent to child. Otherwise AddEdge returns ErrVertexNotInTree,
+//     ErrVertexHasParent or ErrEdgeCreatesCycle.
+//   - Only the edge to a leaf can be removed; removing any other edge returns
+//     ErrEdgeDisconnectsTree.
+//
+// A vertex that has been added but not yet connected is outside the tree
+// until an edge attaches it.
+//
+// Example:
+//
+//	g, _ := simple.New(graph.StringHash, graph.Directed(), graph.Tree())
+//	_ = g.AddVertexWithOptions("CEO")
+//	_ = g.AddVertexWithOptions("CTO")
+//	_ = g.AddEdgeWithOptions("CEO", "CTO")
+//
+//	t := g.(graph.RootedTree[string, string])
+//	depth, _ := t.Depth("CTO") // 1
+type RootedTree[K Ordered, T any] interface {
+	Interface[K, T]
+
+	// Root returns the root of the tree, or ErrTreeHasNoRoot if the tree has
+	// no vertices.
+	Root() (K, error)
+
+	// Parent returns the parent of the vertex. It returns ErrVertexIsRoot for
+	// the root.
+	Parent(hash K) (K, error)
+
+	// Children returns the children of the vertex in key order.
+	Children(hash K) ([]K, error)
+
+	// Ancestors returns the ancestors of the vertex, from its parent up to the
+	// root.
+	Ancestors(hash K) ([]K, error)
+
+	// Descendants returns the descendants of the vertex in breadth-first
+	// order, children in key order.
+	Descendants(hash K) ([]K, error)
+
+	// Depth returns the number of edges between the root and the vertex.
+	Depth(hash K) (int, error)
+
+	// Subtree returns a new tree rooted at the vertex, holding the vertex, its
+	// descendants and copies of the edges between them.
+	Subtree(hash K) (RootedTree[K, T], error)
+
+	// Reroot makes the vertex the root of the tree. In a directed tree the
+	// edges on the path between the old and the new root are reversed.
+	Reroot(hash K) error
+}
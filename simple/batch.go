@@ -0,0 +1,450 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
+)
+
+// Transactional is an optional interface for a Store that can run several
+// operations under a single acquisition of its lock. Graphs use it to make a
+// Batch atomic with respect to other users of the store; without it, the
+// changes of a batch are still rolled back on failure but may be observed
+// while the batch runs.
+type Transactional[K comparable, T any] interface {
+	// Transact calls fn with a view of the store that performs operations
+	// without further locking. Other operations on the store wait until fn
+	// returns. The view must not be used after fn returns. If fn returns an
+	// error, the store must discard any state that it keeps for the
+	// operations of fn, such as records not yet written.
+	//
+	// Parameters:
+	//   - fn: The function performing the operations.
+	//
+	// Returns:
+	//   - The error returned by fn, or an error if the operations could not be completed.
+	Transact(fn func(view Store[K, T]) error) error
+}
+
+// Batch runs fn against a transaction and commits or rolls back its changes
+// as a unit. See graph.Batcher.
+func (d *directedGraph[K, T]) Batch(fn func(tx graph.Interface[K, T]) error) error {
+	return runBatch(d.store, d.traits, d.events, func(store Store[K, T], traits *graph.Traits, events *observers[K]) (graph.Interface[K, T], error) {
+		g, err := newDirectedGraph(d.hash, traits, store)
+		if err != nil {
+			return nil, err
+		}
+		g.events = events
+		return g, nil
+	}, fn)
+}
+
+// Batch runs fn against a transaction and commits or rolls back its changes
+// as a unit. See graph.Batcher.
+func (u *undirected[K, T]) Batch(fn func(tx graph.Interface[K, T]) error) error {
+	return runBatch(u.store, u.traits, u.events, func(store Store[K, T], traits *graph.Traits, events *observers[K]) (graph.Interface[K, T], error) {
+		g, err := newUndirected(u.hash, traits, store)
+		if err != nil {
+			return nil, err
+		}
+		g.events = events
+		return g, nil
+	}, fn)
+}
+
+// runBatch runs fn against a graph created by newGraph over a transaction
+// store, which records how to undo each change. The cycle check is deferred
+// to the end of the batch. If fn or the check fails, the changes are undone;
+// otherwise the events of the batch are published once it is committed.
+// Other changes to the graph wait until the batch is done, so its events are
+// not interleaved with theirs.
+func runBatch[K graph.Ordered, T any](
+	store Store[K, T],
+	traits *graph.Traits,
+	events *observers[K],
+	newGraph func(Store[K, T], *graph.Traits, *observers[K]) (graph.Interface[K, T], error),
+	fn func(tx graph.Interface[K, T]) error,
+) error {
+	if events.parent == nil {
+		events.changes.Lock()
+		defer events.changes.Unlock()
+	}
+
+	batch := events.batch()
+
+	run := func(view Store[K, T]) error {
+		tx := &txStore[K, T]{Store: view}
+
+		txTraits := traits.Clone()
+		txTraits.PreventCycles = false
+
+		g, err := newGraph(tx, txTraits, batch)
+		if err != nil {
+			return err
+		}
+
+		err = fn(g)
+		if err == nil && traits.PreventCycles && tx.addedEdges {
+			var cyclic bool
+			if cyclic, err = hasCycle(g); err == nil && cyclic {
+				err = graph.ErrEdgeCreatesCycle
+			}
+		}
+
+		if err != nil {
+			if rollbackErr := tx.rollback(); rollbackErr != nil {
+				return errors.Join(err, fmt.Errorf("rollback: %w", rollbackErr))
+			}
+			return err
+		}
+
+		return nil
+	}
+
+	var err error
+	if t, ok := store.(Transactional[K, T]); ok {
+		err = t.Transact(run)
+	} else {
+		err = run(store)
+	}
+	if err != nil {
+		return err
+	}
+
+	events.publish(batch.pending...)
+	return nil
+}
+
+// hasCycle reports whether g contains a cycle. A directed graph is checked by
+// removing vertices without incoming edges until none are left; an undirected
+// graph by merging the vertices connected by each edge.
+func hasCycle[K graph.Ordered, T any](g graph.Interface[K, T]) (bool, error) {
+	edges, err := g.Edges()
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", graph.ErrFailedToGetEdges, err)
+	}
+
+	if !g.Traits().IsDirected {
+		parent := make(map[K]K)
+		var find func(K) K
+		find = func(v K) K {
+			p, ok := parent[v]
+			if !ok || p == v {
+				return v
+			}
+			root := find(p)
+			parent[v] = root
+			return root
+		}
+
+		for _, edge := range edges {
+			a, b := find(edge.Source()), find(edge.Target())
+			if a == b {
+				return true, nil
+			}
+			parent[a] = b
+		}
+
+		return false, nil
+	}
+
+	inDegree := make(map[K]int)
+	successors := make(map[K][]K)
+	for _, edge := range edges {
+		inDegree[edge.Target()]++
+		successors[edge.Source()] = append(successors[edge.Source()], edge.Target())
+	}
+
+	vertices, err := g.Vertices()
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	ready := make([]K, 0, len(vertices))
+	for _, vertex := range vertices {
+		if inDegree[vertex.ID()] == 0 {
+			ready = append(ready, vertex.ID())
+		}
+	}
+
+	removed := 0
+	for len(ready) > 0 {
+		v := ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		removed++
+
+		for _, w := range successors[v] {
+			if inDegree[w]--; inDegree[w] == 0 {
+				ready = append(ready, w)
+			}
+		}
+	}
+
+	return removed < len(vertices), nil
+}
+
+// txStore is a Store that records how to undo every change made through it.
+type txStore[K comparable, T any] struct {
+	Store[K, T]
+
+	// undo holds the inverse of each change, in the order of the changes.
+	undo []func() error
+
+	// addedEdges is set once an edge has been added.
+	addedEdges bool
+}
+
+// rollback undoes the changes in reverse order. It continues past failures
+// and returns them joined.
+func (tx *txStore[K, T]) rollback() error {
+	var errs []error
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		if err := tx.undo[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	tx.undo = nil
+	return errors.Join(errs...)
+}
+
+func (tx *txStore[K, T]) AddVertex(key K, value T, properties graph.VertexProperties) error {
+	if err := tx.Store.AddVertex(key, value, properties); err != nil {
+		return err
+	}
+
+	tx.undo = append(tx.undo, func() error {
+		return tx.Store.RemoveVertex(key)
+	})
+	return nil
+}
+
+func (tx *txStore[K, T]) ModifyVertex(key K, properties graph.VertexProperties) error {
+	_, previous, err := tx.Store.FindVertex(key)
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Store.ModifyVertex(key, properties); err != nil {
+		return err
+	}
+
+	tx.undo = append(tx.undo, func() error {
+		return tx.Store.ModifyVertex(key, previous)
+	})
+	return nil
+}
+
+func (tx *txStore[K, T]) RemoveVertex(key K) error {
+	value, properties, err := tx.Store.FindVertex(key)
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Store.RemoveVertex(key); err != nil {
+		return err
+	}
+
+	tx.undo = append(tx.undo, func() error {
+		return tx.Store.AddVertex(key, value, properties)
+	})
+	return nil
+}
+
+// AddEdge adds the edge, assigning it an ID first if it has none so that it
+// can be removed again by ID.
+func (tx *txStore[K, T]) AddEdge(source, target K, edge graph.Edge[K]) error {
+	id := edgeutil.ID(edge)
+	if id == 0 {
+		id = tx.Store.NextEdgeID()
+		edge = newEdgeWithID(source, target, edge.Properties(), id)
+	}
+
+	if err := tx.Store.AddEdge(source, target, edge); err != nil {
+		return err
+	}
+
+	tx.addedEdges = true
+	tx.undo = append(tx.undo, func() error {
+		// The undirected pair of an edge shares its ID, so the other
+		// direction may already be gone.
+		if err := tx.Store.RemoveEdgeByID(id); err != nil && !errors.Is(err, graph.ErrEdgeNotFound) {
+			return err
+		}
+		return nil
+	})
+	return nil
+}
+
+func (tx *txStore[K, T]) ModifyEdge(source, target K, edge graph.Edge[K]) error {
+	edges, err := tx.Store.FindEdges(source, target)
+	if err != nil {
+		return err
+	}
+
+	var previous graph.Edge[K]
+	for _, e := range edges {
+		if id := edgeutil.ID(edge); id == 0 || id == edgeutil.ID(e) {
+			previous = e
+			break
+		}
+	}
+	if previous == nil {
+		return graph.ErrEdgeNotFound
+	}
+
+	if err = tx.Store.ModifyEdge(source, target, edge); err != nil {
+		return err
+	}
+
+	tx.undo = append(tx.undo, func() error {
+		return tx.Store.ModifyEdge(source, target, previous)
+	})
+	return nil
+}
+
+func (tx *txStore[K, T]) RemoveEdge(source, target K) error {
+	edges, err := tx.Store.FindEdges(source, target)
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Store.RemoveEdge(source, target); err != nil {
+		return err
+	}
+
+	tx.undo = append(tx.undo, tx.restoreEdges(edges))
+	return nil
+}
+
+func (tx *txStore[K, T]) RemoveEdgeByID(id graph.EdgeID) error {
+	edge, err := tx.Store.FindEdgeByID(id)
+	if err != nil {
+		return err
+	}
+
+	// An undirected edge is also stored in the reverse direction.
+	removed := []graph.Edge[K]{edge}
+	if edge.Source() != edge.Target() {
+		reverse, err := tx.Store.FindEdges(edge.Target(), edge.Source())
+		if err != nil {
+			return err
+		}
+		if i := indexOfEdge(reverse, id); i >= 0 {
+			removed = append(removed, reverse[i])
+		}
+	}
+
+	if err = tx.Store.RemoveEdgeByID(id); err != nil {
+		return err
+	}
+
+	tx.undo = append(tx.undo, tx.restoreEdges(removed))
+	return nil
+}
+
+// restoreEdges returns an undo function that adds the edges back with their
+// IDs.
+func (tx *txStore[K, T]) restoreEdges(edges []graph.Edge[K]) func() error {
+	return func() error {
+		for _, edge := range edges {
+			if err := tx.Store.AddEdge(edge.Source(), edge.Target(), edge); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Transact runs fn with the store locked once for all of its operations.
+func (ms *memoryLedger[K, T]) Transact(fn func(view Store[K, T]) error) error {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	return fn(&memoryView[K, T]{ms: ms})
+}
+
+// memoryView is the Store passed to the function of memoryLedger.Transact.
+// It uses the ledger without locking, as the lock is already held.
+type memoryView[K graph.Ordered, T any] struct {
+	ms *memoryLedger[K, T]
+}
+
+func (v *memoryView[K, T]) New() (Store[K, T], error) {
+	return v.ms.New()
+}
+
+func (v *memoryView[K, T]) AddVertex(key K, value T, properties graph.VertexProperties) error {
+	return v.ms.addVertex(key, value, properties)
+}
+
+func (v *memoryView[K, T]) FindVertex(key K) (T, graph.VertexProperties, error) {
+	return v.ms.findVertex(key)
+}
+
+func (v *memoryView[K, T]) ModifyVertex(key K, properties graph.VertexProperties) error {
+	return v.ms.modifyVertex(key, properties)
+}
+
+func (v *memoryView[K, T]) RemoveVertex(key K) error {
+	return v.ms.removeVertex(key)
+}
+
+func (v *memoryView[K, T]) ListVertices() ([]K, error) {
+	return v.ms.listVertices()
+}
+
+func (v *memoryView[K, T]) CountVertices() (int, error) {
+	return v.ms.countVertices()
+}
+
+func (v *memoryView[K, T]) AddEdge(source, target K, edge graph.Edge[K]) error {
+	return v.ms.addEdge(source, target, edge)
+}
+
+func (v *memoryView[K, T]) FindEdge(source, target K) (graph.Edge[K], error) {
+	return v.ms.findEdge(source, target)
+}
+
+func (v *memoryView[K, T]) ModifyEdge(source, target K, edge graph.Edge[K]) error {
+	return v.ms.modifyEdge(source, target, edge)
+}
+
+func (v *memoryView[K, T]) RemoveEdge(source, target K) error {
+	return v.ms.removeEdge(source, target)
+}
+
+func (v *memoryView[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	return v.ms.listEdges()
+}
+
+func (v *memoryView[K, T]) CountEdges() (int, error) {
+	return v.ms.countEdges()
+}
+
+func (v *memoryView[K, T]) NextEdgeID() graph.EdgeID {
+	return v.ms.nextEdgeID()
+}
+
+func (v *memoryView[K, T]) FindEdges(source, target K) ([]graph.Edge[K], error) {
+	return v.ms.findEdges(source, target)
+}
+
+func (v *memoryView[K, T]) FindEdgeByID(id graph.EdgeID) (graph.Edge[K], error) {
+	return v.ms.findEdgeByID(id)
+}
+
+func (v *memoryView[K, T]) RemoveEdgeByID(id graph.EdgeID) error {
+	return v.ms.removeEdgeByID(id)
+}
+
+func (v *memoryView[K, T]) WouldCreateCycle(source, target K) (bool, error) {
+	return v.ms.wouldCreateCycle(source, target)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch_Commit(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.IntHash, graph.Directed(), graph.PreventCycles())
+	is.NoError(err)
+
+	err = g.(graph.Batcher[int, int]).Batch(func(tx graph.Interface[int, int]) error {
+		for i := 1; i <= 3; i++ {
+			if err := tx.AddVertexWithOptions(i); err != nil {
+				return err
+			}
+		}
+		if err := tx.AddEdgeWithOptions(1, 2); err != nil {
+			return err
+		}
+		return tx.AddEdgeWithOptions(2, 3)
+	})
+	is.NoError(err)
+
+	order, _ := g.Order()
+	is.Equal(3, order)
+	size, _ := g.Size()
+	is.Equal(2, size)
+
+	// Cycle prevention still applies outside of a batch.
+	is.ErrorIs(g.AddEdgeWithOptions(3, 1), graph.ErrEdgeCreatesCycle)
+}
+
+func TestBatch_Rollback(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.StringHash, graph.Directed(), graph.Weighted())
+	is.NoError(err)
+	is.NoError(g.AddVertexWithOptions("A", VertexWeight(1)))
+	is.NoError(g.AddVertexWithOptions("B"))
+	is.NoError(g.AddVertexWithOptions("C"))
+	is.NoError(g.AddEdgeWithOptions("A", "B", EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "C", EdgeWeight(3)))
+
+	failure := errors.New("failure")
+	err = g.(graph.Batcher[string, string]).Batch(func(tx graph.Interface[string, string]) error {
+		is.NoError(tx.SetVertexWithOptions("A", VertexWeight(10)))
+		is.NoError(tx.SetEdgeWithOptions("A", "B", EdgeWeight(20)))
+		is.NoError(tx.RemoveEdge("B", "C"))
+		is.NoError(tx.RemoveVertex("C"))
+		is.NoError(tx.AddVertexWithOptions("D"))
+		is.NoError(tx.AddEdgeWithOptions("D", "A"))
+		return failure
+	})
+	is.ErrorIs(err, failure)
+
+	order, _ := g.Order()
+	is.Equal(3, order)
+	size, _ := g.Size()
+	is.Equal(2, size)
+
+	v, _ := g.Vertex("A")
+	is.Equal(float64(1), v.Properties().Weight())
+	e, _ := g.Edge("A", "B")
+	is.Equal(float64(2), e.Properties().Weight())
+	e, err = g.Edge("B", "C")
+	is.NoError(err)
+	is.Equal(float64(3), e.Properties().Weight())
+	ok, _ := g.HasVertex("D")
+	is.False(ok)
+}
+
+func TestBatch_DeferredCycleCheck(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.IntHash, graph.Directed(), graph.PreventCycles())
+	is.NoError(err)
+	for i := 1; i <= 3; i++ {
+		is.NoError(g.AddVertexWithOptions(i))
+	}
+	is.NoError(g.AddEdgeWithOptions(1, 2))
+	is.NoError(g.AddEdgeWithOptions(2, 3))
+
+	batcher := g.(graph.Batcher[int, int])
+
+	// Reversing a path passes through a cyclic state but ends acyclic.
+	is.NoError(batcher.Batch(func(tx graph.Interface[int, int]) error {
+		for _, step := range [][2]int{{3, 2}, {2, 1}} {
+			if err := tx.AddEdgeWithOptions(step[0], step[1]); err != nil {
+				return err
+			}
+			if err := tx.RemoveEdge(step[1], step[0]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	ok, _ := g.HasEdge(3, 2)
+	is.True(ok)
+	ok, _ = g.HasEdge(1, 2)
+	is.False(ok)
+
+	// A batch that ends with a cycle is rolled back.
+	err = batcher.Batch(func(tx graph.Interface[int, int]) error {
+		return tx.AddEdgeWithOptions(1, 3)
+	})
+	is.ErrorIs(err, graph.ErrEdgeCreatesCycle)
+	size, _ := g.Size()
+	is.Equal(2, size)
+
+	u, err := New(graph.IntHash, graph.PreventCycles())
+	is.NoError(err)
+	err = u.(graph.Batcher[int, int]).Batch(func(tx graph.Interface[int, int]) error {
+		for i := 1; i <= 3; i++ {
+			if err := tx.AddVertexWithOptions(i); err != nil {
+				return err
+			}
+		}
+		for _, e := range [][2]int{{1, 2}, {2, 3}, {3, 1}} {
+			if err := tx.AddEdgeWithOptions(e[0], e[1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	is.ErrorIs(err, graph.ErrEdgeCreatesCycle)
+	order, _ := u.Order()
+	is.Zero(order)
+}
+
+func TestBatch_Store(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	// A store that is not Transactional is still rolled back.
+	inner, err := NewMemoryStore[int, int]()
+	is.NoError(err)
+	store := &countingStore[int, int]{Store: inner}
+	g, err := NewWithStore(graph.IntHash, store, graph.MultiGraph())
+	is.NoError(err)
+	is.NoError(g.AddVertexWithOptions(1))
+	is.NoError(g.AddVertexWithOptions(2))
+	is.NoError(g.AddEdgeWithOptions(1, 2))
+
+	err = g.(graph.Batcher[int, int]).Batch(func(tx graph.Interface[int, int]) error {
+		is.NoError(tx.AddEdgeWithOptions(1, 2))
+		is.NoError(tx.RemoveEdge(2, 1))
+		return errors.New("failure")
+	})
+	is.Error(err)
+	parallel, _ := g.(graph.Multigraph[int, int]).EdgesBetween(1, 2)
+	is.Len(parallel, 1)
+
+	// The changes of a batch on a file store are logged together.
+	dir := t.TempDir()
+	fs, fg := openFileGraph(t, dir)
+	batcher := fg.(graph.Batcher[string, string])
+	is.NoError(batcher.Batch(func(tx graph.Interface[string, string]) error {
+		is.NoError(tx.AddVertexWithOptions("A"))
+		is.NoError(tx.AddVertexWithOptions("B"))
+		return tx.AddEdgeWithOptions("A", "B")
+	}))
+	is.Error(batcher.Batch(func(tx graph.Interface[string, string]) error {
+		is.NoError(tx.AddVertexWithOptions("C"))
+		return errors.New("failure")
+	}))
+	is.NoError(fs.Close())
+
+	fs, fg = openFileGraph(t, dir)
+	defer fs.Close()
+	order, _ := fg.Order()
+	is.Equal(2, order)
+	ok, _ := fg.HasEdge("A", "B")
+	is.True(ok)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"errors"
+
+	"github.com/sixafter/graph"
+)
+
+// ErrBuilderFinished is returned when a Builder is used after Build.
+var ErrBuilderFinished = errors.New("builder has already built its graph")
+
+// Builder assembles a graph in bulk, for loaders that add millions of
+// vertices and edges from a trusted source. It writes straight into a fresh
+// in-memory ledger without locking and without the per-edge cycle check of
+// AddEdge, so PreventCycles and Acyclic are not enforced while building.
+// Missing vertices and duplicate edges are still rejected.
+//
+// A Builder is not safe for concurrent use and can build a single graph.
+//
+// Example:
+//
+//	b := simple.NewBuilder(graph.IntHash, graph.Directed())
+//	b.AddVertexWithOptions(1)
+//	b.AddVertexWithOptions(2)
+//	if err := b.AddEdgeWithOptions(1, 2, simple.EdgeWeight(3)); err != nil {
+//		log.Fatal(err)
+//	}
+//	g, err := b.Build()
+type Builder[K graph.Ordered, T any] struct {
+	hash   graph.Hash[K, T]
+	traits graph.Traits
+	store  *memoryLedger[K, T]
+}
+
+// NewBuilder creates a Builder for a graph with the given hash function and
+// traits.
+func NewBuilder[K graph.Ordered, T any](hash graph.Hash[K, T], options ...func(*graph.Traits)) *Builder[K, T] {
+	b := &Builder[K, T]{hash: hash}
+
+	for _, option := range options {
+		option(&b.traits)
+	}
+
+	s, _ := NewMemoryStore[K, T]()
+	b.store = s.(*memoryLedger[K, T])
+
+	return b
+}
+
+// AddVertexWithOptions adds a vertex and returns its key. If a vertex with the
+// same key exists, it is replaced.
+func (b *Builder[K, T]) AddVertexWithOptions(value T, options ...graph.VertexOption) K {
+	hash := b.hash(value)
+	if b.store == nil {
+		return hash
+	}
+
+	properties := &VertexProperties{
+		v: make(map[string]any),
+	}
+	for _, option := range options {
+		option(properties)
+	}
+
+	b.store.vertices.Set(b.store.owner, hash, storedVertex[T]{value: value, properties: properties})
+
+	return hash
+}
+
+// AddEdgeWithOptions adds an edge between two existing vertices. For
+// undirected graphs the reverse direction is added as well. It returns
+// ErrVertexNotFound if either vertex is missing and, unless the graph is a
+// multigraph, ErrEdgeAlreadyExists if the edge exists.
+func (b *Builder[K, T]) AddEdgeWithOptions(source, target K, options ...graph.EdgeOption) error {
+	if b.store == nil {
+		return ErrBuilderFinished
+	}
+
+	if _, ok := b.store.vertices.Get(source); !ok {
+		return graph.ErrVertexNotFound
+	}
+	if _, ok := b.store.vertices.Get(target); !ok {
+		return graph.ErrVertexNotFound
+	}
+	if !b.traits.IsMultiGraph && len(b.store.edgesBetween(source, target)) > 0 {
+		return graph.ErrEdgeAlreadyExists
+	}
+
+	edge := NewEdgeWithOptions(source, target, func(p graph.EdgeProperties) {
+		for _, option := range options {
+			option(p)
+		}
+	})
+
+	b.store.lastEdgeID++
+	id := b.store.lastEdgeID
+
+	b.store.insertEdge(source, target, newEdgeWithID(source, target, edge.Properties(), id))
+	if !b.traits.IsDirected {
+		b.store.insertEdge(target, source, newEdgeWithID(target, source, edge.Properties(), id))
+	}
+
+	return nil
+}
+
+// Build returns the assembled graph. The Builder cannot be used afterwards.
+func (b *Builder[K, T]) Build() (graph.Interface[K, T], error) {
+	if b.store == nil {
+		return nil, ErrBuilderFinished
+	}
+
+	store := b.store
+	b.store = nil
+
+	traits := b.traits
+	return NewWithStore(b.hash, store, func(t *graph.Traits) {
+		*t = traits
+	})
+}
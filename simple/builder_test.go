@@ -0,0 +1,61 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	for _, directed := range []bool{true, false} {
+		is := assert.New(t)
+
+		var options []func(*graph.Traits)
+		if directed {
+			options = append(options, graph.Directed())
+		}
+
+		b := NewBuilder(graph.IntHash, options...)
+		is.Equal(1, b.AddVertexWithOptions(1, VertexWeight(2)))
+		b.AddVertexWithOptions(2)
+		b.AddVertexWithOptions(3)
+		is.NoError(b.AddEdgeWithOptions(1, 2, EdgeWeight(5)))
+		is.NoError(b.AddEdgeWithOptions(2, 3))
+		is.ErrorIs(b.AddEdgeWithOptions(1, 2), graph.ErrEdgeAlreadyExists)
+		is.ErrorIs(b.AddEdgeWithOptions(1, 4), graph.ErrVertexNotFound)
+
+		g, err := b.Build()
+		is.NoError(err)
+		is.Equal(directed, g.Traits().IsDirected)
+
+		order, _ := g.Order()
+		is.Equal(3, order)
+		size, _ := g.Size()
+		is.Equal(2, size)
+
+		v, _ := g.Vertex(1)
+		is.Equal(float64(2), v.Properties().Weight())
+
+		e, err := g.Edge(1, 2)
+		is.NoError(err)
+		is.Equal(float64(5), e.Properties().Weight())
+
+		ok, _ := g.HasEdge(2, 1)
+		is.Equal(!directed, ok)
+
+		// The built graph behaves like any other.
+		is.NoError(g.AddEdgeWithOptions(3, 1))
+
+		_, err = b.Build()
+		is.ErrorIs(err, ErrBuilderFinished)
+		is.ErrorIs(b.AddEdgeWithOptions(1, 3), ErrBuilderFinished)
+	}
+}
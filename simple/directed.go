@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
 	"github.com/sixafter/graph/internal/paths"
 )
 
@@ -24,7 +25,8 @@ import (
 type directedGraph[K graph.Ordered, T any] struct {
 	hash   graph.Hash[K, T]
 	traits *graph.Traits
-	store  ledger[K, T]
+	store  Store[K, T]
+	events *observers[K]
 }
 
 // newDirectedGraph creates a new directedGraph graph with the given hash function, traits, and
@@ -32,19 +34,20 @@ type directedGraph[K graph.Ordered, T any] struct {
 // Parameters:
 //   - hash: A function that computes a unique hash for vertices.
 //   - traits: Interface traits that define properties such as directedness and cycle prevention.
-//   - ledger: A memory ledger backend for the graph.
+//   - store: The store backend for the graph.
 //
 // Returns:
 //   - A pointer to a new directedGraph graph instance.
 //
 // Example:
 //
-//	graph := newDirectedGraph(IntHash, &Traits{IsDirected: true}, newMemoryStore())
-func newDirectedGraph[K graph.Ordered, T any](hash graph.Hash[K, T], traits *graph.Traits, store ledger[K, T]) (*directedGraph[K, T], error) {
+//	graph := newDirectedGraph(IntHash, &Traits{IsDirected: true}, NewMemoryStore[int, int]())
+func newDirectedGraph[K graph.Ordered, T any](hash graph.Hash[K, T], traits *graph.Traits, store Store[K, T]) (*directedGraph[K, T], error) {
 	return &directedGraph[K, T]{
 		hash:   hash,
 		traits: traits,
 		store:  store,
+		events: &observers[K]{},
 	}, nil
 }
 
@@ -54,7 +57,11 @@ func (d *directedGraph[K, T]) Traits() *graph.Traits {
 
 func (d *directedGraph[K, T]) AddVertex(vertex graph.Vertex[K, T]) error {
 	hash := d.hash(vertex.Value())
-	return d.store.AddVertex(hash, vertex.Value(), vertex.Properties())
+	event := graph.Event[K]{Type: graph.VertexAdded, Vertex: hash, VertexAfter: vertex.Properties()}
+
+	return d.events.change(func() error {
+		return d.store.AddVertex(hash, vertex.Value(), vertex.Properties())
+	}, event)
 }
 
 func (d *directedGraph[K, T]) AddVertexWithOptions(value T, options ...graph.VertexOption) error {
@@ -100,21 +107,35 @@ func (d *directedGraph[K, T]) Vertex(hash K) (graph.Vertex[K, T], error) {
 
 func (d *directedGraph[K, T]) SetVertexWithOptions(value T, options ...graph.VertexOption) error {
 	hash := d.hash(value)
-	_, props, err := d.store.FindVertex(hash)
-	if err != nil {
-		return err
-	}
 
-	for _, option := range options {
-		p, ok := props.(*VertexProperties)
-		if !ok {
-			return fmt.Errorf("failed to modify vertex: %T", props)
+	var props graph.VertexProperties
+	describe := func() ([]graph.Event[K], error) {
+		_, before, err := d.store.FindVertex(hash)
+		if err != nil {
+			return nil, err
 		}
+		props = before
+
+		// Options are applied to a copy, leaving the stored properties to the store.
+		if p, ok := props.(*VertexProperties); ok {
+			props = p.Clone()
+		}
+
+		for _, option := range options {
+			p, ok := props.(*VertexProperties)
+			if !ok {
+				return nil, fmt.Errorf("failed to modify vertex: %T", props)
+			}
 
-		option(p)
+			option(p)
+		}
+
+		return []graph.Event[K]{{Type: graph.VertexModified, Vertex: hash, VertexBefore: before, VertexAfter: props}}, nil
 	}
 
-	return d.store.ModifyVertex(hash, props)
+	return d.events.update(describe, func() error {
+		return d.store.ModifyVertex(hash, props)
+	})
 }
 
 func (d *directedGraph[K, T]) HasVertex(hash K) (bool, error) {
@@ -127,38 +148,67 @@ func (d *directedGraph[K, T]) HasVertex(hash K) (bool, error) {
 }
 
 func (d *directedGraph[K, T]) RemoveVertex(hash K) error {
-	return d.store.RemoveVertex(hash)
+	describe := func() ([]graph.Event[K], error) {
+		_, props, err := d.store.FindVertex(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		return []graph.Event[K]{{Type: graph.VertexRemoved, Vertex: hash, VertexBefore: props}}, nil
+	}
+
+	return d.events.update(describe, func() error {
+		return d.store.RemoveVertex(hash)
+	})
 }
 
 func (d *directedGraph[K, T]) AddEdge(edge graph.Edge[K]) error {
+	_, err := d.addEdge(edge)
+	return err
+}
+
+// addEdge validates and stores edge under a new ID, which it returns.
+func (d *directedGraph[K, T]) addEdge(edge graph.Edge[K]) (graph.EdgeID, error) {
 	source := edge.Source()
 	_, _, err := d.store.FindVertex(source)
 	if err != nil {
-		return fmt.Errorf("%w: %v", graph.ErrVertexNotFound, source)
+		return 0, fmt.Errorf("%w: %v", graph.ErrVertexNotFound, source)
 	}
 
 	target := edge.Target()
 	_, _, err = d.store.FindVertex(target)
 	if err != nil {
-		return fmt.Errorf("%w: %v", graph.ErrVertexNotFound, target)
+		return 0, fmt.Errorf("%w: %v", graph.ErrVertexNotFound, target)
 	}
 
-	if _, err = d.Edge(source, target); !errors.Is(err, graph.ErrEdgeNotFound) {
-		return graph.ErrEdgeAlreadyExists
+	if !d.traits.IsMultiGraph {
+		if _, err = d.Edge(source, target); !errors.Is(err, graph.ErrEdgeNotFound) {
+			return 0, graph.ErrEdgeAlreadyExists
+		}
 	}
 
 	if d.traits.PreventCycles {
 		var createsCycle bool
 		createsCycle, err = d.wouldCreateCycle(source, target)
 		if err != nil {
-			return fmt.Errorf("%w: %v", graph.ErrEdgeCreatesCycle, err)
+			return 0, fmt.Errorf("%w: %v", graph.ErrEdgeCreatesCycle, err)
 		}
 		if createsCycle {
-			return graph.ErrEdgeCreatesCycle
+			return 0, graph.ErrEdgeCreatesCycle
 		}
 	}
 
-	return d.store.AddEdge(source, target, edge)
+	id := d.store.NextEdgeID()
+	event := graph.Event[K]{Type: graph.EdgeAdded, Source: source, Target: target, EdgeID: id, EdgeAfter: edge.Properties()}
+
+	err = d.events.change(func() error {
+		return d.store.AddEdge(source, target, newEdgeWithID(source, target, edge.Properties(), id))
+	}, event)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
 }
 
 func (d *directedGraph[K, T]) AddEdgeWithOptions(source, target K, options ...graph.EdgeOption) error {
@@ -171,6 +221,16 @@ func (d *directedGraph[K, T]) AddEdgeWithOptions(source, target K, options ...gr
 	return d.AddEdge(e)
 }
 
+func (d *directedGraph[K, T]) InsertEdge(source, target K, options ...graph.EdgeOption) (graph.EdgeID, error) {
+	e := NewEdgeWithOptions(source, target, func(p graph.EdgeProperties) {
+		for _, option := range options {
+			option(p)
+		}
+	})
+
+	return d.addEdge(e)
+}
+
 func (d *directedGraph[K, T]) AddEdgesFrom(g graph.Interface[K, T]) error {
 	edges, err := g.Edges()
 	if err != nil {
@@ -214,36 +274,96 @@ func (d *directedGraph[K, T]) Edges() ([]graph.Edge[K], error) {
 	return d.store.ListEdges()
 }
 
-func (d *directedGraph[K, T]) SetEdgeWithOptions(source, target K, options ...graph.EdgeOption) error {
-	existingEdge, err := d.store.FindEdge(source, target)
-	if err != nil {
-		return err
+func (d *directedGraph[K, T]) EdgesBetween(source, target K) ([]graph.Edge[K], error) {
+	if _, _, err := d.store.FindVertex(source); err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrVertexNotFound, source)
+	}
+	if _, _, err := d.store.FindVertex(target); err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrVertexNotFound, target)
 	}
 
-	for _, option := range options {
-		ep := existingEdge.Properties()
-		dp, ok := ep.(*EdgeProperties) // Attempt to assert the type
-		if !ok {
-			return fmt.Errorf("failed to modify edge: %T", ep)
+	return d.store.FindEdges(source, target)
+}
+
+func (d *directedGraph[K, T]) EdgeByID(id graph.EdgeID) (graph.Edge[K], error) {
+	return d.store.FindEdgeByID(id)
+}
+
+func (d *directedGraph[K, T]) RemoveEdgeByID(id graph.EdgeID) error {
+	describe := func() ([]graph.Event[K], error) {
+		edge, err := d.store.FindEdgeByID(id)
+		if err != nil {
+			return nil, err
 		}
 
-		option(dp)
+		return removedEdgeEvents([]graph.Edge[K]{edge}), nil
 	}
 
-	return d.store.ModifyEdge(source, target, existingEdge)
+	return d.events.update(describe, func() error {
+		return d.store.RemoveEdgeByID(id)
+	})
 }
 
-func (d *directedGraph[K, T]) RemoveEdge(source, target K) error {
-	if _, err := d.Edge(source, target); err != nil {
-		return err
+func (d *directedGraph[K, T]) SetEdgeWithOptions(source, target K, options ...graph.EdgeOption) error {
+	var existingEdge graph.Edge[K]
+	describe := func() ([]graph.Event[K], error) {
+		var err error
+		existingEdge, err = d.store.FindEdge(source, target)
+		if err != nil {
+			return nil, err
+		}
+		before := existingEdge.Properties()
+
+		// Options are applied to a copy, leaving the stored edge to the store.
+		existingEdge = existingEdge.Clone()
+
+		for _, option := range options {
+			ep := existingEdge.Properties()
+			dp, ok := ep.(*EdgeProperties) // Attempt to assert the type
+			if !ok {
+				return nil, fmt.Errorf("failed to modify edge: %T", ep)
+			}
+
+			option(dp)
+		}
+
+		return []graph.Event[K]{{
+			Type:       graph.EdgeModified,
+			Source:     source,
+			Target:     target,
+			EdgeID:     edgeutil.ID(existingEdge),
+			EdgeBefore: before,
+			EdgeAfter:  existingEdge.Properties(),
+		}}, nil
 	}
 
-	err := d.store.RemoveEdge(source, target)
-	if err != nil {
-		return fmt.Errorf("%w: %v -> %v", graph.ErrFailedToRemoveEdge, source, target)
+	return d.events.update(describe, func() error {
+		return d.store.ModifyEdge(source, target, existingEdge)
+	})
+}
+
+func (d *directedGraph[K, T]) RemoveEdge(source, target K) error {
+	// The edges are listed under the changes lock, so that each edge removed
+	// has an event, including a parallel edge added just before.
+	describe := func() ([]graph.Event[K], error) {
+		if _, err := d.Edge(source, target); err != nil {
+			return nil, err
+		}
+
+		edges, err := d.store.FindEdges(source, target)
+		if err != nil {
+			return nil, err
+		}
+
+		return removedEdgeEvents(edges), nil
 	}
 
-	return nil
+	return d.events.update(describe, func() error {
+		if err := d.store.RemoveEdge(source, target); err != nil {
+			return fmt.Errorf("%w: %v -> %v", graph.ErrFailedToRemoveEdge, source, target)
+		}
+		return nil
+	})
 }
 
 func (d *directedGraph[K, T]) AdjacencyMap() (map[K]map[K]graph.Edge[K], error) {
@@ -263,7 +383,9 @@ func (d *directedGraph[K, T]) AdjacencyMap() (map[K]map[K]graph.Edge[K], error)
 	}
 
 	for _, edge := range edges {
-		m[edge.Source()][edge.Target()] = edge
+		if lighter(m[edge.Source()][edge.Target()], edge) {
+			m[edge.Source()][edge.Target()] = edge
+		}
 	}
 
 	return m, nil
@@ -289,13 +411,30 @@ func (d *directedGraph[K, T]) PredecessorMap() (map[K]map[K]graph.Edge[K], error
 		if _, ok := m[edge.Target()]; !ok {
 			m[edge.Target()] = make(map[K]graph.Edge[K])
 		}
-		m[edge.Target()][edge.Source()] = edge
+		if lighter(m[edge.Target()][edge.Source()], edge) {
+			m[edge.Target()][edge.Source()] = edge
+		}
 	}
 
 	return m, nil
 }
 
+// Clone returns a copy of the graph. If the store is Versioned, the clone is
+// backed by a fork of it, which takes constant time: the clone and the graph
+// share their data until either changes, and a change copies only what it
+// touches. Their properties are copied too before either one hands them out,
+// so changing the items of one does not affect the other. Otherwise, the
+// vertices and edges are copied to a new store.
 func (d *directedGraph[K, T]) Clone() (graph.Interface[K, T], error) {
+	if v, ok := d.store.(Versioned[K, T]); ok {
+		s, err := v.Fork()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", graph.ErrFailedToCloneGraph, err)
+		}
+
+		return newDirectedGraph(d.hash, d.traits.Clone(), s)
+	}
+
 	s, err := d.store.New()
 	if err != nil {
 		return nil, err
@@ -305,13 +444,14 @@ func (d *directedGraph[K, T]) Clone() (graph.Interface[K, T], error) {
 		hash:   d.hash,
 		traits: d.traits.Clone(),
 		store:  s,
+		events: &observers[K]{},
 	}
 
 	if err := clone.AddVerticesFrom(d); err != nil {
 		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToAddVertices, err)
 	}
 
-	if err := clone.AddEdgesFrom(d); err != nil {
+	if err := cloneEdges(d.store, s); err != nil {
 		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToAddEdges, err)
 	}
 
@@ -370,26 +510,7 @@ func (d *directedGraph[K, T]) Vertices() ([]graph.Vertex[K, T], error) {
 }
 
 func (d *directedGraph[K, T]) Neighbors(hash K) ([]graph.Vertex[K, T], error) {
-	adjacencyMap, err := d.AdjacencyMap()
-	if err != nil {
-		return nil, err
-	}
-
-	neighborsMap, exists := adjacencyMap[hash]
-	if !exists {
-		return nil, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
-	}
-
-	neighbors := make([]graph.Vertex[K, T], 0, len(neighborsMap))
-	for neighborHash := range neighborsMap {
-		vertex, err := d.Vertex(neighborHash)
-		if err != nil {
-			return nil, fmt.Errorf("%w: failed to get neighbor vertex", graph.ErrFailedToGetVertex)
-		}
-		neighbors = append(neighbors, vertex)
-	}
-
-	return neighbors, nil
+	return d.OutNeighbors(hash)
 }
 
 func (d *directedGraph[K, T]) Degree(key K) (int, error) {
@@ -407,38 +528,18 @@ func (d *directedGraph[K, T]) Degree(key K) (int, error) {
 }
 
 func (d *directedGraph[K, T]) InDegree(hash K) (int, error) {
-	predecessorMap, err := d.PredecessorMap()
-	if err != nil {
-		return 0, fmt.Errorf("%w: failed to retrieve predecessor map", graph.ErrFailedToGetEdges)
-	}
-
-	edges, exists := predecessorMap[hash]
-	if !exists {
-		return 0, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
-	}
-
-	return len(edges), nil
+	edges, err := d.InEdges(hash)
+	return len(edges), err
 }
 
 func (d *directedGraph[K, T]) OutDegree(hash K) (int, error) {
-	adjacencyMap, err := d.AdjacencyMap()
-	if err != nil {
-		return 0, fmt.Errorf("%w: failed to retrieve adjacency map", graph.ErrFailedToGetEdges)
-	}
-
-	edges, exists := adjacencyMap[hash]
-	if !exists {
-		return 0, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
-	}
-
-	return len(edges), nil
+	edges, err := d.OutEdges(hash)
+	return len(edges), err
 }
 
 func (d *directedGraph[K, T]) wouldCreateCycle(source, target K) (bool, error) {
-	// If the underlying ledger implements WouldCreateCycle, use that fast path.
-	if cc, ok := d.store.(interface {
-		WouldCreateCycle(source, target K) (bool, error)
-	}); ok {
+	// If the underlying store implements CycleDetector, use that fast path.
+	if cc, ok := d.store.(CycleDetector[K]); ok {
 		return cc.WouldCreateCycle(source, target)
 	}
 
@@ -446,97 +547,18 @@ func (d *directedGraph[K, T]) wouldCreateCycle(source, target K) (bool, error) {
 	return paths.WouldCreateCycle(graph.Interface[K, T](d), source, target)
 }
 
-// StreamVerticesWithContext streams vertices from the graph in paginated batches. The stream can be canceled
-// or timed out using a context, and the state of the cursor can be used to resume from the last point.
+// StreamVerticesWithContext streams vertices from the graph in paginated batches, in the order of
+// their keys. The stream can be canceled or timed out using a context, and the cursor can be used to
+// resume after the last vertex sent.
 func (d *directedGraph[K, T]) StreamVerticesWithContext(ctx context.Context, cursor graph.Cursor, limit int, ch chan<- []graph.Vertex[K, T]) (graph.Cursor, error) {
-	defer close(ch) // Ensure the channel is closed when the function returns
-
-	if limit <= 0 {
-		return nil, errors.New("limit must be greater than zero")
-	}
-
-	// Deserialize the cursor state to determine the starting position
-	var currentPosition int64
-	if err := cursor.SetState(cursor.State()); err != nil {
-		return nil, err
-	}
-	state, ok := cursor.(*Cursor)
-	if !ok {
-		return nil, errors.New("invalid cursor type")
-	}
-	currentPosition = state.position
-
-	// Fetch vertices from the underlying ledger
-	vertices, err := d.Vertices()
-	if err != nil {
-		return nil, err
-	}
-
-	// Stream vertices in batches
-	for currentPosition < int64(len(vertices)) {
-		end := currentPosition + int64(limit)
-		if end > int64(len(vertices)) {
-			end = int64(len(vertices))
-		}
-
-		batch := vertices[currentPosition:end]
-		select {
-		case <-ctx.Done(): // Handle cancellation
-			return cursor, ctx.Err()
-		case ch <- batch: // Send batch to channel
-		}
-
-		currentPosition = end
-		state.position = currentPosition
-	}
-
-	return cursor, nil
+	return streamVertices(ctx, d.store, cursor, limit, ch)
 }
 
-// StreamEdgesWithContext streams edges from the graph in paginated batches. The stream can be canceled
-// or timed out using a context, and the state of the cursor can be used to resume from the last point.
+// StreamEdgesWithContext streams edges from the graph in paginated batches, in the order of Edges.
+// The stream can be canceled or timed out using a context, and the cursor can be used to resume after
+// the last edge sent.
 func (d *directedGraph[K, T]) StreamEdgesWithContext(ctx context.Context, cursor graph.Cursor, limit int, ch chan<- graph.Edge[K]) (graph.Cursor, error) {
-	defer close(ch) // Ensure the channel is closed when the function returns
-
-	if limit <= 0 {
-		return nil, errors.New("limit must be greater than zero")
-	}
-
-	// Deserialize the cursor state to determine the starting position
-	var currentPosition int64
-	if err := cursor.SetState(cursor.State()); err != nil {
-		return nil, err
-	}
-	state, ok := cursor.(*Cursor)
-	if !ok {
-		return nil, errors.New("invalid cursor type")
-	}
-	currentPosition = state.position
-
-	// Fetch edges from the underlying ledger
-	edges, err := d.Edges()
-	if err != nil {
-		return nil, err
-	}
-
-	// Stream edges in batches
-	for currentPosition < int64(len(edges)) {
-		end := currentPosition + int64(limit)
-		if end > int64(len(edges)) {
-			end = int64(len(edges))
-		}
-
-		for _, edge := range edges[currentPosition:end] {
-			select {
-			case <-ctx.Done(): // Handle cancellation
-				return cursor, ctx.Err()
-			case ch <- edge: // Send each edge to the channel
-			}
-		}
-
-		currentPosition = end
-		state.position = currentPosition
-	}
-
-	return cursor, nil
+	return streamEdges(ctx, d.store, cursor, limit, ch, func(graph.Edge[K]) bool {
+		return true
+	})
 }
@@ -147,7 +147,7 @@ func TestStreamEdgesWithContext_Directed(t *testing.T) {
 	is.NoError(d.AddEdgeWithOptions("C", "D", EdgeWeight(2)))
 
 	ctx := context.Background()
-	cursor := &Cursor{position: 0}
+	cursor := EmptyCursor()
 	ch := make(chan graph.Edge[string])
 
 	go func() {
@@ -179,7 +179,7 @@ func TestStreamVerticesWithContext_Directed(t *testing.T) {
 	is.NoError(d.AddVertexWithOptions("B", VertexWeight(10), VertexItem("label", "VertexB")))
 
 	ctx := context.Background()
-	cursor := &Cursor{position: 0}
+	cursor := EmptyCursor()
 	ch := make(chan []graph.Vertex[string, string])
 
 	go func() {
@@ -326,3 +326,79 @@ func TestInDegree_Directed(t *testing.T) {
 	is.NoError(err, "Fetching in-degree should not fail")
 	is.Equal(0, inDegree, "In-degree of vertex A should be 0")
 }
+
+func TestMultigraph_Directed(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := New(graph.StringHash, graph.Directed(), graph.Weighted(), graph.MultiGraph())
+	is.NoError(g.AddVertexWithOptions("A"))
+	is.NoError(g.AddVertexWithOptions("B"))
+
+	m, ok := g.(graph.Multigraph[string, string])
+	is.True(ok, "Graph should implement Multigraph")
+
+	first, err := m.InsertEdge("A", "B", EdgeWeight(5))
+	is.NoError(err)
+	second, err := m.InsertEdge("A", "B", EdgeWeight(2))
+	is.NoError(err)
+	is.NoError(g.AddEdgeWithOptions("B", "A", EdgeWeight(1)))
+	is.NotEqual(first, second, "Parallel edges should have distinct IDs")
+
+	size, _ := g.Size()
+	is.Equal(3, size)
+
+	out, _ := g.OutDegree("A")
+	is.Equal(2, out)
+	in, _ := g.InDegree("B")
+	is.Equal(2, in)
+	degree, _ := g.Degree("A")
+	is.Equal(3, degree)
+
+	between, err := m.EdgesBetween("A", "B")
+	is.NoError(err)
+	is.Len(between, 2)
+	is.Equal(first, between[0].(graph.IdentifiedEdge[string]).ID())
+	is.Equal(second, between[1].(graph.IdentifiedEdge[string]).ID())
+
+	// The adjacency map holds the lightest parallel edge; Edge returns the first.
+	adjacency, _ := g.AdjacencyMap()
+	is.Equal(float64(2), adjacency["A"]["B"].Properties().Weight())
+	edge, _ := g.Edge("A", "B")
+	is.Equal(float64(5), edge.Properties().Weight())
+
+	byID, err := m.EdgeByID(second)
+	is.NoError(err)
+	is.Equal(float64(2), byID.Properties().Weight())
+
+	// Clones keep the edge IDs.
+	clone, _ := g.Clone()
+	cloned, err := clone.(graph.Multigraph[string, string]).EdgeByID(first)
+	is.NoError(err)
+	is.Equal(float64(5), cloned.Properties().Weight())
+
+	is.NoError(m.RemoveEdgeByID(first))
+	is.ErrorIs(m.RemoveEdgeByID(first), graph.ErrEdgeNotFound)
+	_, err = m.EdgeByID(first)
+	is.ErrorIs(err, graph.ErrEdgeNotFound)
+	between, _ = m.EdgesBetween("A", "B")
+	is.Len(between, 1)
+	size, _ = g.Size()
+	is.Equal(2, size)
+
+	is.NoError(g.RemoveEdge("A", "B"))
+	ok, _ = g.HasEdge("A", "B")
+	is.False(ok)
+	size, _ = g.Size()
+	is.Equal(1, size)
+
+	_, err = m.EdgesBetween("A", "Z")
+	is.ErrorIs(err, graph.ErrVertexNotFound)
+
+	// Without the trait, parallel edges are rejected.
+	s, _ := New(graph.StringHash, graph.Directed())
+	is.NoError(s.AddVertexWithOptions("A"))
+	is.NoError(s.AddVertexWithOptions("B"))
+	is.NoError(s.AddEdgeWithOptions("A", "B"))
+	is.ErrorIs(s.AddEdgeWithOptions("A", "B"), graph.ErrEdgeAlreadyExists)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sixafter/graph"
+)
+
+// OutNeighbors returns the successors of the vertex. See graph.Incidence.
+func (d *directedGraph[K, T]) OutNeighbors(hash K) ([]graph.Vertex[K, T], error) {
+	edges, err := d.OutEdges(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return neighborVertices(d.store, edges, graph.Edge[K].Target)
+}
+
+// InNeighbors returns the predecessors of the vertex. See graph.Incidence.
+func (d *directedGraph[K, T]) InNeighbors(hash K) ([]graph.Vertex[K, T], error) {
+	edges, err := d.InEdges(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return neighborVertices(d.store, edges, graph.Edge[K].Source)
+}
+
+// OutEdges returns the edges leaving the vertex. See graph.Incidence.
+func (d *directedGraph[K, T]) OutEdges(hash K) ([]graph.Edge[K], error) {
+	return incidentEdges(outEdges(d.store, hash))
+}
+
+// InEdges returns the edges entering the vertex. See graph.Incidence.
+func (d *directedGraph[K, T]) InEdges(hash K) ([]graph.Edge[K], error) {
+	return incidentEdges(inEdges(d.store, hash))
+}
+
+// OutNeighbors returns the neighbors of the vertex. See graph.Incidence.
+func (u *undirected[K, T]) OutNeighbors(hash K) ([]graph.Vertex[K, T], error) {
+	edges, err := u.OutEdges(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return neighborVertices(u.store, edges, graph.Edge[K].Target)
+}
+
+// InNeighbors returns the neighbors of the vertex, as OutNeighbors does. See
+// graph.Incidence.
+func (u *undirected[K, T]) InNeighbors(hash K) ([]graph.Vertex[K, T], error) {
+	return u.OutNeighbors(hash)
+}
+
+// OutEdges returns the edges of the vertex, with the vertex as their source.
+// See graph.Incidence.
+func (u *undirected[K, T]) OutEdges(hash K) ([]graph.Edge[K], error) {
+	return incidentEdges(outEdges(u.store, hash))
+}
+
+// InEdges returns the edges of the vertex, with the vertex as their target.
+// See graph.Incidence.
+func (u *undirected[K, T]) InEdges(hash K) ([]graph.Edge[K], error) {
+	// The reverse of every edge is stored too, so the edges entering the
+	// vertex are all of its edges.
+	return incidentEdges(inEdges(u.store, hash))
+}
+
+func (t *rootedTree[K, T]) OutNeighbors(hash K) ([]graph.Vertex[K, T], error) {
+	return t.Interface.(graph.Incidence[K, T]).OutNeighbors(hash)
+}
+
+func (t *rootedTree[K, T]) InNeighbors(hash K) ([]graph.Vertex[K, T], error) {
+	return t.Interface.(graph.Incidence[K, T]).InNeighbors(hash)
+}
+
+func (t *rootedTree[K, T]) OutEdges(hash K) ([]graph.Edge[K], error) {
+	return t.Interface.(graph.Incidence[K, T]).OutEdges(hash)
+}
+
+func (t *rootedTree[K, T]) InEdges(hash K) ([]graph.Edge[K], error) {
+	return t.Interface.(graph.Incidence[K, T]).InEdges(hash)
+}
+
+func (s *snapshot[K, T]) OutNeighbors(hash K) ([]graph.Vertex[K, T], error) {
+	return s.Interface.(graph.Incidence[K, T]).OutNeighbors(hash)
+}
+
+func (s *snapshot[K, T]) InNeighbors(hash K) ([]graph.Vertex[K, T], error) {
+	return s.Interface.(graph.Incidence[K, T]).InNeighbors(hash)
+}
+
+func (s *snapshot[K, T]) OutEdges(hash K) ([]graph.Edge[K], error) {
+	return s.Interface.(graph.Incidence[K, T]).OutEdges(hash)
+}
+
+func (s *snapshot[K, T]) InEdges(hash K) ([]graph.Edge[K], error) {
+	return s.Interface.(graph.Incidence[K, T]).InEdges(hash)
+}
+
+// incidentEdges wraps the error of an edge index query for a missing vertex
+// in ErrVertexNotFound.
+func incidentEdges[K graph.Ordered](edges []graph.Edge[K], err error) ([]graph.Edge[K], error) {
+	if errors.Is(err, graph.ErrVertexNotFound) {
+		return nil, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetEdges, err)
+	}
+
+	return edges, nil
+}
+
+// neighborVertices returns the distinct vertices that key selects from the
+// edges, which must be ordered by those keys.
+func neighborVertices[K graph.Ordered, T any](store Store[K, T], edges []graph.Edge[K], key func(graph.Edge[K]) K) ([]graph.Vertex[K, T], error) {
+	vertices := make([]graph.Vertex[K, T], 0, len(edges))
+	for i, edge := range edges {
+		// Parallel edges lead to the same neighbor.
+		if i > 0 && key(edges[i-1]) == key(edge) {
+			continue
+		}
+
+		value, properties, err := store.FindVertex(key(edge))
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get neighbor vertex", graph.ErrFailedToGetVertex)
+		}
+		vertices = append(vertices, NewVertex(key(edge), value, properties))
+	}
+
+	return vertices, nil
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+// vertexKeys returns the keys of the vertices.
+func vertexKeys[K comparable, T any](vertices []graph.Vertex[K, T]) []K {
+	keys := make([]K, len(vertices))
+	for i, v := range vertices {
+		keys[i] = v.ID()
+	}
+	return keys
+}
+
+func TestIncidence_Directed(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.StringHash, graph.Directed(), graph.MultiGraph(), graph.Weighted())
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C", "D"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "B", EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("C", "B", EdgeWeight(3)))
+	is.NoError(g.AddEdgeWithOptions("B", "D", EdgeWeight(4)))
+	is.NoError(g.AddEdgeWithOptions("B", "B", EdgeWeight(5)))
+
+	inc := g.(graph.Incidence[string, string])
+
+	out, err := inc.OutNeighbors("B")
+	is.NoError(err)
+	is.Equal([]string{"B", "D"}, vertexKeys(out))
+	in, err := inc.InNeighbors("B")
+	is.NoError(err)
+	is.Equal([]string{"A", "B", "C"}, vertexKeys(in))
+
+	neighbors, err := g.Neighbors("B")
+	is.NoError(err)
+	is.Equal(vertexKeys(out), vertexKeys(neighbors))
+
+	// Parallel edges are listed with their properties.
+	edges, err := inc.InEdges("B")
+	is.NoError(err)
+	weights := make([]float64, len(edges))
+	for i, e := range edges {
+		weights[i] = e.Properties().Weight()
+	}
+	is.Equal([]float64{1, 2, 5, 3}, weights)
+
+	degree, err := g.InDegree("B")
+	is.NoError(err)
+	is.Equal(4, degree)
+	degree, err = g.OutDegree("B")
+	is.NoError(err)
+	is.Equal(2, degree)
+	degree, err = g.Degree("B")
+	is.NoError(err)
+	is.Equal(6, degree)
+
+	_, err = inc.OutEdges("missing")
+	is.ErrorIs(err, graph.ErrVertexNotFound)
+	_, err = g.InDegree("missing")
+	is.ErrorIs(err, graph.ErrVertexNotFound)
+}
+
+func TestIncidence_Undirected(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.IntHash)
+	is.NoError(err)
+	for i := 1; i <= 3; i++ {
+		is.NoError(g.AddVertexWithOptions(i))
+	}
+	is.NoError(g.AddEdgeWithOptions(2, 1))
+	is.NoError(g.AddEdgeWithOptions(1, 3))
+
+	inc := g.(graph.Incidence[int, int])
+
+	out, err := inc.OutEdges(1)
+	is.NoError(err)
+	is.Len(out, 2)
+	for _, e := range out {
+		is.Equal(1, e.Source())
+	}
+	in, err := inc.InEdges(1)
+	is.NoError(err)
+	is.Len(in, 2)
+	for _, e := range in {
+		is.Equal(1, e.Target())
+	}
+
+	neighbors, err := inc.InNeighbors(1)
+	is.NoError(err)
+	is.Equal([]int{2, 3}, vertexKeys(neighbors))
+	degree, err := g.InDegree(1)
+	is.NoError(err)
+	is.Equal(2, degree)
+
+	// Stores without an edge index give the same answers.
+	inner, err := NewMemoryStore[int, int]()
+	is.NoError(err)
+	c, err := NewWithStore(graph.IntHash, &countingStore[int, int]{Store: inner})
+	is.NoError(err)
+	is.NoError(c.AddVerticesFrom(g))
+	is.NoError(c.AddEdgesFrom(g))
+	degree, err = c.Degree(1)
+	is.NoError(err)
+	is.Equal(2, degree)
+	neighbors, err = c.Neighbors(1)
+	is.NoError(err)
+	is.Equal([]int{2, 3}, vertexKeys(neighbors))
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"errors"
+	"iter"
+	"slices"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
+)
+
+// AllVertices returns the keys and values of the vertices in ascending order
+// of their keys. See graph.Iterable.
+func (d *directedGraph[K, T]) AllVertices() iter.Seq2[K, T] {
+	return allVertices(d.store)
+}
+
+// AllEdges returns the edges in the order of Edges. See graph.Iterable.
+func (d *directedGraph[K, T]) AllEdges() iter.Seq[graph.Edge[K]] {
+	return allEdges(d.store, func(graph.Edge[K]) bool {
+		return true
+	})
+}
+
+// Successors returns the keys of the targets of the edges leaving the vertex
+// in ascending order. See graph.Iterable.
+func (d *directedGraph[K, T]) Successors(hash K) iter.Seq[K] {
+	return neighbors(func() ([]graph.Edge[K], error) {
+		return outEdges(d.store, hash)
+	}, graph.Edge[K].Target)
+}
+
+// Predecessors returns the keys of the sources of the edges entering the
+// vertex in ascending order. See graph.Iterable.
+func (d *directedGraph[K, T]) Predecessors(hash K) iter.Seq[K] {
+	return neighbors(func() ([]graph.Edge[K], error) {
+		return inEdges(d.store, hash)
+	}, graph.Edge[K].Source)
+}
+
+// IncidentEdges returns the edges leaving the vertex followed by the edges
+// entering it. See graph.Iterable.
+func (d *directedGraph[K, T]) IncidentEdges(hash K) iter.Seq[graph.Edge[K]] {
+	return func(yield func(graph.Edge[K]) bool) {
+		out, err := outEdges(d.store, hash)
+		if err != nil {
+			return
+		}
+		for _, edge := range out {
+			if !yield(edge) {
+				return
+			}
+		}
+
+		in, err := inEdges(d.store, hash)
+		if err != nil {
+			return
+		}
+		for _, edge := range in {
+			// Self-loops have been returned with the edges leaving the vertex.
+			if edge.Source() == hash {
+				continue
+			}
+			if !yield(edge) {
+				return
+			}
+		}
+	}
+}
+
+// AllVertices returns the keys and values of the vertices in ascending order
+// of their keys. See graph.Iterable.
+func (u *undirected[K, T]) AllVertices() iter.Seq2[K, T] {
+	return allVertices(u.store)
+}
+
+// AllEdges returns each edge once, in the order of Edges. See
+// graph.Iterable.
+func (u *undirected[K, T]) AllEdges() iter.Seq[graph.Edge[K]] {
+	// Each edge is stored in both directions, so only the direction from the
+	// smaller key to the larger one is returned, as Edges does.
+	return allEdges(u.store, func(edge graph.Edge[K]) bool {
+		return edge.Source() <= edge.Target()
+	})
+}
+
+// Successors returns the keys of the neighbors of the vertex in ascending
+// order. See graph.Iterable.
+func (u *undirected[K, T]) Successors(hash K) iter.Seq[K] {
+	return neighbors(func() ([]graph.Edge[K], error) {
+		return outEdges(u.store, hash)
+	}, graph.Edge[K].Target)
+}
+
+// Predecessors returns the keys of the neighbors of the vertex in ascending
+// order, as Successors does. See graph.Iterable.
+func (u *undirected[K, T]) Predecessors(hash K) iter.Seq[K] {
+	return u.Successors(hash)
+}
+
+// IncidentEdges returns the edges of the vertex, with the vertex as their
+// source. See graph.Iterable.
+func (u *undirected[K, T]) IncidentEdges(hash K) iter.Seq[graph.Edge[K]] {
+	return func(yield func(graph.Edge[K]) bool) {
+		// The reverse of every edge is stored too, so the edges leaving the
+		// vertex are all of its edges.
+		edges, err := outEdges(u.store, hash)
+		if err != nil {
+			return
+		}
+		for _, edge := range edges {
+			if !yield(edge) {
+				return
+			}
+		}
+	}
+}
+
+func (t *rootedTree[K, T]) AllVertices() iter.Seq2[K, T] {
+	return t.Interface.(graph.Iterable[K, T]).AllVertices()
+}
+
+func (t *rootedTree[K, T]) AllEdges() iter.Seq[graph.Edge[K]] {
+	return t.Interface.(graph.Iterable[K, T]).AllEdges()
+}
+
+func (t *rootedTree[K, T]) Successors(hash K) iter.Seq[K] {
+	return t.Interface.(graph.Iterable[K, T]).Successors(hash)
+}
+
+func (t *rootedTree[K, T]) Predecessors(hash K) iter.Seq[K] {
+	return t.Interface.(graph.Iterable[K, T]).Predecessors(hash)
+}
+
+func (t *rootedTree[K, T]) IncidentEdges(hash K) iter.Seq[graph.Edge[K]] {
+	return t.Interface.(graph.Iterable[K, T]).IncidentEdges(hash)
+}
+
+func (s *snapshot[K, T]) AllVertices() iter.Seq2[K, T] {
+	return s.Interface.(graph.Iterable[K, T]).AllVertices()
+}
+
+func (s *snapshot[K, T]) AllEdges() iter.Seq[graph.Edge[K]] {
+	return s.Interface.(graph.Iterable[K, T]).AllEdges()
+}
+
+func (s *snapshot[K, T]) Successors(hash K) iter.Seq[K] {
+	return s.Interface.(graph.Iterable[K, T]).Successors(hash)
+}
+
+func (s *snapshot[K, T]) Predecessors(hash K) iter.Seq[K] {
+	return s.Interface.(graph.Iterable[K, T]).Predecessors(hash)
+}
+
+func (s *snapshot[K, T]) IncidentEdges(hash K) iter.Seq[graph.Edge[K]] {
+	return s.Interface.(graph.Iterable[K, T]).IncidentEdges(hash)
+}
+
+// allVertices returns a sequence of the vertices of store, from a sorted list
+// of the keys taken when the sequence starts.
+func allVertices[K graph.Ordered, T any](store Store[K, T]) iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		keys, err := store.ListVertices()
+		if err != nil {
+			return
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			value, _, err := store.FindVertex(key)
+			if errors.Is(err, graph.ErrVertexNotFound) {
+				continue // Removed since the keys were listed
+			}
+			if err != nil {
+				return
+			}
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// allEdges returns a sequence of the edges of store accepted by include, from
+// a sorted list of the edges taken when the sequence starts.
+func allEdges[K graph.Ordered, T any](store Store[K, T], include func(graph.Edge[K]) bool) iter.Seq[graph.Edge[K]] {
+	return func(yield func(graph.Edge[K]) bool) {
+		edges, err := store.ListEdges()
+		if err != nil {
+			return
+		}
+		edges = slices.DeleteFunc(edges, func(edge graph.Edge[K]) bool {
+			return !include(edge)
+		})
+		slices.SortFunc(edges, edgeutil.Compare[K])
+
+		for _, edge := range edges {
+			if !yield(edge) {
+				return
+			}
+		}
+	}
+}
+
+// neighbors returns a sequence of the distinct keys that key selects from the
+// edges returned by list, which must be ordered by those keys.
+func neighbors[K graph.Ordered](list func() ([]graph.Edge[K], error), key func(graph.Edge[K]) K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		edges, err := list()
+		if err != nil {
+			return
+		}
+
+		for i, edge := range edges {
+			// Parallel edges lead to the same neighbor.
+			if i > 0 && key(edges[i-1]) == key(edge) {
+				continue
+			}
+			if !yield(key(edge)) {
+				return
+			}
+		}
+	}
+}
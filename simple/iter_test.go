@@ -0,0 +1,129 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterable_Directed(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.StringHash, graph.Directed(), graph.MultiGraph())
+	is.NoError(err)
+	for _, v := range []string{"C", "A", "B", "D"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "C"))
+	is.NoError(g.AddEdgeWithOptions("A", "B"))
+	is.NoError(g.AddEdgeWithOptions("A", "B"))
+	is.NoError(g.AddEdgeWithOptions("C", "A"))
+	is.NoError(g.AddEdgeWithOptions("A", "A"))
+
+	it := g.(graph.Iterable[string, string])
+
+	var keys []string
+	for key := range it.AllVertices() {
+		keys = append(keys, key)
+	}
+	is.Equal([]string{"A", "B", "C", "D"}, keys)
+
+	edges, _ := g.Edges()
+	is.Equal(edges, slices.Collect(it.AllEdges()))
+
+	is.Equal([]string{"A", "B", "C"}, slices.Collect(it.Successors("A")))
+	is.Equal([]string{"A", "C"}, slices.Collect(it.Predecessors("A")))
+	is.Empty(slices.Collect(it.Successors("D")))
+	is.Empty(slices.Collect(it.Successors("missing")))
+
+	// Parallel edges and the self-loop are returned once each.
+	is.Len(slices.Collect(it.IncidentEdges("A")), 5)
+	is.Len(slices.Collect(it.IncidentEdges("B")), 2)
+
+	// Loops can stop early.
+	for key := range it.Successors("A") {
+		is.Equal("A", key)
+		break
+	}
+}
+
+func TestIterable_Undirected(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.IntHash)
+	is.NoError(err)
+	for i := 1; i <= 4; i++ {
+		is.NoError(g.AddVertexWithOptions(i))
+	}
+	is.NoError(g.AddEdgeWithOptions(3, 1))
+	is.NoError(g.AddEdgeWithOptions(1, 2))
+	is.NoError(g.AddEdgeWithOptions(2, 2))
+
+	it := g.(graph.Iterable[int, int])
+
+	edges, _ := g.Edges()
+	is.Equal(edges, slices.Collect(it.AllEdges()))
+	is.Len(edges, 3)
+
+	is.Equal([]int{2, 3}, slices.Collect(it.Successors(1)))
+	is.Equal([]int{2, 3}, slices.Collect(it.Predecessors(1)))
+	is.Equal([]int{1, 2}, slices.Collect(it.Successors(2)))
+	for edge := range it.IncidentEdges(1) {
+		is.Equal(1, edge.Source())
+	}
+	is.Len(slices.Collect(it.IncidentEdges(2)), 2)
+}
+
+func TestIterable_Stores(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	// Stores without an edge index are searched.
+	inner, err := NewMemoryStore[int, int]()
+	is.NoError(err)
+	g, err := NewWithStore(graph.IntHash, &countingStore[int, int]{Store: inner}, graph.Directed())
+	is.NoError(err)
+	for i := 1; i <= 300; i++ {
+		is.NoError(g.AddVertexWithOptions(i))
+		if i > 1 {
+			is.NoError(g.AddEdgeWithOptions(i, 1))
+		}
+	}
+
+	it := g.(graph.Iterable[int, int])
+	is.Len(slices.Collect(it.Predecessors(1)), 299)
+	is.Equal([]int{1}, slices.Collect(it.Successors(300)))
+
+	count := 0
+	for range it.AllVertices() {
+		count++
+	}
+	is.Equal(300, count)
+	is.Len(slices.Collect(it.AllEdges()), 299)
+
+	// A snapshot iterates over its version, and stops once it is released.
+	d, err := New(graph.IntHash, graph.Directed())
+	is.NoError(err)
+	is.NoError(d.AddVertexWithOptions(1))
+	is.NoError(d.AddVertexWithOptions(2))
+	snap, err := d.(graph.Snapshotter[int, int]).Snapshot()
+	is.NoError(err)
+	is.NoError(d.AddEdgeWithOptions(1, 2))
+
+	si := snap.(graph.Iterable[int, int])
+	is.Empty(slices.Collect(si.Successors(1)))
+	is.Equal([]int{2}, slices.Collect(d.(graph.Iterable[int, int]).Successors(1)))
+
+	snap.Release()
+	is.Empty(maps.Collect(si.AllVertices()))
+}
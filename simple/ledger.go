@@ -6,21 +6,42 @@
 package simple
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
 )
 
-// ledger defines the interface for managing both vertices and edges in a graph.
+// Store defines the interface for managing both vertices and edges in a graph.
 // It combines methods for adding, modifying, deleting, and retrieving vertices and edges,
 // as well as counting and listing them.
 //
+// Graphs created by New keep their data in the store returned by NewMemoryStore.
+// A graph can be backed by any other implementation, such as a disk-backed,
+// remote or instrumented store, by passing it to NewWithStore. The graph does
+// not lock around its calls to the store, so a store used by a graph that has
+// concurrent callers, or shared with other code, must be safe for concurrent
+// use.
+//
+// A store may also implement optional interfaces that the graph uses as fast
+// paths when they are available:
+//   - CycleDetector, consulted before adding an edge to a graph with the
+//     PreventCycles trait instead of traversing the graph.
+//   - EdgeIndex, used to list the edges of a vertex without listing every
+//     edge of the graph.
+//   - Pager, used to stream the graph a page at a time.
+//   - Transactional, used to apply a Batch atomically.
+//   - Versioned, used to take snapshots and clone the graph in constant time.
+//
 // Type Parameters:
 //   - K: The type used to uniquely identify vertices (keys), must be comparable.
 //   - T: The type of metadata stored in each Vertex.
-type ledger[K comparable, T any] interface {
-	// New creates and returns a new instance of the ledger implementation.
-	// This method allows for creating fresh, independent ledger instances
-	// that adhere to the ledger interface.
-	New() (ledger[K, T], error)
+type Store[K comparable, T any] interface {
+	// New creates and returns a new, empty instance of the store implementation.
+	// This method allows for creating fresh, independent store instances,
+	// for example when a graph is cloned.
+	New() (Store[K, T], error)
 
 	// Vertex Management
 
@@ -84,7 +105,9 @@ type ledger[K comparable, T any] interface {
 
 	// AddEdge adds an edge between the vertices with the specified source and target keys.
 	// If either Vertex does not exist, ErrVertexNotFound must be returned for the respective Vertex.
-	// If the edge already exists, ErrEdgeAlreadyExists must be returned.
+	// Several edges may be stored between the same vertices; they are told apart by the
+	// ID of the edge (see graph.IdentifiedEdge). Adding an edge whose ID is already stored
+	// between the same vertices replaces it. An edge without an ID is assigned one.
 	//
 	// Parameters:
 	//   - source: The key of the source Vertex.
@@ -96,6 +119,7 @@ type ledger[K comparable, T any] interface {
 	AddEdge(source, target K, edge graph.Edge[K]) error
 
 	// FindEdge retrieves the edge between the specified source and target vertices.
+	// If there are parallel edges, the one added first is returned.
 	// If the edge does not exist, ErrEdgeNotFound must be returned.
 	//
 	// Parameters:
@@ -107,6 +131,7 @@ type ledger[K comparable, T any] interface {
 	FindEdge(source, target K) (graph.Edge[K], error)
 
 	// ModifyEdge updates the properties of an existing edge between the specified source and target vertices.
+	// The stored edge with the same ID is replaced, or the first one if the edge has no ID.
 	//
 	// Parameters:
 	//   - source: The unique identifier of the source Vertex.
@@ -117,7 +142,7 @@ type ledger[K comparable, T any] interface {
 	//   - An error if the edge does not exist (ErrEdgeNotFound) or the operation fails.
 	ModifyEdge(source, target K, edge graph.Edge[K]) error
 
-	// RemoveEdge removes the edge between the specified vertices.
+	// RemoveEdge removes every edge from source to target.
 	// If the edge does not exist, ErrEdgeNotFound must be returned.
 	//
 	// Parameters:
@@ -134,9 +159,165 @@ type ledger[K comparable, T any] interface {
 	//   - A slice of edges and an error if the operation fails.
 	ListEdges() ([]graph.Edge[K], error)
 
-	// CountEdges returns the total number of edges in the graph.
+	// CountEdges returns the total number of edges in the graph. An edge stored in
+	// both directions under the same ID is counted once.
 	//
 	// Returns:
 	//   - The number of edges and an error if the operation fails.
 	CountEdges() (int, error)
+
+	// Parallel Edge Management
+
+	// NextEdgeID reserves and returns a new edge ID. IDs are never reused by
+	// the same store.
+	//
+	// Returns:
+	//   - A new, non-zero edge ID.
+	NextEdgeID() graph.EdgeID
+
+	// FindEdges retrieves every edge from source to target, in the order they were added.
+	// If there is no such edge, an empty slice is returned.
+	//
+	// Parameters:
+	//   - source: The key of the source Vertex.
+	//   - target: The key of the target Vertex.
+	//
+	// Returns:
+	//   - The edges and an error if the operation fails.
+	FindEdges(source, target K) ([]graph.Edge[K], error)
+
+	// FindEdgeByID retrieves the edge with the given ID, as it was first added.
+	// If the edge does not exist, ErrEdgeNotFound must be returned.
+	//
+	// Parameters:
+	//   - id: The ID of the edge.
+	//
+	// Returns:
+	//   - The edge and an error if the edge does not exist.
+	FindEdgeByID(id graph.EdgeID) (graph.Edge[K], error)
+
+	// RemoveEdgeByID removes every stored direction of the edge with the given ID.
+	// If the edge does not exist, ErrEdgeNotFound must be returned.
+	//
+	// Parameters:
+	//   - id: The ID of the edge.
+	//
+	// Returns:
+	//   - An error if the edge does not exist or the operation fails.
+	RemoveEdgeByID(id graph.EdgeID) error
+}
+
+// CycleDetector is an optional interface for a Store that can tell whether an
+// edge would create a cycle more efficiently than a traversal of the graph,
+// for instance by using an index or a query on the backing storage.
+type CycleDetector[K comparable] interface {
+	// WouldCreateCycle reports whether adding an edge from source to target
+	// would create a cycle. If either vertex does not exist,
+	// ErrVertexNotFound must be returned.
+	//
+	// Parameters:
+	//   - source: The key of the source Vertex.
+	//   - target: The key of the target Vertex.
+	//
+	// Returns:
+	//   - true if the edge would create a cycle, and an error if the operation fails.
+	WouldCreateCycle(source, target K) (bool, error)
+}
+
+// EdgeIndex is an optional interface for a Store that indexes the edges of
+// each vertex. Graphs use it to enumerate the neighbors and incident edges of
+// a vertex; for other stores, they search the edges returned by ListEdges.
+type EdgeIndex[K comparable] interface {
+	// OutEdges returns the edges leaving the vertex, ordered by target and
+	// then by edge ID. If the vertex does not exist, ErrVertexNotFound must
+	// be returned.
+	//
+	// Parameters:
+	//   - key: The key of the Vertex.
+	//
+	// Returns:
+	//   - The edges whose source is the Vertex, and an error if the operation fails.
+	OutEdges(key K) ([]graph.Edge[K], error)
+
+	// InEdges returns the edges entering the vertex, ordered by source and
+	// then by edge ID. If the vertex does not exist, ErrVertexNotFound must be
+	// returned.
+	//
+	// Parameters:
+	//   - key: The key of the Vertex.
+	//
+	// Returns:
+	//   - The edges whose target is the Vertex, and an error if the operation fails.
+	InEdges(key K) ([]graph.Edge[K], error)
+}
+
+// cloneEdges copies every stored edge from one store to another, keeping
+// the edge IDs. The vertices must already exist in the destination.
+func cloneEdges[K graph.Ordered, T any](from, to Store[K, T]) error {
+	edges, err := from.ListEdges()
+	if err != nil {
+		return err
+	}
+
+	for _, edge := range edges {
+		if err = to.AddEdge(edge.Source(), edge.Target(), edge.Clone()); err != nil {
+			return fmt.Errorf("%w (%v, %v): %v", graph.ErrFailedToAddEdge, edge.Source(), edge.Target(), err)
+		}
+	}
+
+	return nil
+}
+
+// outEdges returns the edges leaving the vertex with the given key, ordered
+// by target and ID, from the index of the store if it has one.
+func outEdges[K graph.Ordered, T any](store Store[K, T], key K) ([]graph.Edge[K], error) {
+	if index, ok := store.(EdgeIndex[K]); ok {
+		return index.OutEdges(key)
+	}
+
+	return searchEdges(store, key, func(edge graph.Edge[K]) bool {
+		return edge.Source() == key
+	})
+}
+
+// inEdges returns the edges entering the vertex with the given key, ordered
+// by source and ID, from the index of the store if it has one.
+func inEdges[K graph.Ordered, T any](store Store[K, T], key K) ([]graph.Edge[K], error) {
+	if index, ok := store.(EdgeIndex[K]); ok {
+		return index.InEdges(key)
+	}
+
+	return searchEdges(store, key, func(edge graph.Edge[K]) bool {
+		return edge.Target() == key
+	})
+}
+
+// searchEdges returns the edges of a store without an EdgeIndex that match,
+// ordered by source, target and ID.
+func searchEdges[K graph.Ordered, T any](store Store[K, T], key K, match func(graph.Edge[K]) bool) ([]graph.Edge[K], error) {
+	if _, _, err := store.FindVertex(key); err != nil {
+		return nil, err
+	}
+
+	edges, err := store.ListEdges()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]graph.Edge[K], 0)
+	for _, edge := range edges {
+		if match(edge) {
+			matched = append(matched, edge)
+		}
+	}
+	slices.SortFunc(matched, edgeutil.Compare[K])
+
+	return matched, nil
+}
+
+// lighter reports whether edge should take the place of current in an
+// adjacency map. Of several parallel edges, the one with the lowest weight is
+// kept.
+func lighter[K comparable](current, edge graph.Edge[K]) bool {
+	return current == nil || edge.Properties().Weight() < current.Properties().Weight()
 }
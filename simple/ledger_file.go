@@ -0,0 +1,817 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
+)
+
+const (
+	// fileStoreLog is the name of the write-ahead log in the store directory.
+	fileStoreLog = "graph.wal"
+
+	// fileStoreSnapshot is the name of the snapshot in the store directory.
+	fileStoreSnapshot = "graph.snapshot"
+
+	// fileFrameHeaderSize is the size of the length and checksum that
+	// precede each frame.
+	fileFrameHeaderSize = 8
+
+	// fileSnapshotFrame is the number of records per frame of a snapshot.
+	fileSnapshotFrame = 1024
+
+	// defaultCompactEvery is the number of log records after which the log is
+	// compacted into a snapshot unless configured otherwise.
+	defaultCompactEvery = 10000
+)
+
+// fileOp identifies the operation recorded in a fileRecord.
+type fileOp uint8
+
+const (
+	fileOpSnapshot fileOp = iota + 1
+	fileOpAddVertex
+	fileOpModifyVertex
+	fileOpRemoveVertex
+	fileOpAddEdge
+	fileOpModifyEdge
+	fileOpRemoveEdge
+	fileOpRemoveEdgeByID
+)
+
+// fileRecord is a single entry of the write-ahead log or the snapshot.
+// Records are written in frames: the records of one transaction are encoded
+// together with encoding/gob and preceded by their length and CRC-32
+// checksum, so a frame torn by a crash can be detected and dropped as a whole.
+type fileRecord[K comparable, T any] struct {
+	// Sequence orders the records of the log. The snapshot record holds the
+	// sequence of the last record it includes.
+	Sequence uint64
+	Op       fileOp
+	Source   K
+	Target   K
+	Value    T
+	EdgeID   graph.EdgeID
+
+	// HasProperties is false for a vertex stored without properties.
+	HasProperties bool
+	Weight        float64
+	Items         map[string]any
+	Metadata      any
+}
+
+// FileStoreOption configures a FileStore.
+type FileStoreOption func(*fileStoreOptions)
+
+type fileStoreOptions struct {
+	compactEvery int
+	syncWrites   bool
+}
+
+// CompactEvery sets the number of log records after which the log is
+// compacted into a snapshot. Zero disables automatic compaction; Compact can
+// still be called explicitly. The default is 10000.
+func CompactEvery(records int) FileStoreOption {
+	return func(o *fileStoreOptions) {
+		o.compactEvery = records
+	}
+}
+
+// SyncWrites sets whether every record is flushed to stable storage before
+// the operation returns. It is enabled by default; disabling it trades the
+// durability of the most recent operations on power loss for speed.
+func SyncWrites(sync bool) FileStoreOption {
+	return func(o *fileStoreOptions) {
+		o.syncWrites = sync
+	}
+}
+
+// FileStore is a durable Store that keeps the graph in memory and appends
+// every change to a write-ahead log in a directory. When the store is opened,
+// the latest snapshot is loaded and the log is replayed on top of it. Once
+// enough records have been logged, the state is compacted into a new snapshot
+// and the log is truncated.
+//
+// A crash can leave a partially written frame at the end of the log. Such a
+// frame fails its checksum and is discarded when the store is reopened, so
+// the store recovers the state as of the last complete operation. The changes
+// of a graph Batch are written in a single frame and so are recovered all
+// together or not at all.
+//
+// Vertex values, keys and the items and metadata of properties are encoded
+// with encoding/gob. Concrete types stored in items or metadata other than
+// the basic types must be registered with gob.Register.
+//
+// The directory must not be shared by several open stores. Clones of a graph
+// backed by a FileStore are kept in memory.
+//
+// Example:
+//
+//	store, err := simple.OpenFileStore[string, string]("data/graph")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer store.Close()
+//
+//	g, err := simple.NewWithStore(graph.StringHash, store, graph.Directed())
+type FileStore[K graph.Ordered, T any] struct {
+	// memory holds the current state of the graph.
+	memory *memoryLedger[K, T]
+
+	// dir is the directory holding the log and the snapshot.
+	dir string
+
+	// log is the write-ahead log, opened for appending.
+	log *os.File
+
+	// sequence is the sequence of the last record written.
+	sequence uint64
+
+	// pending is the number of records in the log since the last snapshot.
+	pending int
+
+	options fileStoreOptions
+
+	// err is set when a record could not be written. The in-memory state may
+	// then be ahead of the log, so every later change fails with it.
+	err error
+
+	// lock serializes changes so that records are logged in the order they
+	// are applied.
+	lock sync.Mutex
+}
+
+// OpenFileStore opens the durable store in dir, creating the directory if it
+// does not exist, and recovers the graph persisted in it.
+func OpenFileStore[K graph.Ordered, T any](dir string, options ...FileStoreOption) (*FileStore[K, T], error) {
+	s := &FileStore[K, T]{
+		dir: dir,
+		options: fileStoreOptions{
+			compactEvery: defaultCompactEvery,
+			syncWrites:   true,
+		},
+	}
+
+	for _, option := range options {
+		option(&s.options)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	memory, _ := NewMemoryStore[K, T]()
+	s.memory = memory.(*memoryLedger[K, T])
+
+	if err := s.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := s.replayLog(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// loadSnapshot restores the state saved by the last compaction, if any.
+func (s *FileStore[K, T]) loadSnapshot() error {
+	f, err := os.Open(filepath.Join(s.dir, fileStoreSnapshot))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := &fileFrameReader[K, T]{r: f}
+
+	frame, err := r.next()
+	if err != nil || len(frame) != 1 || frame[0].Op != fileOpSnapshot {
+		return fmt.Errorf("%w: invalid snapshot header", graph.ErrStoreCorrupted)
+	}
+	header := frame[0]
+
+	for {
+		frame, err = r.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: snapshot frame at offset %d: %v", graph.ErrStoreCorrupted, r.offset, err)
+		}
+		for _, record := range frame {
+			if err = s.apply(record); err != nil {
+				return fmt.Errorf("%w: snapshot frame at offset %d: %v", graph.ErrStoreCorrupted, r.offset, err)
+			}
+		}
+	}
+
+	s.sequence = header.Sequence
+	if header.EdgeID > s.memory.lastEdgeID {
+		s.memory.lastEdgeID = header.EdgeID
+	}
+
+	return nil
+}
+
+// replayLog applies the records logged since the snapshot and opens the log
+// for appending. A torn or damaged frame ends the log; it and anything after
+// it is truncated.
+func (s *FileStore[K, T]) replayLog() error {
+	f, err := os.OpenFile(filepath.Join(s.dir, fileStoreLog), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	r := &fileFrameReader[K, T]{r: f}
+	for {
+		start := r.offset
+
+		frame, err := r.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if err = f.Truncate(start); err != nil {
+				_ = f.Close()
+				return err
+			}
+			break
+		}
+
+		for _, record := range frame {
+			// Records already included in the snapshot are left from a
+			// compaction interrupted before the log was truncated.
+			if record.Sequence <= s.sequence {
+				continue
+			}
+
+			if err = s.apply(record); err != nil {
+				_ = f.Close()
+				return fmt.Errorf("%w: log record %d: %v", graph.ErrStoreCorrupted, record.Sequence, err)
+			}
+			s.sequence = record.Sequence
+			s.pending++
+		}
+	}
+
+	s.log = f
+	return nil
+}
+
+// apply performs the operation of a record on the in-memory state.
+func (s *FileStore[K, T]) apply(record fileRecord[K, T]) error {
+	switch record.Op {
+	case fileOpAddVertex:
+		return s.memory.AddVertex(record.Source, record.Value, record.vertexProperties())
+	case fileOpModifyVertex:
+		return s.memory.ModifyVertex(record.Source, record.vertexProperties())
+	case fileOpRemoveVertex:
+		return s.memory.RemoveVertex(record.Source)
+	case fileOpAddEdge:
+		return s.memory.AddEdge(record.Source, record.Target, record.edge())
+	case fileOpModifyEdge:
+		return s.memory.ModifyEdge(record.Source, record.Target, record.edge())
+	case fileOpRemoveEdge:
+		return s.memory.RemoveEdge(record.Source, record.Target)
+	case fileOpRemoveEdgeByID:
+		return s.memory.RemoveEdgeByID(record.EdgeID)
+	default:
+		return fmt.Errorf("unknown operation %d", record.Op)
+	}
+}
+
+// write appends the records to the log in a single frame, compacting the log
+// once it holds enough records. The lock must be held.
+func (s *FileStore[K, T]) write(records []fileRecord[K, T]) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	for i := range records {
+		s.sequence++
+		records[i].Sequence = s.sequence
+	}
+
+	if err := writeFileFrame(s.log, records); err != nil {
+		s.err = err
+		return err
+	}
+	if s.options.syncWrites {
+		if err := s.log.Sync(); err != nil {
+			s.err = err
+			return err
+		}
+	}
+
+	s.pending += len(records)
+	if s.options.compactEvery > 0 && s.pending >= s.options.compactEvery {
+		// The change is already durable in the log; a failed compaction is
+		// retried after the next change.
+		_ = s.compact()
+	}
+
+	return nil
+}
+
+// usable returns the error that prevents changes, if any. The lock must be
+// held.
+func (s *FileStore[K, T]) usable() error {
+	if s.log == nil {
+		return graph.ErrStoreClosed
+	}
+	if s.err != nil {
+		return fmt.Errorf("write-ahead log failed: %w", s.err)
+	}
+	return nil
+}
+
+// Compact saves the current state to a new snapshot and empties the log.
+func (s *FileStore[K, T]) Compact() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.usable(); err != nil {
+		return err
+	}
+
+	return s.compact()
+}
+
+// compact writes the snapshot next to the old one and renames it into place,
+// so a crash leaves either the old or the new snapshot. The lock must be held.
+func (s *FileStore[K, T]) compact() error {
+	path := filepath.Join(s.dir, fileStoreSnapshot)
+	tmp := path + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	if err = s.writeSnapshot(f); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	syncDir(s.dir)
+
+	if err = s.log.Truncate(0); err != nil {
+		return err
+	}
+	if s.options.syncWrites {
+		if err = s.log.Sync(); err != nil {
+			return err
+		}
+	}
+
+	s.pending = 0
+	return nil
+}
+
+// writeSnapshot writes a header followed by the records that rebuild the
+// current state.
+func (s *FileStore[K, T]) writeSnapshot(w io.Writer) error {
+	s.memory.lock.RLock()
+	defer s.memory.lock.RUnlock()
+
+	header := fileRecord[K, T]{Sequence: s.sequence, Op: fileOpSnapshot, EdgeID: s.memory.lastEdgeID}
+	if err := writeFileFrame(w, []fileRecord[K, T]{header}); err != nil {
+		return err
+	}
+
+	frame := make([]fileRecord[K, T], 0, fileSnapshotFrame)
+	add := func(record fileRecord[K, T]) error {
+		frame = append(frame, record)
+		if len(frame) < fileSnapshotFrame {
+			return nil
+		}
+		err := writeFileFrame(w, frame)
+		frame = frame[:0]
+		return err
+	}
+
+	for key, vertex := range s.memory.vertices.All() {
+		record := fileRecord[K, T]{Op: fileOpAddVertex, Source: key, Value: vertex.value}
+		record.setProperties(vertex.properties)
+		if err := add(record); err != nil {
+			return err
+		}
+	}
+
+	for source, targets := range s.memory.outEdges.All() {
+		for target, edges := range targets.All() {
+			for _, edge := range edges {
+				if err := add(edgeRecord[K, T](fileOpAddEdge, source, target, edge)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if len(frame) == 0 {
+		return nil
+	}
+	return writeFileFrame(w, frame)
+}
+
+// Close closes the log. Later changes fail with ErrStoreClosed; the graph can
+// still be read.
+func (s *FileStore[K, T]) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.log == nil {
+		return graph.ErrStoreClosed
+	}
+
+	err := s.log.Close()
+	s.log = nil
+	return err
+}
+
+// New creates and returns a new, empty in-memory store.
+func (s *FileStore[K, T]) New() (Store[K, T], error) {
+	return NewMemoryStore[K, T]()
+}
+
+// Transact runs fn with the store locked once for all of its operations.
+// The records of the changes made by fn are written to the log in a single
+// frame once fn returns nil; if fn returns an error, nothing is written, and
+// fn must have undone its changes.
+func (s *FileStore[K, T]) Transact(fn func(view Store[K, T]) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.usable(); err != nil {
+		return err
+	}
+
+	var records []fileRecord[K, T]
+	err := s.memory.Transact(func(view Store[K, T]) error {
+		return fn(&fileView[K, T]{Store: view, records: &records})
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.write(records)
+}
+
+// AddVertex adds a vertex and logs it.
+func (s *FileStore[K, T]) AddVertex(key K, value T, properties graph.VertexProperties) error {
+	return s.Transact(func(view Store[K, T]) error {
+		return view.AddVertex(key, value, properties)
+	})
+}
+
+// FindVertex retrieves a vertex and its properties by its key.
+func (s *FileStore[K, T]) FindVertex(key K) (T, graph.VertexProperties, error) {
+	return s.memory.FindVertex(key)
+}
+
+// ModifyVertex updates the properties of a vertex and logs the change.
+func (s *FileStore[K, T]) ModifyVertex(key K, properties graph.VertexProperties) error {
+	return s.Transact(func(view Store[K, T]) error {
+		return view.ModifyVertex(key, properties)
+	})
+}
+
+// RemoveVertex removes a vertex and logs the removal.
+func (s *FileStore[K, T]) RemoveVertex(key K) error {
+	return s.Transact(func(view Store[K, T]) error {
+		return view.RemoveVertex(key)
+	})
+}
+
+// ListVertices retrieves all vertex keys in the graph.
+func (s *FileStore[K, T]) ListVertices() ([]K, error) {
+	return s.memory.ListVertices()
+}
+
+// CountVertices returns the total number of vertices in the graph.
+func (s *FileStore[K, T]) CountVertices() (int, error) {
+	return s.memory.CountVertices()
+}
+
+// AddEdge adds an edge and logs it.
+func (s *FileStore[K, T]) AddEdge(source, target K, edge graph.Edge[K]) error {
+	return s.Transact(func(view Store[K, T]) error {
+		return view.AddEdge(source, target, edge)
+	})
+}
+
+// FindEdge retrieves the edge between the specified source and target vertices.
+func (s *FileStore[K, T]) FindEdge(source, target K) (graph.Edge[K], error) {
+	return s.memory.FindEdge(source, target)
+}
+
+// ModifyEdge updates the properties of an edge and logs the change.
+func (s *FileStore[K, T]) ModifyEdge(source, target K, edge graph.Edge[K]) error {
+	return s.Transact(func(view Store[K, T]) error {
+		return view.ModifyEdge(source, target, edge)
+	})
+}
+
+// RemoveEdge removes every edge from source to target and logs the removal.
+func (s *FileStore[K, T]) RemoveEdge(source, target K) error {
+	return s.Transact(func(view Store[K, T]) error {
+		return view.RemoveEdge(source, target)
+	})
+}
+
+// ListEdges retrieves all edges in the graph.
+func (s *FileStore[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	return s.memory.ListEdges()
+}
+
+// CountEdges returns the total number of edges in the graph.
+func (s *FileStore[K, T]) CountEdges() (int, error) {
+	return s.memory.CountEdges()
+}
+
+// NextEdgeID reserves and returns a new edge ID.
+func (s *FileStore[K, T]) NextEdgeID() graph.EdgeID {
+	return s.memory.NextEdgeID()
+}
+
+// FindEdges retrieves every edge from source to target.
+func (s *FileStore[K, T]) FindEdges(source, target K) ([]graph.Edge[K], error) {
+	return s.memory.FindEdges(source, target)
+}
+
+// FindEdgeByID retrieves the edge with the given ID.
+func (s *FileStore[K, T]) FindEdgeByID(id graph.EdgeID) (graph.Edge[K], error) {
+	return s.memory.FindEdgeByID(id)
+}
+
+// RemoveEdgeByID removes the edge with the given ID and logs the removal.
+func (s *FileStore[K, T]) RemoveEdgeByID(id graph.EdgeID) error {
+	return s.Transact(func(view Store[K, T]) error {
+		return view.RemoveEdgeByID(id)
+	})
+}
+
+// Version returns a number that increases with every change to the store.
+func (s *FileStore[K, T]) Version() uint64 {
+	return s.memory.Version()
+}
+
+// Fork returns an in-memory copy of the store that shares its data until
+// either is changed. Changes to the copy are not logged.
+func (s *FileStore[K, T]) Fork() (Store[K, T], error) {
+	return s.memory.Fork()
+}
+
+// PageVertices returns up to limit vertex keys in ascending order, starting
+// after the given key. See Pager.
+func (s *FileStore[K, T]) PageVertices(after *K, limit int) ([]K, error) {
+	return s.memory.PageVertices(after, limit)
+}
+
+// PageEdges returns up to limit edges in the order of ListEdges, starting
+// after the given edge. See Pager.
+func (s *FileStore[K, T]) PageEdges(after graph.Edge[K], limit int) ([]graph.Edge[K], error) {
+	return s.memory.PageEdges(after, limit)
+}
+
+// OutEdges returns the edges leaving the vertex, ordered by target and ID.
+// See EdgeIndex.
+func (s *FileStore[K, T]) OutEdges(key K) ([]graph.Edge[K], error) {
+	return s.memory.OutEdges(key)
+}
+
+// InEdges returns the edges entering the vertex, ordered by source and ID.
+// See EdgeIndex.
+func (s *FileStore[K, T]) InEdges(key K) ([]graph.Edge[K], error) {
+	return s.memory.InEdges(key)
+}
+
+// WouldCreateCycle checks if adding an edge from source to target would
+// create a cycle in the graph.
+func (s *FileStore[K, T]) WouldCreateCycle(source, target K) (bool, error) {
+	return s.memory.WouldCreateCycle(source, target)
+}
+
+// fileView is the Store passed to the function of FileStore.Transact. It
+// applies changes to the in-memory view and collects their records.
+type fileView[K graph.Ordered, T any] struct {
+	Store[K, T]
+	records *[]fileRecord[K, T]
+}
+
+func (v *fileView[K, T]) AddVertex(key K, value T, properties graph.VertexProperties) error {
+	if err := v.Store.AddVertex(key, value, properties); err != nil {
+		return err
+	}
+
+	record := fileRecord[K, T]{Op: fileOpAddVertex, Source: key, Value: value}
+	record.setProperties(properties)
+	*v.records = append(*v.records, record)
+	return nil
+}
+
+func (v *fileView[K, T]) ModifyVertex(key K, properties graph.VertexProperties) error {
+	if err := v.Store.ModifyVertex(key, properties); err != nil {
+		return err
+	}
+
+	record := fileRecord[K, T]{Op: fileOpModifyVertex, Source: key}
+	record.setProperties(properties)
+	*v.records = append(*v.records, record)
+	return nil
+}
+
+func (v *fileView[K, T]) RemoveVertex(key K) error {
+	if err := v.Store.RemoveVertex(key); err != nil {
+		return err
+	}
+
+	*v.records = append(*v.records, fileRecord[K, T]{Op: fileOpRemoveVertex, Source: key})
+	return nil
+}
+
+// AddEdge adds an edge, assigning it an ID first if it has none, so that
+// replaying the log restores the same ID.
+func (v *fileView[K, T]) AddEdge(source, target K, edge graph.Edge[K]) error {
+	if edgeutil.ID(edge) == 0 {
+		edge = newEdgeWithID(source, target, edge.Properties(), v.Store.NextEdgeID())
+	}
+	if err := v.Store.AddEdge(source, target, edge); err != nil {
+		return err
+	}
+
+	*v.records = append(*v.records, edgeRecord[K, T](fileOpAddEdge, source, target, edge))
+	return nil
+}
+
+func (v *fileView[K, T]) ModifyEdge(source, target K, edge graph.Edge[K]) error {
+	// Resolve the edge that is modified, so the record does not depend on
+	// the order of parallel edges.
+	if edgeutil.ID(edge) == 0 {
+		first, err := v.Store.FindEdge(source, target)
+		if err != nil {
+			return err
+		}
+		edge = newEdgeWithID(source, target, edge.Properties(), edgeutil.ID(first))
+	}
+	if err := v.Store.ModifyEdge(source, target, edge); err != nil {
+		return err
+	}
+
+	*v.records = append(*v.records, edgeRecord[K, T](fileOpModifyEdge, source, target, edge))
+	return nil
+}
+
+func (v *fileView[K, T]) RemoveEdge(source, target K) error {
+	if err := v.Store.RemoveEdge(source, target); err != nil {
+		return err
+	}
+
+	*v.records = append(*v.records, fileRecord[K, T]{Op: fileOpRemoveEdge, Source: source, Target: target})
+	return nil
+}
+
+func (v *fileView[K, T]) RemoveEdgeByID(id graph.EdgeID) error {
+	if err := v.Store.RemoveEdgeByID(id); err != nil {
+		return err
+	}
+
+	*v.records = append(*v.records, fileRecord[K, T]{Op: fileOpRemoveEdgeByID, EdgeID: id})
+	return nil
+}
+
+// edgeRecord creates the record of an edge operation.
+func edgeRecord[K comparable, T any](op fileOp, source, target K, edge graph.Edge[K]) fileRecord[K, T] {
+	record := fileRecord[K, T]{Op: op, Source: source, Target: target, EdgeID: edgeutil.ID(edge)}
+	if p := edge.Properties(); p != nil {
+		record.HasProperties = true
+		record.Weight = p.Weight()
+		record.Items = p.Items()
+		record.Metadata = p.Metadata()
+	}
+	return record
+}
+
+// setProperties copies vertex properties into the record.
+func (r *fileRecord[K, T]) setProperties(properties graph.VertexProperties) {
+	if properties == nil {
+		return
+	}
+	r.HasProperties = true
+	r.Weight = properties.Weight()
+	r.Items = properties.Items()
+	r.Metadata = properties.Metadata()
+}
+
+// vertexProperties rebuilds the vertex properties held by the record.
+func (r *fileRecord[K, T]) vertexProperties() graph.VertexProperties {
+	if !r.HasProperties {
+		return nil
+	}
+	return &VertexProperties{v: r.items(), weight: r.Weight, metadata: r.Metadata}
+}
+
+// edge rebuilds the edge held by the record.
+func (r *fileRecord[K, T]) edge() graph.Edge[K] {
+	properties := &EdgeProperties{items: r.items(), weight: r.Weight, metadata: r.Metadata}
+	return newEdgeWithID(r.Source, r.Target, properties, r.EdgeID)
+}
+
+// items returns the items of the record; gob decodes an empty map as nil.
+func (r *fileRecord[K, T]) items() map[string]any {
+	if r.Items == nil {
+		return make(map[string]any)
+	}
+	return r.Items
+}
+
+// writeFileFrame encodes records and writes them with their length and
+// checksum in a single call.
+func writeFileFrame[K comparable, T any](w io.Writer, records []fileRecord[K, T]) error {
+	var buf bytes.Buffer
+	buf.Write(make([]byte, fileFrameHeaderSize))
+
+	if err := gob.NewEncoder(&buf).Encode(records); err != nil {
+		return fmt.Errorf("encode records: %w", err)
+	}
+
+	b := buf.Bytes()
+	payload := b[fileFrameHeaderSize:]
+	binary.BigEndian.PutUint32(b[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(b[4:8], crc32.ChecksumIEEE(payload))
+
+	_, err := w.Write(b)
+	return err
+}
+
+// fileFrameReader reads frames of records and tracks the offset of the next
+// frame.
+type fileFrameReader[K comparable, T any] struct {
+	r      io.Reader
+	offset int64
+}
+
+// next reads the records of the next frame. It returns io.EOF at the clean
+// end of the input, and another error for a truncated or damaged frame.
+func (fr *fileFrameReader[K, T]) next() ([]fileRecord[K, T], error) {
+	var header [fileFrameHeaderSize]byte
+	if _, err := io.ReadFull(fr.r, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, errors.New("truncated frame header")
+		}
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[0:4])
+	buf := bytes.NewBuffer(make([]byte, 0, min(size, 1<<20)))
+	if n, err := io.CopyN(buf, fr.r, int64(size)); err != nil {
+		return nil, fmt.Errorf("truncated frame: read %d of %d bytes", n, size)
+	}
+	if crc32.ChecksumIEEE(buf.Bytes()) != binary.BigEndian.Uint32(header[4:8]) {
+		return nil, errors.New("checksum mismatch")
+	}
+
+	var records []fileRecord[K, T]
+	if err := gob.NewDecoder(buf).Decode(&records); err != nil {
+		return nil, fmt.Errorf("decode records: %w", err)
+	}
+
+	fr.offset += fileFrameHeaderSize + int64(size)
+	return records, nil
+}
+
+// syncDir flushes a directory entry change, such as a rename, to stable
+// storage where the platform supports it.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
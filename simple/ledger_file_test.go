@@ -0,0 +1,192 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// openFileGraph opens the file store in dir and a directed graph backed by it.
+func openFileGraph(t *testing.T, dir string, options ...FileStoreOption) (*FileStore[string, string], graph.Interface[string, string]) {
+	store, err := OpenFileStore[string, string](dir, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewWithStore(graph.StringHash, store, graph.Directed())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return store, g
+}
+
+func TestFileStore_Replay(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+	dir := t.TempDir()
+
+	store, g := openFileGraph(t, dir)
+	is.NoError(g.AddVertexWithOptions("A", VertexWeight(2), VertexItem("color", "red")))
+	is.NoError(g.AddVertexWithOptions("B"))
+	is.NoError(g.AddVertexWithOptions("C"))
+	is.NoError(g.AddVertexWithOptions("D"))
+	is.NoError(g.AddEdgeWithOptions("A", "B", EdgeWeight(3), EdgeItem("label", "ab")))
+	is.NoError(g.AddEdgeWithOptions("B", "C", EdgeWeight(4)))
+	is.NoError(g.AddEdgeWithOptions("C", "D"))
+	is.NoError(g.SetEdgeWithOptions("B", "C", EdgeWeight(5)))
+	is.NoError(g.RemoveEdge("C", "D"))
+	is.NoError(g.RemoveVertex("D"))
+	before, _ := store.FindEdge("A", "B")
+	is.NoError(store.Close())
+	is.ErrorIs(g.AddVertexWithOptions("E"), graph.ErrStoreClosed)
+
+	store, g = openFileGraph(t, dir)
+	defer store.Close()
+
+	order, _ := g.Order()
+	is.Equal(3, order)
+	size, _ := g.Size()
+	is.Equal(2, size)
+
+	v, err := g.Vertex("A")
+	is.NoError(err)
+	is.Equal(float64(2), v.Properties().Weight())
+	is.Equal("red", v.Properties().Items()["color"])
+
+	e, err := store.FindEdge("A", "B")
+	is.NoError(err)
+	is.Equal(edgeutil.ID(before), edgeutil.ID(e))
+	is.Equal(float64(3), e.Properties().Weight())
+	is.Equal("ab", e.Properties().Items()["label"])
+
+	e, _ = g.Edge("B", "C")
+	is.Equal(float64(5), e.Properties().Weight())
+
+	// New edges never reuse the IDs of edges that were removed.
+	is.NoError(g.AddEdgeWithOptions("C", "A"))
+	e, _ = store.FindEdge("C", "A")
+	is.Greater(edgeutil.ID(e), graph.EdgeID(3))
+}
+
+func TestFileStore_TornRecord(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+	dir := t.TempDir()
+	log := filepath.Join(dir, fileStoreLog)
+
+	store, g := openFileGraph(t, dir)
+	is.NoError(g.AddVertexWithOptions("A"))
+	is.NoError(g.AddVertexWithOptions("B"))
+	is.NoError(store.Close())
+	complete, _ := os.Stat(log)
+
+	store, g = openFileGraph(t, dir)
+	is.NoError(g.AddEdgeWithOptions("A", "B"))
+	is.NoError(store.Close())
+
+	// Simulate a crash in the middle of writing the last record.
+	data, err := os.ReadFile(log)
+	is.NoError(err)
+	is.NoError(os.WriteFile(log, data[:len(data)-3], 0o644))
+
+	store, g = openFileGraph(t, dir)
+	order, _ := g.Order()
+	is.Equal(2, order)
+	size, _ := g.Size()
+	is.Equal(0, size)
+
+	info, _ := os.Stat(log)
+	is.Equal(complete.Size(), info.Size(), "the torn record is truncated")
+
+	// The log keeps working after recovery.
+	is.NoError(g.AddEdgeWithOptions("B", "A"))
+	is.NoError(store.Close())
+
+	// A damaged record is dropped as well.
+	data, _ = os.ReadFile(log)
+	data[len(data)-1] ^= 0xff
+	is.NoError(os.WriteFile(log, data, 0o644))
+
+	store, g = openFileGraph(t, dir)
+	defer store.Close()
+	ok, _ := g.HasEdge("B", "A")
+	is.False(ok)
+}
+
+func TestFileStore_Compaction(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+	dir := t.TempDir()
+	log := filepath.Join(dir, fileStoreLog)
+
+	store, g := openFileGraph(t, dir, CompactEvery(4))
+	for _, v := range []string{"A", "B", "C"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", EdgeWeight(1)))
+
+	// The fourth record triggered a compaction.
+	info, err := os.Stat(log)
+	is.NoError(err)
+	is.Zero(info.Size())
+	_, err = os.Stat(filepath.Join(dir, fileStoreSnapshot))
+	is.NoError(err)
+
+	is.NoError(g.AddEdgeWithOptions("B", "C", EdgeWeight(2)))
+	stale, _ := os.ReadFile(log)
+	is.NoError(store.Compact())
+	is.NoError(store.Close())
+
+	// Restore the log as left by a crash between writing the snapshot and
+	// truncating the log: its records are already part of the snapshot.
+	is.NoError(os.WriteFile(log, stale, 0o644))
+
+	store, g = openFileGraph(t, dir)
+	defer store.Close()
+
+	order, _ := g.Order()
+	is.Equal(3, order)
+	edges, _ := g.Edges()
+	is.Len(edges, 2)
+	e, _ := g.Edge("B", "C")
+	is.Equal(float64(2), e.Properties().Weight())
+}
+
+func TestFileStore_Undirected(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+	dir := t.TempDir()
+
+	store, err := OpenFileStore[int, int](dir, CompactEvery(0))
+	is.NoError(err)
+	g, _ := NewWithStore(graph.IntHash, store, graph.MultiGraph())
+	for i := 1; i <= 3; i++ {
+		is.NoError(g.AddVertexWithOptions(i))
+	}
+	is.NoError(g.AddEdgeWithOptions(1, 2))
+	is.NoError(g.AddEdgeWithOptions(1, 2))
+	is.NoError(g.AddEdgeWithOptions(2, 3))
+	is.NoError(store.Compact())
+	is.NoError(g.AddEdgeWithOptions(3, 1))
+	is.NoError(store.Close())
+
+	store, err = OpenFileStore[int, int](dir)
+	is.NoError(err)
+	defer store.Close()
+	g, _ = NewWithStore(graph.IntHash, store, graph.MultiGraph())
+
+	size, _ := g.Size()
+	is.Equal(4, size)
+	parallel, _ := g.(graph.Multigraph[int, int]).EdgesBetween(2, 1)
+	is.Len(parallel, 2)
+}
@@ -6,64 +6,101 @@
 package simple
 
 import (
+	"cmp"
 	"fmt"
-	"sort"
+	"iter"
+	"runtime"
+	"slices"
 	"sync"
+	"sync/atomic"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
 	"github.com/sixafter/graph/internal/queue"
+	"github.com/sixafter/graph/internal/sorted"
 )
 
-// memoryLedger is an in-memory implementation of the ledger interface,
+// memoryLedger is an in-memory implementation of the Store interface,
 // providing methods to manage the vertices and edges of a graph.
 //
-// This implementation uses maps to efficiently ledger and retrieve graph data,
-// including vertex data, vertex properties, and both incoming and outgoing edges.
+// This implementation keeps the vertices, both incoming and outgoing edges
+// and the edge IDs in sorted maps, which list and page them in order and
+// share their nodes with forks until changed.
 //
 // Type Parameters:
 //   - K: The type used to uniquely identify vertices (e.g., string, int). Must be comparable.
 //   - T: The type of data stored in each vertex (e.g., a custom struct or primitive type).
 type memoryLedger[K graph.Ordered, T any] struct {
-	// vertices maps vertex identifiers (keys of type K) to their associated data (of type T).
-	vertices map[K]T
-
-	// vertexProps maps vertex identifiers to their associated properties,
-	// such as metadata or attributes specific to each vertex.
-	vertexProps map[K]graph.VertexProperties
-
-	// outEdges maps each vertex to a map of its outgoing edges.
-	// The inner map maps the target vertex identifiers to their corresponding Edge objects.
-	outEdges map[K]map[K]graph.Edge[K]
-
-	// inEdges maps each vertex to a map of its incoming edges.
-	// The inner map maps the source vertex identifiers to their corresponding Edge objects.
-	inEdges map[K]map[K]graph.Edge[K]
+	// vertices maps vertex identifiers (keys of type K) to their associated
+	// data (of type T) and properties.
+	vertices sorted.Map[K, storedVertex[T]]
+
+	// outEdges maps each vertex with outgoing edges to a map of them.
+	// The inner map maps the target vertex identifiers to the edges to that
+	// target, in the order they were added.
+	outEdges sorted.Map[K, adjacency[K]]
+
+	// inEdges maps each vertex with incoming edges to a map of them.
+	// The inner map maps the source vertex identifiers to the edges from that
+	// source, in the order they were added.
+	inEdges sorted.Map[K, adjacency[K]]
+
+	// edgeIDs maps each edge ID to the source/target pairs it is stored under.
+	// The first pair is the direction the edge was added in.
+	edgeIDs sorted.Map[graph.EdgeID, []tuple[K]]
+
+	// lastEdgeID is the most recently reserved edge ID.
+	lastEdgeID graph.EdgeID
+
+	// version counts the changes made to the ledger.
+	version uint64
+
+	// owner owns the nodes of the sorted maps that the ledger may change in
+	// place. Forking gives the ledger a new owner, so that the nodes it
+	// shares with the fork are copied before they are changed.
+	owner *sorted.Owner
+
+	// share holds the fork generations the ledger belongs to. It is nil
+	// until the ledger is forked.
+	share *sharing
+
+	// ownedVertices and ownedEdges hold the vertices and edge IDs whose
+	// properties have been copied or replaced since the last fork. The
+	// properties of the others may be shared with a fork, so they are copied
+	// before they are handed out, as callers may change their items. While
+	// the sets are nil, no properties are shared.
+	ownedVertices map[K]struct{}
+	ownedEdges    map[graph.EdgeID]struct{}
 
 	// lock is a read-write mutex used to ensure thread-safe access to the graph's data.
 	lock sync.RWMutex
+}
 
-	// edgeCount tracks the total number of edges in the graph,
-	// including both incoming and outgoing edges.
-	edgeCount int
+// storedVertex is the value and properties of a vertex.
+type storedVertex[T any] struct {
+	value      T
+	properties graph.VertexProperties
 }
 
-// newMemoryStore initializes a new in-memory graph ledger.
+// adjacency maps the other vertex of each edge of a vertex to the edges
+// between them, in the order they were added. The slices of edges may be
+// shared with a fork, so they are replaced rather than changed.
+type adjacency[K graph.Ordered] = sorted.Map[K, []graph.Edge[K]]
+
+// NewMemoryStore initializes a new in-memory graph store. It is the store used
+// by New, and implements CycleDetector.
 //
 // Returns:
-//   - A pointer to a memoryLedger instance.
-func newMemoryStore[K graph.Ordered, T any]() (ledger[K, T], error) {
+//   - A Store backed by sorted maps.
+func NewMemoryStore[K graph.Ordered, T any]() (Store[K, T], error) {
 	return &memoryLedger[K, T]{
-		vertices:    make(map[K]T),
-		vertexProps: make(map[K]graph.VertexProperties),
-		outEdges:    make(map[K]map[K]graph.Edge[K]),
-		inEdges:     make(map[K]map[K]graph.Edge[K]),
+		owner: new(sorted.Owner),
 	}, nil
 }
 
-// New creates and returns a new instance of the ledger implementation.
-// This method allows for creating fresh, independent ledger instances that adhere to the ledger interface.
-func (ms *memoryLedger[K, T]) New() (ledger[K, T], error) {
-	return newMemoryStore[K, T]()
+// New creates and returns a new, empty in-memory store.
+func (ms *memoryLedger[K, T]) New() (Store[K, T], error) {
+	return NewMemoryStore[K, T]()
 }
 
 // AddVertex adds a vertex with the specified hash, value, and properties to the graph.
@@ -72,12 +109,19 @@ func (ms *memoryLedger[K, T]) AddVertex(hash K, value T, properties graph.Vertex
 	ms.lock.Lock()
 	defer ms.lock.Unlock()
 
-	if _, exists := ms.vertices[hash]; exists {
+	return ms.addVertex(hash, value, properties)
+}
+
+// addVertex implements AddVertex without locking.
+func (ms *memoryLedger[K, T]) addVertex(hash K, value T, properties graph.VertexProperties) error {
+	if _, exists := ms.vertices.Get(hash); exists {
 		return graph.ErrVertexAlreadyExists
 	}
 
-	ms.vertices[hash] = value
-	ms.vertexProps[hash] = properties
+	ms.write()
+	stored := storedVertex[T]{value: value, properties: properties}
+	ms.vertices.Set(ms.owner, hash, stored)
+	ms.ownVertex(hash, stored)
 	return nil
 }
 
@@ -87,78 +131,187 @@ func (ms *memoryLedger[K, T]) AddEdge(source, target K, edge graph.Edge[K]) erro
 	ms.lock.Lock()
 	defer ms.lock.Unlock()
 
-	if _, exists := ms.vertices[source]; !exists {
+	return ms.addEdge(source, target, edge)
+}
+
+// addEdge implements AddEdge without locking.
+func (ms *memoryLedger[K, T]) addEdge(source, target K, edge graph.Edge[K]) error {
+	if _, exists := ms.vertices.Get(source); !exists {
 		return graph.ErrVertexNotFound
 	}
-	if _, exists := ms.vertices[target]; !exists {
+	if _, exists := ms.vertices.Get(target); !exists {
 		return graph.ErrVertexNotFound
 	}
 
-	if ms.outEdges[source] == nil {
-		ms.outEdges[source] = make(map[K]graph.Edge[K])
+	ms.insertEdge(source, target, edge)
+	return nil
+}
+
+// insertEdge stores edge under source and target without locking or
+// checking that the vertices exist.
+func (ms *memoryLedger[K, T]) insertEdge(source, target K, edge graph.Edge[K]) {
+	ms.write()
+
+	id := edgeutil.ID(edge)
+	if id == 0 {
+		ms.lastEdgeID++
+		id = ms.lastEdgeID
+		edge = newEdgeWithID(source, target, edge.Properties(), id)
+	} else if id > ms.lastEdgeID {
+		ms.lastEdgeID = id
 	}
-	if ms.inEdges[target] == nil {
-		ms.inEdges[target] = make(map[K]graph.Edge[K])
+
+	if ms.ownedEdges != nil {
+		ms.ownedEdges[id] = struct{}{}
 	}
 
-	ms.outEdges[source][target] = edge
-	ms.inEdges[target][source] = edge
-	ms.edgeCount++
-	return nil
+	// An edge stored again under the same pair, such as the reverse of an
+	// undirected self-loop, replaces itself.
+	if i := indexOfEdge(ms.edgesBetween(source, target), id); i >= 0 {
+		ms.replaceEdge(source, target, i, edge)
+		return
+	}
+
+	ms.setEdges(&ms.outEdges, source, target, append(slices.Clip(ms.edgesBetween(source, target)), edge))
+	ms.setEdges(&ms.inEdges, target, source, append(slices.Clip(edgesOf(&ms.inEdges, target, source)), edge))
+	pairs, _ := ms.edgeIDs.Get(id)
+	ms.edgeIDs.Set(ms.owner, id, append(slices.Clip(pairs), tuple[K]{source: source, target: target}))
 }
 
 // FindVertex retrieves a vertex and its properties by its hash.
 // If the vertex does not exist, ErrVertexNotFound is returned.
 func (ms *memoryLedger[K, T]) FindVertex(key K) (T, graph.VertexProperties, error) {
-	ms.lock.RLock()
-	defer ms.lock.RUnlock()
+	defer ms.lockProperties()()
 
-	vertex, exists := ms.vertices[key]
+	return ms.findVertex(key)
+}
+
+// findVertex implements FindVertex without locking.
+func (ms *memoryLedger[K, T]) findVertex(key K) (T, graph.VertexProperties, error) {
+	vertex, exists := ms.vertices.Get(key)
 	if !exists {
 		return *new(T), nil, graph.ErrVertexNotFound
 	}
 
-	return vertex, ms.vertexProps[key], nil
+	return vertex.value, ms.ownVertex(key, vertex), nil
 }
 
 // FindEdge retrieves an edge between the specified source and target vertices.
+// If there are parallel edges, the one added first is returned.
 // If the edge does not exist, ErrEdgeNotFound is returned.
 func (ms *memoryLedger[K, T]) FindEdge(source, target K) (graph.Edge[K], error) {
-	ms.lock.RLock()
-	defer ms.lock.RUnlock()
+	defer ms.lockProperties()()
 
-	if edge, exists := ms.outEdges[source][target]; exists {
-		return edge, nil
+	return ms.findEdge(source, target)
+}
+
+// findEdge implements FindEdge without locking.
+func (ms *memoryLedger[K, T]) findEdge(source, target K) (graph.Edge[K], error) {
+	if edges := ms.edgesBetween(source, target); len(edges) > 0 {
+		return ms.ownEdge(edges[0]), nil
 	}
 	return nil, graph.ErrEdgeNotFound
 }
 
+// FindEdges retrieves every edge from source to target, in the order they were added.
+func (ms *memoryLedger[K, T]) FindEdges(source, target K) ([]graph.Edge[K], error) {
+	defer ms.lockProperties()()
+
+	return ms.findEdges(source, target)
+}
+
+// findEdges implements FindEdges without locking.
+func (ms *memoryLedger[K, T]) findEdges(source, target K) ([]graph.Edge[K], error) {
+	return ms.ownEdges(slices.Clone(ms.edgesBetween(source, target))), nil
+}
+
+// FindEdgeByID retrieves the edge with the given ID, in the direction it was added.
+// If the edge does not exist, ErrEdgeNotFound is returned.
+func (ms *memoryLedger[K, T]) FindEdgeByID(id graph.EdgeID) (graph.Edge[K], error) {
+	defer ms.lockProperties()()
+
+	return ms.findEdgeByID(id)
+}
+
+// findEdgeByID implements FindEdgeByID without locking.
+func (ms *memoryLedger[K, T]) findEdgeByID(id graph.EdgeID) (graph.Edge[K], error) {
+	pairs, exists := ms.edgeIDs.Get(id)
+	if !exists {
+		return nil, graph.ErrEdgeNotFound
+	}
+
+	edges := ms.edgesBetween(pairs[0].source, pairs[0].target)
+	return ms.ownEdge(edges[indexOfEdge(edges, id)]), nil
+}
+
+// NextEdgeID reserves and returns a new edge ID.
+func (ms *memoryLedger[K, T]) NextEdgeID() graph.EdgeID {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	return ms.nextEdgeID()
+}
+
+// nextEdgeID implements NextEdgeID without locking.
+func (ms *memoryLedger[K, T]) nextEdgeID() graph.EdgeID {
+	ms.lastEdgeID++
+	return ms.lastEdgeID
+}
+
 // ModifyVertex updates the properties of an existing vertex.
 // If the vertex does not exist, ErrVertexNotFound is returned.
 func (ms *memoryLedger[K, T]) ModifyVertex(key K, properties graph.VertexProperties) error {
 	ms.lock.Lock()
 	defer ms.lock.Unlock()
 
-	if _, exists := ms.vertices[key]; !exists {
+	return ms.modifyVertex(key, properties)
+}
+
+// modifyVertex implements ModifyVertex without locking.
+func (ms *memoryLedger[K, T]) modifyVertex(key K, properties graph.VertexProperties) error {
+	vertex, exists := ms.vertices.Get(key)
+	if !exists {
 		return graph.ErrVertexNotFound
 	}
 
-	ms.vertexProps[key] = properties
+	ms.write()
+	vertex.properties = properties
+	ms.vertices.Set(ms.owner, key, vertex)
+	ms.ownVertex(key, vertex)
 	return nil
 }
 
 // ModifyEdge updates the properties of an existing edge between the specified source and target vertices.
+// The stored edge with the same ID is replaced, or the first one if the edge has no ID.
 // If the edge does not exist, ErrEdgeNotFound is returned.
 func (ms *memoryLedger[K, T]) ModifyEdge(source, target K, edge graph.Edge[K]) error {
 	ms.lock.Lock()
 	defer ms.lock.Unlock()
 
-	if _, exists := ms.outEdges[source][target]; !exists {
+	return ms.modifyEdge(source, target, edge)
+}
+
+// modifyEdge implements ModifyEdge without locking.
+func (ms *memoryLedger[K, T]) modifyEdge(source, target K, edge graph.Edge[K]) error {
+	edges := ms.edgesBetween(source, target)
+	if len(edges) == 0 {
 		return graph.ErrEdgeNotFound
 	}
 
-	ms.outEdges[source][target] = edge
-	ms.inEdges[target][source] = edge
+	i := 0
+	if id := edgeutil.ID(edge); id != 0 {
+		if i = indexOfEdge(edges, id); i < 0 {
+			return graph.ErrEdgeNotFound
+		}
+	} else {
+		edge = newEdgeWithID(source, target, edge.Properties(), edgeutil.ID(edges[0]))
+	}
+
+	ms.write()
+	ms.replaceEdge(source, target, i, edge)
+	if ms.ownedEdges != nil {
+		ms.ownedEdges[edgeutil.ID(edge)] = struct{}{}
+	}
 	return nil
 }
 
@@ -168,76 +321,483 @@ func (ms *memoryLedger[K, T]) RemoveVertex(key K) error {
 	ms.lock.Lock()
 	defer ms.lock.Unlock()
 
-	if _, exists := ms.vertices[key]; !exists {
+	return ms.removeVertex(key)
+}
+
+// removeVertex implements RemoveVertex without locking.
+func (ms *memoryLedger[K, T]) removeVertex(key K) error {
+	if _, exists := ms.vertices.Get(key); !exists {
 		return graph.ErrVertexNotFound
 	}
 
-	if len(ms.outEdges[key]) > 0 || len(ms.inEdges[key]) > 0 {
+	// A vertex is only held in outEdges and inEdges while it has edges.
+	if _, exists := ms.outEdges.Get(key); exists {
+		return graph.ErrVertexHasEdges
+	}
+	if _, exists := ms.inEdges.Get(key); exists {
 		return graph.ErrVertexHasEdges
 	}
 
-	delete(ms.vertices, key)
-	delete(ms.vertexProps, key)
-	delete(ms.outEdges, key)
-	delete(ms.inEdges, key)
+	ms.write()
+	ms.vertices.Delete(ms.owner, key)
 	return nil
 }
 
-// RemoveEdge removes an edge between the specified source and target vertices.
+// RemoveEdge removes every edge from source to target.
 // If the edge does not exist, ErrEdgeNotFound is returned.
 func (ms *memoryLedger[K, T]) RemoveEdge(source, target K) error {
 	ms.lock.Lock()
 	defer ms.lock.Unlock()
 
-	if _, exists := ms.outEdges[source][target]; !exists {
+	return ms.removeEdge(source, target)
+}
+
+// removeEdge implements RemoveEdge without locking.
+func (ms *memoryLedger[K, T]) removeEdge(source, target K) error {
+	edges := ms.edgesBetween(source, target)
+	if len(edges) == 0 {
 		return graph.ErrEdgeNotFound
 	}
 
-	delete(ms.outEdges[source], target)
-	delete(ms.inEdges[target], source)
-	ms.edgeCount--
+	ms.write()
+	for _, edge := range edges {
+		ms.forgetEdgeID(edgeutil.ID(edge), source, target)
+	}
+
+	ms.setEdges(&ms.outEdges, source, target, nil)
+	ms.setEdges(&ms.inEdges, target, source, nil)
 	return nil
 }
 
-// ListVertices retrieves all vertex hashes in the graph.
-func (ms *memoryLedger[K, T]) ListVertices() ([]K, error) {
+// RemoveEdgeByID removes every stored direction of the edge with the given ID.
+// If the edge does not exist, ErrEdgeNotFound is returned.
+func (ms *memoryLedger[K, T]) RemoveEdgeByID(id graph.EdgeID) error {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	return ms.removeEdgeByID(id)
+}
+
+// removeEdgeByID implements RemoveEdgeByID without locking.
+func (ms *memoryLedger[K, T]) removeEdgeByID(id graph.EdgeID) error {
+	pairs, exists := ms.edgeIDs.Get(id)
+	if !exists {
+		return graph.ErrEdgeNotFound
+	}
+
+	ms.write()
+	for _, pair := range pairs {
+		source, target := pair.source, pair.target
+		out := ms.edgesBetween(source, target)
+		in := edgesOf(&ms.inEdges, target, source)
+		i := indexOfEdge(out, id)
+
+		ms.setEdges(&ms.outEdges, source, target, append(out[:i:i], out[i+1:]...))
+		ms.setEdges(&ms.inEdges, target, source, append(in[:i:i], in[i+1:]...))
+	}
+
+	ms.edgeIDs.Delete(ms.owner, id)
+	return nil
+}
+
+// forgetEdgeID drops the source/target pair from the index entry of id.
+func (ms *memoryLedger[K, T]) forgetEdgeID(id graph.EdgeID, source, target K) {
+	pairs, _ := ms.edgeIDs.Get(id)
+	for i, pair := range pairs {
+		if pair.source == source && pair.target == target {
+			pairs = append(pairs[:i:i], pairs[i+1:]...)
+			break
+		}
+	}
+
+	if len(pairs) == 0 {
+		ms.edgeIDs.Delete(ms.owner, id)
+		return
+	}
+	ms.edgeIDs.Set(ms.owner, id, pairs)
+}
+
+// Version returns a number that increases with every change to the ledger.
+func (ms *memoryLedger[K, T]) Version() uint64 {
 	ms.lock.RLock()
 	defer ms.lock.RUnlock()
 
-	keys := make([]K, 0, len(ms.vertices))
-	for key := range ms.vertices {
-		keys = append(keys, key)
+	return ms.version
+}
+
+// Fork returns a copy of the ledger in constant time. The copy shares the
+// data of the ledger until either of them is changed; a change then copies
+// only the nodes of the sorted maps it touches.
+func (ms *memoryLedger[K, T]) Fork() (Store[K, T], error) {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	return ms.fork(), nil
+}
+
+// fork implements Fork without locking.
+func (ms *memoryLedger[K, T]) fork() *memoryLedger[K, T] {
+	if ms.share == nil {
+		ms.share = &sharing{}
+		runtime.AddCleanup(ms, (*sharing).release, ms.share)
+	}
+
+	// The fork also shares whatever the ledger still shares with its
+	// earlier forks, so it joins their generations.
+	generation := new(atomic.Int32)
+	generation.Store(2)
+	for _, g := range ms.share.generations {
+		g.Add(1)
+	}
+
+	child := &memoryLedger[K, T]{
+		vertices:      ms.vertices,
+		outEdges:      ms.outEdges,
+		inEdges:       ms.inEdges,
+		edgeIDs:       ms.edgeIDs,
+		lastEdgeID:    ms.lastEdgeID,
+		version:       ms.version,
+		owner:         new(sorted.Owner),
+		share:         &sharing{generations: append(slices.Clone(ms.share.generations), generation)},
+		ownedVertices: make(map[K]struct{}),
+		ownedEdges:    make(map[graph.EdgeID]struct{}),
+	}
+	runtime.AddCleanup(child, (*sharing).release, child.share)
+
+	ms.share.generations = append(ms.share.generations, generation)
+	ms.owner = new(sorted.Owner)
+	ms.ownedVertices = make(map[K]struct{})
+	ms.ownedEdges = make(map[graph.EdgeID]struct{})
+
+	return child
+}
+
+// release gives up the data the ledger shares with its forks, so they no
+// longer copy it before a change. The ledger must not be used afterwards.
+func (ms *memoryLedger[K, T]) release() {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	if ms.share != nil {
+		ms.share.release()
+	}
+}
+
+// write prepares the ledger for a change: it counts the change and, once no
+// fork shares data with the ledger any more, stops tracking the properties
+// it has copied. The sorted maps copy their shared nodes as they are touched.
+func (ms *memoryLedger[K, T]) write() {
+	ms.version++
+
+	if ms.share == nil || !ms.share.shared() {
+		ms.ownedVertices, ms.ownedEdges = nil, nil
+	}
+}
+
+// lockProperties locks the ledger for a read that returns properties and
+// returns the function that unlocks it. While properties may be shared with
+// a fork, such a read copies them and so takes the write lock.
+func (ms *memoryLedger[K, T]) lockProperties() func() {
+	ms.lock.RLock()
+	if ms.ownedVertices == nil {
+		return ms.lock.RUnlock
 	}
+	ms.lock.RUnlock()
 
-	// Sort hashes to ensure consistent ordering
-	sort.Slice(keys, func(i, j int) bool {
-		return keys[i] < keys[j] // Assumes K supports < via Ordered[K]
+	ms.lock.Lock()
+	return ms.lock.Unlock
+}
+
+// ownVertex returns the properties of the stored vertex with the given key,
+// first copying them if they may be shared with a fork.
+func (ms *memoryLedger[K, T]) ownVertex(key K, vertex storedVertex[T]) graph.VertexProperties {
+	properties := vertex.properties
+	if ms.ownedVertices == nil {
+		return properties
+	}
+	if _, ok := ms.ownedVertices[key]; ok {
+		return properties
+	}
+
+	ms.ownedVertices[key] = struct{}{}
+	p, ok := properties.(*VertexProperties)
+	if !ok {
+		return properties
+	}
+
+	vertex.properties = p.Clone()
+	ms.vertices.Set(ms.owner, key, vertex)
+	return vertex.properties
+}
+
+// ownEdge returns the stored edge, first replacing it in every direction with
+// a copy of its properties if they may be shared with a fork.
+func (ms *memoryLedger[K, T]) ownEdge(edge graph.Edge[K]) graph.Edge[K] {
+	id := edgeutil.ID(edge)
+	if ms.ownedEdges == nil {
+		return edge
+	}
+	if _, ok := ms.ownedEdges[id]; ok {
+		return edge
+	}
+
+	ms.ownedEdges[id] = struct{}{}
+	if edge.Properties() == nil {
+		return edge
+	}
+
+	properties := edge.Properties().Clone()
+	owned := edge
+	pairs, _ := ms.edgeIDs.Get(id)
+	for _, pair := range pairs {
+		copied := newEdgeWithID(pair.source, pair.target, properties, id)
+		ms.replaceEdge(pair.source, pair.target, indexOfEdge(ms.edgesBetween(pair.source, pair.target), id), copied)
+
+		if pair.source == edge.Source() && pair.target == edge.Target() {
+			owned = copied
+		}
+	}
+
+	return owned
+}
+
+// ownEdges replaces each of the edges with the result of ownEdge.
+func (ms *memoryLedger[K, T]) ownEdges(edges []graph.Edge[K]) []graph.Edge[K] {
+	if ms.ownedEdges != nil {
+		for i, edge := range edges {
+			edges[i] = ms.ownEdge(edge)
+		}
+	}
+
+	return edges
+}
+
+// edgesBetween returns the stored edges from source to target, which must
+// not be changed.
+func (ms *memoryLedger[K, T]) edgesBetween(source, target K) []graph.Edge[K] {
+	return edgesOf(&ms.outEdges, source, target)
+}
+
+// replaceEdge replaces the edge at position i of the edges from source to
+// target, in both indexes.
+func (ms *memoryLedger[K, T]) replaceEdge(source, target K, i int, edge graph.Edge[K]) {
+	out := slices.Clone(ms.edgesBetween(source, target))
+	out[i] = edge
+	ms.setEdges(&ms.outEdges, source, target, out)
+
+	in := slices.Clone(edgesOf(&ms.inEdges, target, source))
+	in[i] = edge
+	ms.setEdges(&ms.inEdges, target, source, in)
+}
+
+// setEdges stores edges under key and other in index, one of the edge
+// indexes, or removes them if edges is empty. A vertex left without edges is
+// removed from the index.
+func (ms *memoryLedger[K, T]) setEdges(index *sorted.Map[K, adjacency[K]], key, other K, edges []graph.Edge[K]) {
+	adjacent, _ := index.Get(key)
+	if len(edges) > 0 {
+		adjacent.Set(ms.owner, other, edges)
+	} else {
+		adjacent.Delete(ms.owner, other)
+	}
+
+	if adjacent.Len() > 0 {
+		index.Set(ms.owner, key, adjacent)
+	} else {
+		index.Delete(ms.owner, key)
+	}
+}
+
+// edgesOf returns the edges stored under key and other in index, one of the
+// edge indexes.
+func edgesOf[K graph.Ordered](index *sorted.Map[K, adjacency[K]], key, other K) []graph.Edge[K] {
+	adjacent, _ := index.Get(key)
+	edges, _ := adjacent.Get(other)
+	return edges
+}
+
+// byID returns edges ordered by ID, copying them first if they are not.
+func byID[K graph.Ordered](edges []graph.Edge[K]) []graph.Edge[K] {
+	compare := func(a, b graph.Edge[K]) int {
+		return cmp.Compare(edgeutil.ID(a), edgeutil.ID(b))
+	}
+	if slices.IsSortedFunc(edges, compare) {
+		return edges
+	}
+
+	edges = slices.Clone(edges)
+	slices.SortFunc(edges, compare)
+	return edges
+}
+
+// sharing tracks the fork generations of a ledger. A generation is a fork
+// and the ledger it was forked from, counted while both may still share
+// data.
+type sharing struct {
+	generations []*atomic.Int32
+	once        sync.Once
+}
+
+// shared reports whether any generation has another member left. If none
+// has, the generations are dropped.
+func (s *sharing) shared() bool {
+	for _, g := range s.generations {
+		if g.Load() > 1 {
+			return true
+		}
+	}
+
+	s.generations = nil
+	return false
+}
+
+// release leaves every generation. It is called at most once, when the
+// ledger is released or garbage collected.
+func (s *sharing) release() {
+	s.once.Do(func() {
+		for _, g := range s.generations {
+			g.Add(-1)
+		}
 	})
+}
 
-	return keys, nil
+// ListVertices retrieves all vertex hashes in the graph.
+func (ms *memoryLedger[K, T]) ListVertices() ([]K, error) {
+	ms.lock.RLock()
+	defer ms.lock.RUnlock()
+
+	return ms.listVertices()
+}
+
+// listVertices implements ListVertices without locking.
+func (ms *memoryLedger[K, T]) listVertices() ([]K, error) {
+	return ms.vertices.Keys(), nil
 }
 
 // ListEdges retrieves all edges in the graph as a slice.
 func (ms *memoryLedger[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	defer ms.lockProperties()()
+
+	return ms.listEdges()
+}
+
+// listEdges implements ListEdges without locking.
+func (ms *memoryLedger[K, T]) listEdges() ([]graph.Edge[K], error) {
+	// The indexes are sorted by source and then by target, so the edges
+	// only need sorting by ID within each pair.
+	allEdges := make([]graph.Edge[K], 0, ms.edgeIDs.Len())
+	for _, targets := range ms.outEdges.All() {
+		for _, edges := range targets.All() {
+			allEdges = append(allEdges, byID(edges)...)
+		}
+	}
+
+	return ms.ownEdges(allEdges), nil
+}
+
+// PageVertices returns up to limit vertex keys in ascending order, starting
+// after the given key. See Pager.
+func (ms *memoryLedger[K, T]) PageVertices(after *K, limit int) ([]K, error) {
 	ms.lock.RLock()
 	defer ms.lock.RUnlock()
 
-	allEdges := make([]graph.Edge[K], 0, ms.edgeCount)
-	for _, targets := range ms.outEdges {
-		for _, edge := range targets {
-			allEdges = append(allEdges, edge)
+	vertices := ms.vertices.All()
+	if after != nil {
+		vertices = ms.vertices.After(*after)
+	}
+
+	keys := make([]K, 0, max(min(limit, ms.vertices.Len()), 0))
+	for key := range vertices {
+		if len(keys) >= limit {
+			break
 		}
+		keys = append(keys, key)
 	}
 
-	// Sort edges by source, then by target
-	sort.Slice(allEdges, func(i, j int) bool {
-		if allEdges[i].Source() == allEdges[j].Source() {
-			return allEdges[i].Target() < allEdges[j].Target()
+	return keys, nil
+}
+
+// PageEdges returns up to limit edges in the order of ListEdges, starting
+// after the given edge. See Pager.
+func (ms *memoryLedger[K, T]) PageEdges(after graph.Edge[K], limit int) ([]graph.Edge[K], error) {
+	defer ms.lockProperties()()
+
+	page := make([]graph.Edge[K], 0, max(min(limit, ms.edgeIDs.Len()), 0))
+
+	// fill adds the edges of a source/target pair that follow after to the
+	// page and reports whether it has room for more.
+	fill := func(edges []graph.Edge[K]) bool {
+		for _, edge := range byID(edges) {
+			if len(page) >= limit {
+				return false
+			}
+			if after == nil || edgeutil.Compare(after, edge) < 0 {
+				page = append(page, edge)
+			}
 		}
-		return allEdges[i].Source() < allEdges[j].Source()
-	})
+		return len(page) < limit
+	}
 
-	return allEdges, nil
+	fillTargets := func(targets iter.Seq2[K, []graph.Edge[K]]) bool {
+		for _, edges := range targets {
+			if !fill(edges) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// The page starts in the edges of the source of after, from its target,
+	// and continues with the sources after it.
+	sources := ms.outEdges.All()
+	if after != nil {
+		targets, _ := ms.outEdges.Get(after.Source())
+		edges, _ := targets.Get(after.Target())
+		if !fill(edges) || !fillTargets(targets.After(after.Target())) {
+			return ms.ownEdges(page), nil
+		}
+		sources = ms.outEdges.After(after.Source())
+	}
+
+	for _, targets := range sources {
+		if !fillTargets(targets.All()) {
+			break
+		}
+	}
+
+	return ms.ownEdges(page), nil
+}
+
+// OutEdges returns the edges leaving the vertex, ordered by target and ID.
+// See EdgeIndex.
+func (ms *memoryLedger[K, T]) OutEdges(key K) ([]graph.Edge[K], error) {
+	defer ms.lockProperties()()
+
+	return ms.incidentEdges(&ms.outEdges, key)
+}
+
+// InEdges returns the edges entering the vertex, ordered by source and ID.
+// See EdgeIndex.
+func (ms *memoryLedger[K, T]) InEdges(key K) ([]graph.Edge[K], error) {
+	defer ms.lockProperties()()
+
+	return ms.incidentEdges(&ms.inEdges, key)
+}
+
+// incidentEdges returns the edges of the vertex with the given key in one of
+// the edge indexes, ordered by the other vertex and ID.
+func (ms *memoryLedger[K, T]) incidentEdges(index *sorted.Map[K, adjacency[K]], key K) ([]graph.Edge[K], error) {
+	if _, exists := ms.vertices.Get(key); !exists {
+		return nil, graph.ErrVertexNotFound
+	}
+
+	var edges []graph.Edge[K]
+	adjacent, _ := index.Get(key)
+	for _, between := range adjacent.All() {
+		edges = append(edges, byID(between)...)
+	}
+
+	return ms.ownEdges(edges), nil
 }
 
 // CountVertices returns the total number of vertices in the ledger.
@@ -245,15 +805,41 @@ func (ms *memoryLedger[K, T]) CountVertices() (int, error) {
 	ms.lock.RLock()
 	defer ms.lock.RUnlock()
 
-	return len(ms.vertices), nil
+	return ms.countVertices()
+}
+
+// countVertices implements CountVertices without locking.
+func (ms *memoryLedger[K, T]) countVertices() (int, error) {
+	return ms.vertices.Len(), nil
 }
 
-// CountEdges returns the total number of edges in the ledger.
+// CountEdges returns the total number of edges in the ledger. An edge stored
+// in both directions under the same ID is counted once.
 func (ms *memoryLedger[K, T]) CountEdges() (int, error) {
 	ms.lock.RLock()
 	defer ms.lock.RUnlock()
 
-	return ms.edgeCount, nil
+	return ms.countEdges()
+}
+
+// countEdges implements CountEdges without locking.
+func (ms *memoryLedger[K, T]) countEdges() (int, error) {
+	return ms.edgeIDs.Len(), nil
+}
+
+// tuple is a source/target pair.
+type tuple[K comparable] struct {
+	source, target K
+}
+
+// indexOfEdge returns the position of the edge with the given ID in edges, or -1.
+func indexOfEdge[K comparable](edges []graph.Edge[K], id graph.EdgeID) int {
+	for i, edge := range edges {
+		if edgeutil.ID(edge) == id {
+			return i
+		}
+	}
+	return -1
 }
 
 // WouldCreateCycle checks if adding an edge from source to target would create a cycle in the graph.
@@ -271,11 +857,16 @@ func (ms *memoryLedger[K, T]) WouldCreateCycle(source, target K) (bool, error) {
 	ms.lock.RLock()
 	defer ms.lock.RUnlock()
 
+	return ms.wouldCreateCycle(source, target)
+}
+
+// wouldCreateCycle implements WouldCreateCycle without locking.
+func (ms *memoryLedger[K, T]) wouldCreateCycle(source, target K) (bool, error) {
 	// Verify that both the source and target vertices exist
-	if _, exists := ms.vertices[source]; !exists {
+	if _, exists := ms.vertices.Get(source); !exists {
 		return false, fmt.Errorf("could not get vertex with hash %v: %w", source, graph.ErrVertexNotFound)
 	}
-	if _, exists := ms.vertices[target]; !exists {
+	if _, exists := ms.vertices.Get(target); !exists {
 		return false, fmt.Errorf("could not get vertex with hash %v: %w", target, graph.ErrVertexNotFound)
 	}
 
@@ -302,10 +893,9 @@ func (ms *memoryLedger[K, T]) WouldCreateCycle(source, target K) (bool, error) {
 			visited[current] = struct{}{}
 
 			// Traverse adjacent vertices using the inEdges map
-			if neighbors, exists := ms.inEdges[current]; exists {
-				for neighbor := range neighbors {
-					stack.Push(neighbor)
-				}
+			neighbors, _ := ms.inEdges.Get(current)
+			for neighbor := range neighbors.All() {
+				stack.Push(neighbor)
 			}
 		}
 	}
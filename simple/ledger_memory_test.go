@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,7 +17,7 @@ func TestVertexOperations(t *testing.T) {
 	t.Parallel()
 	is := assert.New(t)
 
-	s, err := newMemoryStore[int, string]()
+	s, err := NewMemoryStore[int, string]()
 	is.NoError(err)
 
 	v := NewVertexWithOptions(1, "A", VertexItems(map[string]any{
@@ -80,7 +81,7 @@ func TestEdgeOperations(t *testing.T) {
 	t.Parallel()
 	is := assert.New(t)
 
-	s, err := newMemoryStore[int, string]()
+	s, err := NewMemoryStore[int, string]()
 	is.NoError(err)
 
 	// Add vertices for edges
@@ -144,7 +145,7 @@ func TestCycleDetection(t *testing.T) {
 	t.Parallel()
 	is := assert.New(t)
 
-	s, err := newMemoryStore[int, string]()
+	s, err := NewMemoryStore[int, string]()
 	is.NoError(err)
 
 	// Add vertices
@@ -168,3 +169,47 @@ func TestCycleDetection(t *testing.T) {
 	is.NoError(err)
 	is.True(hasCycle)
 }
+
+func TestParallelEdgeOperations(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	s, err := NewMemoryStore[int, string]()
+	is.NoError(err)
+	is.NoError(s.AddVertex(1, "A", &VertexProperties{}))
+	is.NoError(s.AddVertex(2, "B", &VertexProperties{}))
+
+	first, second := s.NextEdgeID(), s.NextEdgeID()
+	is.NoError(s.AddEdge(1, 2, newEdgeWithID(1, 2, NewEdgeWithOptions(1, 2, EdgeWeight(1)).Properties(), first)))
+	is.NoError(s.AddEdge(1, 2, newEdgeWithID(1, 2, NewEdgeWithOptions(1, 2, EdgeWeight(2)).Properties(), second)))
+
+	// Edges without an ID are assigned the next one.
+	is.NoError(s.AddEdge(2, 1, NewEdgeWithOptions(2, 1)))
+	is.Equal(graph.EdgeID(4), s.NextEdgeID())
+
+	count, _ := s.CountEdges()
+	is.Equal(3, count)
+
+	edges, err := s.FindEdges(1, 2)
+	is.NoError(err)
+	is.Len(edges, 2)
+
+	edge, err := s.FindEdge(1, 2)
+	is.NoError(err)
+	is.Equal(first, edgeutil.ID(edge), "FindEdge should return the first parallel edge")
+
+	is.NoError(s.ModifyEdge(1, 2, newEdgeWithID(1, 2, NewEdgeWithOptions(1, 2, EdgeWeight(7)).Properties(), second)))
+	edge, err = s.FindEdgeByID(second)
+	is.NoError(err)
+	is.Equal(float64(7), edge.Properties().Weight())
+
+	is.NoError(s.RemoveEdgeByID(first))
+	edges, _ = s.FindEdges(1, 2)
+	is.Len(edges, 1)
+
+	is.NoError(s.RemoveEdge(1, 2))
+	_, err = s.FindEdgeByID(second)
+	is.ErrorIs(err, graph.ErrEdgeNotFound)
+	count, _ = s.CountEdges()
+	is.Equal(1, count)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingStore is an instrumented Store that counts the edges added through it.
+type countingStore[K comparable, T any] struct {
+	Store[K, T]
+	edges int
+}
+
+func (s *countingStore[K, T]) AddEdge(source, target K, edge graph.Edge[K]) error {
+	s.edges++
+	return s.Store.AddEdge(source, target, edge)
+}
+
+func (s *countingStore[K, T]) New() (Store[K, T], error) {
+	inner, err := s.Store.New()
+	if err != nil {
+		return nil, err
+	}
+	return &countingStore[K, T]{Store: inner}, nil
+}
+
+// cycleCheckingStore is a countingStore that also provides the CycleDetector
+// fast path.
+type cycleCheckingStore[K comparable, T any] struct {
+	*countingStore[K, T]
+	checks int
+}
+
+func (s *cycleCheckingStore[K, T]) WouldCreateCycle(source, target K) (bool, error) {
+	s.checks++
+	return s.Store.(CycleDetector[K]).WouldCreateCycle(source, target)
+}
+
+func TestNewWithStore(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	inner, err := NewMemoryStore[int, int]()
+	is.NoError(err)
+	store := &countingStore[int, int]{Store: inner}
+
+	g, err := NewWithStore(graph.IntHash, store, graph.Directed(), graph.PreventCycles())
+	is.NoError(err)
+
+	for i := 1; i <= 3; i++ {
+		is.NoError(g.AddVertexWithOptions(i))
+	}
+	is.NoError(g.AddEdgeWithOptions(1, 2))
+	is.NoError(g.AddEdgeWithOptions(2, 3))
+	is.ErrorIs(g.AddEdgeWithOptions(3, 1), graph.ErrEdgeCreatesCycle)
+	is.Equal(2, store.edges)
+
+	size, err := g.Size()
+	is.NoError(err)
+	is.Equal(2, size)
+
+	// Clones are backed by a new store of the same kind.
+	clone, err := g.Clone()
+	is.NoError(err)
+	is.NoError(clone.AddEdgeWithOptions(1, 3))
+	is.Equal(2, store.edges)
+	ok, _ := g.HasEdge(1, 3)
+	is.False(ok)
+}
+
+func TestNewWithStore_CycleDetector(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	inner, err := NewMemoryStore[int, int]()
+	is.NoError(err)
+	store := &cycleCheckingStore[int, int]{countingStore: &countingStore[int, int]{Store: inner}}
+
+	g, err := NewWithStore(graph.IntHash, store, graph.Directed(), graph.PreventCycles())
+	is.NoError(err)
+
+	for i := 1; i <= 3; i++ {
+		is.NoError(g.AddVertexWithOptions(i))
+	}
+	is.NoError(g.AddEdgeWithOptions(1, 2))
+	is.NoError(g.AddEdgeWithOptions(2, 3))
+	is.ErrorIs(g.AddEdgeWithOptions(3, 1), graph.ErrEdgeCreatesCycle)
+	is.Equal(3, store.checks)
+	is.Equal(2, store.edges)
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
+)
+
+// observers holds the hooks and subscriptions of a graph and implements
+// graph.Observable for it.
+type observers[K graph.Ordered] struct {
+	// lock guards hooks and subscriptions. Both slices are replaced rather
+	// than modified in place, so a copy taken under the lock stays valid.
+	lock          sync.RWMutex
+	hooks         []*hook[K]
+	subscriptions []*subscription[K]
+
+	// changes serializes checking, applying and publishing each change, so
+	// that every subscription receives the events in the order of the
+	// changes.
+	changes sync.Mutex
+
+	// parent is set for the observers of a batch. Hooks are those of the
+	// parent, and events are kept in pending until the batch is committed.
+	parent  *observers[K]
+	pending []graph.Event[K]
+}
+
+// hook is a registered hook. It is a pointer so that it can be found again
+// for removal.
+type hook[K graph.Ordered] struct {
+	fn func(graph.Event[K]) error
+}
+
+// batch returns the observers for a batch of changes to the graph observed by
+// o.
+func (o *observers[K]) batch() *observers[K] {
+	return &observers[K]{parent: o}
+}
+
+// Hook registers a hook that is called before every change. See
+// graph.Observable.
+func (o *observers[K]) Hook(fn func(graph.Event[K]) error) func() {
+	if o.parent != nil {
+		return o.parent.Hook(fn)
+	}
+
+	h := &hook[K]{fn: fn}
+
+	o.lock.Lock()
+	o.hooks = append(o.hooks[:len(o.hooks):len(o.hooks)], h)
+	o.lock.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			o.lock.Lock()
+			defer o.lock.Unlock()
+
+			hooks := make([]*hook[K], 0, len(o.hooks))
+			for _, other := range o.hooks {
+				if other != h {
+					hooks = append(hooks, other)
+				}
+			}
+			o.hooks = hooks
+		})
+	}
+}
+
+// Subscribe creates a subscription that receives an event after every
+// change. See graph.Observable.
+func (o *observers[K]) Subscribe(options ...func(*graph.SubscriptionOptions)) graph.Subscription[K] {
+	if o.parent != nil {
+		return o.parent.Subscribe(options...)
+	}
+
+	var opts graph.SubscriptionOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	s := &subscription[K]{
+		owner:  o,
+		policy: opts.Backpressure,
+		events: make(chan graph.Event[K], max(opts.Buffer, 0)),
+		done:   make(chan struct{}),
+	}
+
+	o.lock.Lock()
+	o.subscriptions = append(o.subscriptions[:len(o.subscriptions):len(o.subscriptions)], s)
+	o.lock.Unlock()
+
+	return s
+}
+
+// unsubscribe removes s from the subscriptions.
+func (o *observers[K]) unsubscribe(s *subscription[K]) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	subscriptions := make([]*subscription[K], 0, len(o.subscriptions))
+	for _, other := range o.subscriptions {
+		if other != s {
+			subscriptions = append(subscriptions, other)
+		}
+	}
+	o.subscriptions = subscriptions
+}
+
+// change makes the change described by events by calling apply, provided
+// that the hooks accept every event, and then publishes the events. Changes
+// to the graph are made one at a time; the observers of a batch rely on the
+// batch holding the lock of its graph instead.
+func (o *observers[K]) change(apply func() error, events ...graph.Event[K]) error {
+	return o.update(func() ([]graph.Event[K], error) {
+		return events, nil
+	}, apply)
+}
+
+// update is change for a change whose events depend on the state of the
+// graph. describe reads that state and returns the events under the same lock
+// as apply, so that no other change can come between them and the events
+// describe the state the change is applied to.
+func (o *observers[K]) update(describe func() ([]graph.Event[K], error), apply func() error) error {
+	if o.parent == nil {
+		o.changes.Lock()
+		defer o.changes.Unlock()
+	}
+
+	events, err := describe()
+	if err != nil {
+		return err
+	}
+
+	if err := o.check(events...); err != nil {
+		return err
+	}
+
+	if err := apply(); err != nil {
+		return err
+	}
+
+	o.publish(events...)
+	return nil
+}
+
+// check calls the hooks with each event and returns the first error, wrapped
+// in ErrChangeVetoed.
+func (o *observers[K]) check(events ...graph.Event[K]) error {
+	if o.parent != nil {
+		return o.parent.check(events...)
+	}
+
+	o.lock.RLock()
+	hooks := o.hooks
+	o.lock.RUnlock()
+
+	for _, event := range events {
+		for _, h := range hooks {
+			if err := h.fn(event); err != nil {
+				return fmt.Errorf("%w: %w", graph.ErrChangeVetoed, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// publish delivers the events to every subscription. The observers of a
+// batch keep them until the batch is committed. The caller must hold the
+// changes lock of the graph.
+func (o *observers[K]) publish(events ...graph.Event[K]) {
+	if o.parent != nil {
+		o.pending = append(o.pending, events...)
+		return
+	}
+
+	o.lock.RLock()
+	subscriptions := o.subscriptions
+	o.lock.RUnlock()
+
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	for _, event := range events {
+		for _, s := range subscriptions {
+			s.send(event)
+		}
+	}
+}
+
+// removedEdgeEvents returns an EdgeRemoved event for each of the edges.
+func removedEdgeEvents[K graph.Ordered](edges []graph.Edge[K]) []graph.Event[K] {
+	events := make([]graph.Event[K], 0, len(edges))
+	for _, edge := range edges {
+		events = append(events, graph.Event[K]{
+			Type:       graph.EdgeRemoved,
+			Source:     edge.Source(),
+			Target:     edge.Target(),
+			EdgeID:     edgeutil.ID(edge),
+			EdgeBefore: edge.Properties(),
+		})
+	}
+
+	return events
+}
+
+// subscription is the graph.Subscription returned by Subscribe.
+type subscription[K graph.Ordered] struct {
+	owner   *observers[K]
+	policy  graph.Backpressure
+	events  chan graph.Event[K]
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	// lock guards closing events against sending to it.
+	lock   sync.Mutex
+	closed bool
+	once   sync.Once
+}
+
+func (s *subscription[K]) Events() <-chan graph.Event[K] {
+	return s.events
+}
+
+func (s *subscription[K]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+func (s *subscription[K]) Close() {
+	s.once.Do(func() {
+		// Release a change blocked in send before taking the lock.
+		close(s.done)
+		s.owner.unsubscribe(s)
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		s.closed = true
+		close(s.events)
+	})
+}
+
+// send delivers event according to the backpressure policy.
+func (s *subscription[K]) send(event graph.Event[K]) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	switch s.policy {
+	case graph.BackpressureDropNewest:
+		select {
+		case s.events <- event:
+		default:
+			s.dropped.Add(1)
+		}
+
+	case graph.BackpressureDropOldest:
+		for {
+			select {
+			case s.events <- event:
+				return
+			default:
+			}
+
+			select {
+			case <-s.events:
+				s.dropped.Add(1)
+			default:
+				// An unbuffered channel without a receiver holds no
+				// older event to drop.
+				s.dropped.Add(1)
+				return
+			}
+		}
+
+	default:
+		select {
+		case s.events <- event:
+		case <-s.done:
+		}
+	}
+}
+
+func (d *directedGraph[K, T]) Hook(hook func(graph.Event[K]) error) func() {
+	return d.events.Hook(hook)
+}
+
+func (d *directedGraph[K, T]) Subscribe(options ...func(*graph.SubscriptionOptions)) graph.Subscription[K] {
+	return d.events.Subscribe(options...)
+}
+
+func (u *undirected[K, T]) Hook(hook func(graph.Event[K]) error) func() {
+	return u.events.Hook(hook)
+}
+
+func (u *undirected[K, T]) Subscribe(options ...func(*graph.SubscriptionOptions)) graph.Subscription[K] {
+	return u.events.Subscribe(options...)
+}
+
+func (t *rootedTree[K, T]) Hook(hook func(graph.Event[K]) error) func() {
+	return t.Interface.(graph.Observable[K]).Hook(hook)
+}
+
+func (t *rootedTree[K, T]) Subscribe(options ...func(*graph.SubscriptionOptions)) graph.Subscription[K] {
+	return t.Interface.(graph.Observable[K]).Subscribe(options...)
+}
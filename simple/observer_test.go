@@ -0,0 +1,330 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+// drain returns the events waiting in the channel of sub.
+func drain[K graph.Ordered](sub graph.Subscription[K]) []graph.Event[K] {
+	var events []graph.Event[K]
+	for {
+		select {
+		case e := <-sub.Events():
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestObservable_Events(t *testing.T) {
+	t.Parallel()
+
+	for _, directed := range []bool{true, false} {
+		is := assert.New(t)
+
+		options := []func(*graph.Traits){graph.MultiGraph()}
+		if directed {
+			options = append(options, graph.Directed())
+		}
+		g, err := New(graph.StringHash, options...)
+		is.NoError(err)
+
+		sub := g.(graph.Observable[string]).Subscribe(graph.SubscriptionBuffer(32))
+
+		is.NoError(g.AddVertexWithOptions("A", VertexWeight(1)))
+		is.NoError(g.AddVertexWithOptions("B"))
+		is.NoError(g.SetVertexWithOptions("A", VertexWeight(2)))
+		is.NoError(g.AddEdgeWithOptions("A", "B", EdgeWeight(3)))
+		is.NoError(g.AddEdgeWithOptions("A", "B", EdgeWeight(4)))
+		is.NoError(g.SetEdgeWithOptions("A", "B", EdgeWeight(5)))
+		is.NoError(g.RemoveEdge("A", "B"))
+		is.NoError(g.RemoveVertex("B"))
+		is.Error(g.RemoveVertex("B"))
+
+		events := drain(sub)
+		types := make([]graph.EventType, 0, len(events))
+		for _, e := range events {
+			types = append(types, e.Type)
+		}
+		is.Equal([]graph.EventType{
+			graph.VertexAdded, graph.VertexAdded, graph.VertexModified,
+			graph.EdgeAdded, graph.EdgeAdded, graph.EdgeModified,
+			graph.EdgeRemoved, graph.EdgeRemoved, graph.VertexRemoved,
+		}, types)
+
+		is.Equal("A", events[2].Vertex)
+		is.Equal(float64(1), events[2].VertexBefore.Weight())
+		is.Equal(float64(2), events[2].VertexAfter.Weight())
+
+		added := events[3]
+		is.Equal("A", added.Source)
+		is.Equal("B", added.Target)
+		is.NotZero(added.EdgeID)
+		is.Nil(added.EdgeBefore)
+
+		modified := events[5]
+		is.Equal(added.EdgeID, modified.EdgeID)
+		is.Equal(float64(3), modified.EdgeBefore.Weight())
+		is.Equal(float64(5), modified.EdgeAfter.Weight())
+
+		is.Equal(added.EdgeID, events[6].EdgeID)
+		is.Equal(float64(5), events[6].EdgeBefore.Weight())
+		is.Nil(events[6].EdgeAfter)
+		is.Equal("B", events[8].Vertex)
+
+		sub.Close()
+		_, ok := <-sub.Events()
+		is.False(ok)
+		is.NoError(g.AddVertexWithOptions("C"))
+	}
+}
+
+func TestObservable_Veto(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.StringHash, graph.Directed())
+	is.NoError(err)
+	o := g.(graph.Observable[string])
+	sub := o.Subscribe(graph.SubscriptionBuffer(8))
+
+	frozen := errors.New("vertex A is frozen")
+	remove := o.Hook(func(e graph.Event[string]) error {
+		if e.Vertex == "A" && e.Type != graph.VertexAdded || e.Target == "A" {
+			return frozen
+		}
+		return nil
+	})
+
+	is.NoError(g.AddVertexWithOptions("A"))
+	is.NoError(g.AddVertexWithOptions("B"))
+	err = g.SetVertexWithOptions("A", VertexWeight(9))
+	is.ErrorIs(err, graph.ErrChangeVetoed)
+	is.ErrorIs(err, frozen)
+	is.ErrorIs(g.AddEdgeWithOptions("B", "A"), graph.ErrChangeVetoed)
+	is.ErrorIs(g.RemoveVertex("A"), graph.ErrChangeVetoed)
+
+	v, _ := g.Vertex("A")
+	is.Zero(v.Properties().Weight())
+	ok, _ := g.HasEdge("B", "A")
+	is.False(ok)
+	is.Len(drain(sub), 2, "vetoed changes are not published")
+
+	remove()
+	is.NoError(g.RemoveVertex("A"))
+	is.Len(drain(sub), 1)
+}
+
+func TestObservable_Backpressure(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.IntHash)
+	is.NoError(err)
+	o := g.(graph.Observable[int])
+
+	newest := o.Subscribe(graph.SubscriptionBuffer(2), graph.SubscriptionBackpressure(graph.BackpressureDropNewest))
+	oldest := o.Subscribe(graph.SubscriptionBuffer(2), graph.SubscriptionBackpressure(graph.BackpressureDropOldest))
+	blocking := o.Subscribe()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= 5; i++ {
+			is.NoError(g.AddVertexWithOptions(i))
+		}
+	}()
+
+	// A blocking subscription receives every event.
+	for i := 1; i <= 5; i++ {
+		e := <-blocking.Events()
+		is.Equal(i, e.Vertex)
+	}
+	<-done
+
+	var keys []int
+	for _, e := range drain(newest) {
+		keys = append(keys, e.Vertex)
+	}
+	is.Equal([]int{1, 2}, keys)
+	is.Equal(uint64(3), newest.Dropped())
+
+	keys = nil
+	for _, e := range drain(oldest) {
+		keys = append(keys, e.Vertex)
+	}
+	is.Equal([]int{4, 5}, keys)
+	is.Equal(uint64(3), oldest.Dropped())
+
+	// Closing a blocking subscription releases a waiting change.
+	go func() {
+		blocking.Close()
+	}()
+	is.NoError(g.AddVertexWithOptions(6))
+}
+
+func TestObservable_ConcurrentOrder(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.IntHash)
+	is.NoError(err)
+
+	// The subscription is unbuffered, so writers queue up to deliver their
+	// events while it is read.
+	sub := g.(graph.Observable[int]).Subscribe()
+	received := make(chan []graph.Event[int])
+	go func() {
+		var events []graph.Event[int]
+		for e := range sub.Events() {
+			events = append(events, e)
+		}
+		received <- events
+	}()
+
+	// Writers race to add and remove the same vertex, so its events must
+	// alternate between additions and removals.
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				_ = g.AddVertexWithOptions(1)
+				_ = g.RemoveVertex(1)
+			}
+		}()
+	}
+	wg.Wait()
+	sub.Close()
+
+	events := <-received
+	is.NotEmpty(events)
+	for i, e := range events {
+		expected := graph.VertexAdded
+		if i%2 == 1 {
+			expected = graph.VertexRemoved
+		}
+		if !is.Equal(expected, e.Type, "event %d", i) {
+			break
+		}
+	}
+}
+
+func TestObservable_ConcurrentBefore(t *testing.T) {
+	t.Parallel()
+
+	for _, directed := range []bool{true, false} {
+		is := assert.New(t)
+
+		options := []func(*graph.Traits){graph.MultiGraph()}
+		if directed {
+			options = append(options, graph.Directed())
+		}
+		g, err := New(graph.IntHash, options...)
+		is.NoError(err)
+		is.NoError(g.AddVertexWithOptions(1))
+		is.NoError(g.AddVertexWithOptions(2))
+
+		// The hook yields while the changes lock is held, so other writers
+		// get to run up to the lock even on a single CPU.
+		observable := g.(graph.Observable[int])
+		observable.Hook(func(graph.Event[int]) error {
+			runtime.Gosched()
+			return nil
+		})
+
+		sub := observable.Subscribe()
+		received := make(chan []graph.Event[int])
+		go func() {
+			var events []graph.Event[int]
+			for e := range sub.Events() {
+				events = append(events, e)
+			}
+			received <- events
+		}()
+
+		// Writers race to insert, modify and remove parallel edges, so the
+		// state an event describes as before may be changing under it.
+		multi := g.(graph.Multigraph[int, int])
+		var wg sync.WaitGroup
+		for w := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range 100 {
+					_, _ = multi.InsertEdge(1, 2, EdgeWeight(float64(w)))
+					_ = g.SetEdgeWithOptions(1, 2, EdgeWeight(float64(w*1000+i)))
+					if i%3 == 0 {
+						_ = g.RemoveEdge(1, 2)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		_ = g.RemoveEdge(1, 2)
+		sub.Close()
+
+		// Replaying the events must account for every edge, with each
+		// before matching the after of the previous event on the edge.
+		weights := make(map[graph.EdgeID]float64)
+		for i, e := range <-received {
+			switch e.Type {
+			case graph.EdgeAdded:
+				weights[e.EdgeID] = e.EdgeAfter.Weight()
+			case graph.EdgeModified:
+				is.Equal(weights[e.EdgeID], e.EdgeBefore.Weight(), "event %d", i)
+				weights[e.EdgeID] = e.EdgeAfter.Weight()
+			case graph.EdgeRemoved:
+				is.Contains(weights, e.EdgeID, "event %d", i)
+				is.Equal(weights[e.EdgeID], e.EdgeBefore.Weight(), "event %d", i)
+				delete(weights, e.EdgeID)
+			}
+		}
+		is.Empty(weights)
+	}
+}
+
+func TestObservable_Batch(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.IntHash, graph.Directed())
+	is.NoError(err)
+	o := g.(graph.Observable[int])
+	sub := o.Subscribe(graph.SubscriptionBuffer(8))
+
+	checked := 0
+	o.Hook(func(graph.Event[int]) error {
+		checked++
+		return nil
+	})
+
+	batcher := g.(graph.Batcher[int, int])
+	is.Error(batcher.Batch(func(tx graph.Interface[int, int]) error {
+		is.NoError(tx.AddVertexWithOptions(1))
+		return errors.New("failure")
+	}))
+	is.Equal(1, checked)
+	is.Empty(drain(sub), "a rolled back batch publishes nothing")
+
+	is.NoError(batcher.Batch(func(tx graph.Interface[int, int]) error {
+		is.NoError(tx.AddVertexWithOptions(1))
+		is.NoError(tx.AddVertexWithOptions(2))
+		is.Empty(drain(sub), "events wait for the commit")
+		return tx.AddEdgeWithOptions(1, 2)
+	}))
+	is.Len(drain(sub), 3)
+}
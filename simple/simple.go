@@ -6,8 +6,6 @@
 package simple
 
 import (
-	"strconv"
-
 	"github.com/sixafter/graph"
 )
 
@@ -15,14 +13,14 @@ import (
 // type K. These hash values will be obtained using the provided hash function.
 //
 // The graph will use the default in-memory ledger for persisting vertices and
-// edges. To use a different [Store], use [newWithStore].
+// edges. To use a different [Store], use [NewWithStore].
 func New[K graph.Ordered, T any](hash graph.Hash[K, T], options ...func(*graph.Traits)) (graph.Interface[K, T], error) {
-	s, err := newMemoryStore[K, T]()
+	s, err := NewMemoryStore[K, T]()
 	if err != nil {
 		return nil, err
 	}
 
-	return newWithStore(hash, s, options...)
+	return NewWithStore(hash, s, options...)
 }
 
 // NewLike creates a graph that is "like" the given graph: It has the same type,
@@ -38,55 +36,66 @@ func NewLike[K graph.Ordered, T any](g graph.Interface[K, T]) (graph.Interface[K
 		*t = *g.Traits().Clone()
 	}
 
-	s, err := newMemoryStore[K, T]()
+	s, err := NewMemoryStore[K, T]()
 	if err != nil {
 		return nil, err
 	}
 
-	return newWithStore(g.Hash(), s, traits)
+	return NewWithStore(g.Hash(), s, traits)
 }
 
-// newWithStore creates a new graph using the specified hash function, ledger
-// backend, and optional traits.
-func newWithStore[K graph.Ordered, T any](hash graph.Hash[K, T], store ledger[K, T], options ...func(*graph.Traits)) (graph.Interface[K, T], error) {
+// NewWithStore creates a new graph using the specified hash function, store
+// backend, and optional traits. The store should be empty; the graph takes
+// ownership of it and clones of the graph are backed by store.New().
+//
+//	s, _ := simple.NewMemoryStore[int, int]()
+//	g, _ := simple.NewWithStore(graph.IntHash, s, graph.Directed())
+func NewWithStore[K graph.Ordered, T any](hash graph.Hash[K, T], store Store[K, T], options ...func(*graph.Traits)) (graph.Interface[K, T], error) {
 	var p graph.Traits
 
 	for _, option := range options {
 		option(&p)
 	}
 
+	var (
+		g   graph.Interface[K, T]
+		err error
+	)
 	if p.IsDirected {
-		return newDirectedGraph(hash, &p, store)
+		g, err = newDirectedGraph(hash, &p, store)
+	} else {
+		g, err = newUndirected(hash, &p, store)
+	}
+	if err != nil || !p.IsRooted {
+		return g, err
 	}
 
-	return newUndirected(hash, &p, store)
+	return newRootedTree(g)
 }
 
-// Cursor represents the state of streaming operations in the graph.
+// Cursor represents the state of streaming operations in the graph. It holds
+// the last vertex key or edge sent by a stream, encoded as JSON, so a stream
+// resumed with it continues after that item even if the graph has changed in
+// the meantime. An empty state starts at the beginning of the stream.
 type Cursor struct {
-	position int64
+	state []byte
 }
 
-// State serializes the current cursor position into a byte slice by converting it to a string first.
+// State returns a copy of the serialized cursor state.
 func (c *Cursor) State() []byte {
-	return []byte(strconv.FormatInt(c.position, 10))
+	return append([]byte{}, c.state...)
 }
 
-// SetState deserializes the cursor position from a byte slice by converting it back from a string.
+// SetState replaces the cursor state with a copy of state. The state is
+// validated by the stream it is passed to.
 func (c *Cursor) SetState(state []byte) error {
-	pos, err := strconv.ParseInt(string(state), 10, 64)
-	if err != nil {
-		return err
-	}
-	c.position = pos
+	c.state = append(c.state[:0:0], state...)
 	return nil
 }
 
-// EmptyCursor creates a new cursor with newly initialized state.
+// EmptyCursor creates a new cursor that starts at the beginning of a stream.
 func EmptyCursor() graph.Cursor {
-	return &Cursor{
-		position: 0,
-	}
+	return &Cursor{}
 }
 
 // Edge represents a connection between two vertices in a graph.
@@ -107,6 +116,9 @@ type Edge[T any] struct {
 	// Properties Contains additional information or metadata about the edge,
 	// such as weight, capacity, or any custom v.
 	properties graph.EdgeProperties
+
+	// id is the ID assigned to the edge by its graph, or zero.
+	id graph.EdgeID
 }
 
 type EdgeOption func(properties *EdgeProperties)
@@ -137,6 +149,22 @@ func NewEdgeWithOptions[T any](source, target T, options ...graph.EdgeOption) gr
 	}
 }
 
+// newEdgeWithID creates an edge carrying the given ID.
+func newEdgeWithID[T any](source, target T, properties graph.EdgeProperties, id graph.EdgeID) graph.Edge[T] {
+	return &Edge[T]{
+		source:     source,
+		target:     target,
+		properties: properties,
+		id:         id,
+	}
+}
+
+// ID returns the ID assigned to the edge by its graph, or zero for an edge
+// that has not been added to a graph.
+func (e *Edge[T]) ID() graph.EdgeID {
+	return e.id
+}
+
 func (e *Edge[T]) Source() T {
 	return e.source
 }
@@ -154,6 +182,7 @@ func (e *Edge[T]) Clone() graph.Edge[T] {
 		source:     e.source,
 		target:     e.target,
 		properties: e.properties.Clone(),
+		id:         e.id,
 	}
 }
 
@@ -337,8 +366,9 @@ func (p *VertexProperties) Metadata() any {
 // Clone creates a deep copy of the Vertex properties and returns the new instance.
 func (p *VertexProperties) Clone() graph.VertexProperties {
 	clone := VertexProperties{
-		v:      make(map[string]any),
-		weight: p.weight,
+		v:        make(map[string]any),
+		metadata: p.metadata,
+		weight:   p.weight,
 	}
 
 	for k, v := range p.v {
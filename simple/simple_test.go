@@ -77,20 +77,24 @@ func TestDirectedAcyclicGraph(t *testing.T) {
 
 	// Verify adjacency map
 	adjacency, _ := g.AdjacencyMap()
+	// Edges carry the IDs assigned in the order they were added.
+	edge := func(source, target string, id graph.EdgeID) graph.Edge[string] {
+		return newEdgeWithID(source, target, NewEdgeWithOptions(source, target, EdgeWeight(0)).Properties(), id)
+	}
 	expectedAdjacency := map[string]map[string]graph.Edge[string]{
 		"A": {
 
-			"B": NewEdgeWithOptions("A", "B", EdgeWeight(0)),
-			"C": NewEdgeWithOptions("A", "C", EdgeWeight(0)),
+			"B": edge("A", "B", 1),
+			"C": edge("A", "C", 2),
 		},
 		"B": {
-			"D": NewEdgeWithOptions("B", "D", EdgeWeight(0)),
+			"D": edge("B", "D", 3),
 		},
 		"C": {
-			"D": NewEdgeWithOptions("C", "D", EdgeWeight(0)),
+			"D": edge("C", "D", 4),
 		},
 		"D": {
-			"E": NewEdgeWithOptions("D", "E", EdgeWeight(0)),
+			"E": edge("D", "E", 5),
 		},
 		"E": {}, // No outgoing edges
 	}
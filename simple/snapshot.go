@@ -0,0 +1,320 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sixafter/graph"
+)
+
+// Versioned is an optional interface for a Store that can copy itself in
+// constant time. Graphs backed by a Versioned store implement
+// graph.Snapshotter. The in-memory store and FileStore implement it.
+type Versioned[K comparable, T any] interface {
+	// Version returns a number that increases with every change to the
+	// store.
+	Version() uint64
+
+	// Fork returns an independent copy of the store with the same version.
+	// The copy shares the data of the store until either of them is
+	// changed, so forking must not copy the data.
+	//
+	// Returns:
+	//   - The copy of the store.
+	//   - An error if the store cannot be copied.
+	Fork() (Store[K, T], error)
+}
+
+// releaser is implemented by stores that can give up the data they share
+// with other stores before they are garbage collected.
+type releaser interface {
+	release()
+}
+
+// Snapshot returns a read-only snapshot of the current version of the
+// graph. See graph.Snapshotter.
+func (d *directedGraph[K, T]) Snapshot() (graph.Snapshot[K, T], error) {
+	return newSnapshot(d.hash, d.traits, d.store)
+}
+
+// Snapshot returns a read-only snapshot of the current version of the
+// graph. See graph.Snapshotter.
+func (u *undirected[K, T]) Snapshot() (graph.Snapshot[K, T], error) {
+	return newSnapshot(u.hash, u.traits, u.store)
+}
+
+// Snapshot returns a read-only snapshot of the current version of the tree.
+// The snapshot is a plain graph without the rooted tree queries.
+func (t *rootedTree[K, T]) Snapshot() (graph.Snapshot[K, T], error) {
+	return t.Interface.(graph.Snapshotter[K, T]).Snapshot()
+}
+
+// newSnapshot creates a snapshot of the graph with the given hash and traits
+// from a fork of its store.
+func newSnapshot[K graph.Ordered, T any](hash graph.Hash[K, T], traits *graph.Traits, store Store[K, T]) (graph.Snapshot[K, T], error) {
+	versioned, ok := store.(Versioned[K, T])
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", graph.ErrSnapshotsNotSupported, store)
+	}
+
+	fork, err := versioned.Fork()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &snapshotStore[K, T]{store: fork}
+	if v, ok := fork.(Versioned[K, T]); ok {
+		s.version = v.Version()
+	}
+
+	traits = traits.Clone()
+	traits.IsRooted = false
+
+	var g graph.Interface[K, T]
+	if traits.IsDirected {
+		g, err = newDirectedGraph(hash, traits, Store[K, T](s))
+	} else {
+		g, err = newUndirected(hash, traits, Store[K, T](s))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot[K, T]{Interface: g, store: s}, nil
+}
+
+// snapshot is the graph.Snapshot returned by Snapshot. It is a graph over a
+// snapshotStore.
+type snapshot[K graph.Ordered, T any] struct {
+	graph.Interface[K, T]
+	store *snapshotStore[K, T]
+}
+
+func (s *snapshot[K, T]) Version() uint64 {
+	return s.store.version
+}
+
+func (s *snapshot[K, T]) Release() {
+	s.store.release()
+}
+
+// Clone returns a modifiable graph that shares the data of the snapshot
+// until it is changed.
+func (s *snapshot[K, T]) Clone() (graph.Interface[K, T], error) {
+	store, err := s.store.Fork()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToCloneGraph, err)
+	}
+
+	traits := s.Traits().Clone()
+	return NewWithStore(s.Hash(), store, func(t *graph.Traits) {
+		*t = *traits
+	})
+}
+
+// snapshotStore is a read-only Store over a fork. Changes return
+// ErrImmutableGraph, and every operation returns ErrSnapshotReleased once
+// the store has been released.
+type snapshotStore[K graph.Ordered, T any] struct {
+	store   Store[K, T]
+	version uint64
+
+	// lock makes release wait for the reads in progress, so the data the
+	// fork shares is not changed while they run.
+	lock     sync.RWMutex
+	released bool
+}
+
+// release marks the store as released and lets the fork give up its shared
+// data.
+func (s *snapshotStore[K, T]) release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.released {
+		return
+	}
+	s.released = true
+
+	if r, ok := s.store.(releaser); ok {
+		r.release()
+	}
+}
+
+// read runs fn unless the store has been released.
+func (s *snapshotStore[K, T]) read(fn func() error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.released {
+		return graph.ErrSnapshotReleased
+	}
+
+	return fn()
+}
+
+func (s *snapshotStore[K, T]) New() (Store[K, T], error) {
+	return NewMemoryStore[K, T]()
+}
+
+func (s *snapshotStore[K, T]) Version() uint64 {
+	return s.version
+}
+
+func (s *snapshotStore[K, T]) Fork() (Store[K, T], error) {
+	var fork Store[K, T]
+	err := s.read(func() (err error) {
+		fork, err = s.store.(Versioned[K, T]).Fork()
+		return err
+	})
+	return fork, err
+}
+
+func (s *snapshotStore[K, T]) FindVertex(key K) (T, graph.VertexProperties, error) {
+	var (
+		value      T
+		properties graph.VertexProperties
+	)
+	err := s.read(func() (err error) {
+		value, properties, err = s.store.FindVertex(key)
+		return err
+	})
+	return value, properties, err
+}
+
+func (s *snapshotStore[K, T]) ListVertices() ([]K, error) {
+	var keys []K
+	err := s.read(func() (err error) {
+		keys, err = s.store.ListVertices()
+		return err
+	})
+	return keys, err
+}
+
+func (s *snapshotStore[K, T]) CountVertices() (int, error) {
+	var count int
+	err := s.read(func() (err error) {
+		count, err = s.store.CountVertices()
+		return err
+	})
+	return count, err
+}
+
+func (s *snapshotStore[K, T]) FindEdge(source, target K) (graph.Edge[K], error) {
+	var edge graph.Edge[K]
+	err := s.read(func() (err error) {
+		edge, err = s.store.FindEdge(source, target)
+		return err
+	})
+	return edge, err
+}
+
+func (s *snapshotStore[K, T]) FindEdges(source, target K) ([]graph.Edge[K], error) {
+	var edges []graph.Edge[K]
+	err := s.read(func() (err error) {
+		edges, err = s.store.FindEdges(source, target)
+		return err
+	})
+	return edges, err
+}
+
+func (s *snapshotStore[K, T]) FindEdgeByID(id graph.EdgeID) (graph.Edge[K], error) {
+	var edge graph.Edge[K]
+	err := s.read(func() (err error) {
+		edge, err = s.store.FindEdgeByID(id)
+		return err
+	})
+	return edge, err
+}
+
+func (s *snapshotStore[K, T]) ListEdges() ([]graph.Edge[K], error) {
+	var edges []graph.Edge[K]
+	err := s.read(func() (err error) {
+		edges, err = s.store.ListEdges()
+		return err
+	})
+	return edges, err
+}
+
+func (s *snapshotStore[K, T]) CountEdges() (int, error) {
+	var count int
+	err := s.read(func() (err error) {
+		count, err = s.store.CountEdges()
+		return err
+	})
+	return count, err
+}
+
+func (s *snapshotStore[K, T]) PageVertices(after *K, limit int) ([]K, error) {
+	var keys []K
+	err := s.read(func() (err error) {
+		keys, err = pageVertices(s.store, after, limit)
+		return err
+	})
+	return keys, err
+}
+
+func (s *snapshotStore[K, T]) PageEdges(after graph.Edge[K], limit int) ([]graph.Edge[K], error) {
+	var edges []graph.Edge[K]
+	err := s.read(func() (err error) {
+		edges, err = pageEdges(s.store, after, limit)
+		return err
+	})
+	return edges, err
+}
+
+func (s *snapshotStore[K, T]) OutEdges(key K) ([]graph.Edge[K], error) {
+	var edges []graph.Edge[K]
+	err := s.read(func() (err error) {
+		edges, err = outEdges(s.store, key)
+		return err
+	})
+	return edges, err
+}
+
+func (s *snapshotStore[K, T]) InEdges(key K) ([]graph.Edge[K], error) {
+	var edges []graph.Edge[K]
+	err := s.read(func() (err error) {
+		edges, err = inEdges(s.store, key)
+		return err
+	})
+	return edges, err
+}
+
+func (s *snapshotStore[K, T]) AddVertex(K, T, graph.VertexProperties) error {
+	return graph.ErrImmutableGraph
+}
+
+func (s *snapshotStore[K, T]) ModifyVertex(K, graph.VertexProperties) error {
+	return graph.ErrImmutableGraph
+}
+
+func (s *snapshotStore[K, T]) RemoveVertex(K) error {
+	return graph.ErrImmutableGraph
+}
+
+func (s *snapshotStore[K, T]) AddEdge(K, K, graph.Edge[K]) error {
+	return graph.ErrImmutableGraph
+}
+
+func (s *snapshotStore[K, T]) ModifyEdge(K, K, graph.Edge[K]) error {
+	return graph.ErrImmutableGraph
+}
+
+func (s *snapshotStore[K, T]) RemoveEdge(K, K) error {
+	return graph.ErrImmutableGraph
+}
+
+func (s *snapshotStore[K, T]) RemoveEdgeByID(graph.EdgeID) error {
+	return graph.ErrImmutableGraph
+}
+
+// NextEdgeID returns no ID, as no edges can be added.
+func (s *snapshotStore[K, T]) NextEdgeID() graph.EdgeID {
+	return 0
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot_Isolation(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.StringHash, graph.Directed(), graph.MultiGraph())
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "B", EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "C", EdgeWeight(3)))
+
+	s, err := g.(graph.Snapshotter[string, string]).Snapshot()
+	is.NoError(err)
+	defer s.Release()
+
+	is.NoError(g.AddVertexWithOptions("D"))
+	is.NoError(g.AddEdgeWithOptions("C", "D"))
+	is.NoError(g.SetEdgeWithOptions("A", "B", EdgeWeight(10)))
+	is.NoError(g.SetVertexWithOptions("A", VertexWeight(5)))
+	is.NoError(g.RemoveEdge("B", "C"))
+	is.NoError(g.RemoveEdge("A", "B"))
+	is.NoError(g.RemoveVertex("B"))
+
+	order, _ := s.Order()
+	is.Equal(3, order)
+	size, _ := s.Size()
+	is.Equal(3, size)
+	e, err := s.Edge("A", "B")
+	is.NoError(err)
+	is.Equal(float64(1), e.Properties().Weight())
+	v, _ := s.Vertex("A")
+	is.Zero(v.Properties().Weight())
+	ok, _ := s.HasEdge("B", "C")
+	is.True(ok)
+
+	order, _ = g.Order()
+	is.Equal(3, order)
+	size, _ = g.Size()
+	is.Equal(1, size)
+
+	// Versions count the changes made before each snapshot.
+	later, err := g.(graph.Snapshotter[string, string]).Snapshot()
+	is.NoError(err)
+	defer later.Release()
+	is.Equal(s.Version()+7, later.Version())
+}
+
+func TestSnapshot_ImmutableAndClone(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.IntHash)
+	is.NoError(err)
+	is.NoError(g.AddVertexWithOptions(1))
+	is.NoError(g.AddVertexWithOptions(2))
+	is.NoError(g.AddEdgeWithOptions(1, 2))
+
+	s, err := g.(graph.Snapshotter[int, int]).Snapshot()
+	is.NoError(err)
+
+	is.ErrorIs(s.AddVertexWithOptions(3), graph.ErrImmutableGraph)
+	is.ErrorIs(s.SetEdgeWithOptions(2, 1, EdgeWeight(3)), graph.ErrImmutableGraph)
+	is.ErrorIs(s.RemoveEdge(1, 2), graph.ErrImmutableGraph)
+
+	clone, err := s.Clone()
+	is.NoError(err)
+	is.NoError(clone.AddVertexWithOptions(3))
+	is.NoError(clone.AddEdgeWithOptions(2, 3))
+	is.NoError(clone.RemoveEdge(1, 2))
+	is.NoError(g.AddVertexWithOptions(4))
+
+	size, _ := s.Size()
+	is.Equal(1, size)
+	ok, _ := g.HasEdge(2, 3)
+	is.False(ok)
+	ok, _ = g.HasEdge(1, 2)
+	is.True(ok)
+	ok, _ = clone.HasVertex(4)
+	is.False(ok)
+
+	s.Release()
+	_, err = s.Order()
+	is.ErrorIs(err, graph.ErrSnapshotReleased)
+	_, err = s.Clone()
+	is.ErrorIs(err, graph.ErrFailedToCloneGraph)
+	s.Release()
+}
+
+func TestSnapshot_Release(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	store, err := NewMemoryStore[int, int]()
+	is.NoError(err)
+	ms := store.(*memoryLedger[int, int])
+	g, err := NewWithStore(graph.IntHash, store, graph.Directed())
+	is.NoError(err)
+	is.NoError(g.AddVertexWithOptions(1))
+	is.NoError(g.AddVertexWithOptions(2))
+
+	s, err := g.(graph.Snapshotter[int, int]).Snapshot()
+	is.NoError(err)
+
+	// While the snapshot is held, changes copy the data they touch.
+	is.NoError(g.AddEdgeWithOptions(1, 2))
+	is.NotNil(ms.ownedEdges)
+
+	// Once it is released, the graph owns its data again.
+	s.Release()
+	is.NoError(g.AddEdgeWithOptions(2, 1))
+	is.Nil(ms.ownedEdges)
+	is.Nil(ms.share.generations)
+}
+
+func TestSnapshot_ConcurrentWriter(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.IntHash, graph.Directed())
+	is.NoError(err)
+	for i := 0; i < 50; i++ {
+		is.NoError(g.AddVertexWithOptions(i))
+		if i > 0 {
+			is.NoError(g.AddEdgeWithOptions(i-1, i, EdgeWeight(float64(i))))
+		}
+	}
+
+	s, err := g.(graph.Snapshotter[int, int]).Snapshot()
+	is.NoError(err)
+	defer s.Release()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 50; i < 150; i++ {
+			if err := g.AddVertexWithOptions(i); err != nil {
+				panic(err)
+			}
+			if err := g.AddEdgeWithOptions(i-1, i); err != nil {
+				panic(err)
+			}
+			if err := g.SetEdgeWithOptions(i%50, i%50+1, EdgeWeight(0)); err != nil {
+				panic(err)
+			}
+		}
+	}()
+
+	for round := 0; round < 20; round++ {
+		adjacency, err := s.AdjacencyMap()
+		is.NoError(err)
+		is.Len(adjacency, 50)
+
+		total := 0.0
+		for _, targets := range adjacency {
+			for _, e := range targets {
+				total += e.Properties().Weight()
+			}
+		}
+		is.Equal(float64(49*50/2), total, fmt.Sprintf("round %d", round))
+	}
+	wg.Wait()
+
+	order, _ := g.Order()
+	is.Equal(150, order)
+}
+
+func TestSnapshot_FileStore(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	store, g := openFileGraph(t, t.TempDir())
+	defer store.Close()
+	is.NoError(g.AddVertexWithOptions("A"))
+
+	s, err := g.(graph.Snapshotter[string, string]).Snapshot()
+	is.NoError(err)
+	defer s.Release()
+	is.NoError(g.AddVertexWithOptions("B"))
+
+	order, _ := s.Order()
+	is.Equal(1, order)
+
+	// Stores that cannot fork do not support snapshots.
+	inner, _ := NewMemoryStore[int, int]()
+	c, _ := NewWithStore(graph.IntHash, &countingStore[int, int]{Store: inner})
+	_, err = c.(graph.Snapshotter[int, int]).Snapshot()
+	is.ErrorIs(err, graph.ErrSnapshotsNotSupported)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
+	"github.com/sixafter/graph/internal/stream"
+)
+
+// Pager is an optional interface for a Store that can read its vertices and
+// edges a page at a time, without listing all of them. Streams read their
+// pages from the store through it, falling back to ListVertices and ListEdges
+// for other stores. The in-memory store and FileStore implement it.
+type Pager[K graph.Ordered, T any] interface {
+	// PageVertices returns up to limit vertex keys in ascending order.
+	//
+	// Parameters:
+	//   - after: The key the page starts after, or nil for the first page.
+	//   - limit: The maximum number of keys to return.
+	//
+	// Returns:
+	//   - The keys following after. Fewer than limit keys are returned only
+	//     for the last page.
+	//   - An error if the vertices cannot be read.
+	PageVertices(after *K, limit int) ([]K, error)
+
+	// PageEdges returns up to limit edges ordered by source, target and edge
+	// ID, as ListEdges does.
+	//
+	// Parameters:
+	//   - after: The edge the page starts after, or nil for the first page.
+	//     Only its source, target and ID are used, so it does not need to be
+	//     in the store.
+	//   - limit: The maximum number of edges to return.
+	//
+	// Returns:
+	//   - The edges following after. Fewer than limit edges are returned
+	//     only for the last page.
+	//   - An error if the edges cannot be read.
+	PageEdges(after graph.Edge[K], limit int) ([]graph.Edge[K], error)
+}
+
+// streamVertices implements StreamVerticesWithContext for a graph over store.
+// The cursor holds the key of the last vertex sent, so a resumed stream
+// continues with the next key that exists at that time, whatever was added or
+// removed in between.
+func streamVertices[K graph.Ordered, T any](ctx context.Context, store Store[K, T], cursor graph.Cursor, limit int, ch chan<- []graph.Vertex[K, T]) (graph.Cursor, error) {
+	defer close(ch) // Ensure the channel is closed when the function returns
+
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than zero")
+	}
+
+	var after *K
+	position, err := stream.ReadCursor[stream.VertexPosition[K]](cursor)
+	if err != nil {
+		return nil, err
+	}
+	if position != nil {
+		after = &position.Key
+	}
+
+	for {
+		keys, err := pageVertices(store, after, limit)
+		if err != nil {
+			return cursor, fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+		}
+		if len(keys) == 0 {
+			return cursor, nil
+		}
+
+		batch := make([]graph.Vertex[K, T], 0, len(keys))
+		for _, key := range keys {
+			value, properties, err := store.FindVertex(key)
+			if errors.Is(err, graph.ErrVertexNotFound) {
+				continue // Removed since the page was read
+			}
+			if err != nil {
+				return cursor, fmt.Errorf("%w: %v", graph.ErrFailedToGetVertex, key)
+			}
+			batch = append(batch, NewVertex(key, value, properties))
+		}
+
+		if len(batch) > 0 {
+			select {
+			case <-ctx.Done(): // Handle cancellation
+				return cursor, ctx.Err()
+			case ch <- batch: // Send batch to channel
+			}
+		}
+
+		after = &keys[len(keys)-1]
+		if err = stream.WriteCursor(cursor, stream.VertexPosition[K]{Key: *after}); err != nil {
+			return cursor, err
+		}
+
+		if len(keys) < limit {
+			return cursor, nil
+		}
+	}
+}
+
+// streamEdges implements StreamEdgesWithContext for a graph over store. Only
+// the edges accepted by include are sent. The cursor holds the last edge
+// sent, so a resumed stream continues with the next edge that exists at that
+// time, whatever was added or removed in between.
+func streamEdges[K graph.Ordered, T any](ctx context.Context, store Store[K, T], cursor graph.Cursor, limit int, ch chan<- graph.Edge[K], include func(graph.Edge[K]) bool) (graph.Cursor, error) {
+	defer close(ch) // Ensure the channel is closed when the function returns
+
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than zero")
+	}
+
+	var after graph.Edge[K]
+	position, err := stream.ReadCursor[stream.EdgePosition[K]](cursor)
+	if err != nil {
+		return nil, err
+	}
+	if position != nil {
+		after = newEdgeWithID(position.Source, position.Target, nil, position.ID)
+	}
+
+	for {
+		edges, err := pageEdges(store, after, limit)
+		if err != nil {
+			return cursor, fmt.Errorf("failed to get edges: %w", err)
+		}
+
+		for _, edge := range edges {
+			if include(edge) {
+				select {
+				case <-ctx.Done(): // Handle cancellation
+					return cursor, ctx.Err()
+				case ch <- edge: // Send each edge to the channel
+				}
+			}
+
+			after = edge
+			err = stream.WriteCursor(cursor, stream.EdgePositionOf(edge))
+			if err != nil {
+				return cursor, err
+			}
+		}
+
+		if len(edges) < limit {
+			return cursor, nil
+		}
+	}
+}
+
+// pageVertices returns the page of vertex keys of store following after.
+func pageVertices[K graph.Ordered, T any](store Store[K, T], after *K, limit int) ([]K, error) {
+	if p, ok := store.(Pager[K, T]); ok {
+		return p.PageVertices(after, limit)
+	}
+
+	keys, err := store.ListVertices()
+	if err != nil {
+		return nil, err
+	}
+
+	return stream.SelectPage(slices.Values(keys), limit, func(key K) bool {
+		return after == nil || *after < key
+	}, cmp.Compare[K]), nil
+}
+
+// pageEdges returns the page of edges of store following after.
+func pageEdges[K graph.Ordered, T any](store Store[K, T], after graph.Edge[K], limit int) ([]graph.Edge[K], error) {
+	if p, ok := store.(Pager[K, T]); ok {
+		return p.PageEdges(after, limit)
+	}
+
+	edges, err := store.ListEdges()
+	if err != nil {
+		return nil, err
+	}
+
+	return stream.SelectPage(slices.Values(edges), limit, func(edge graph.Edge[K]) bool {
+		return after == nil || edgeutil.Compare(after, edge) < 0
+	}, edgeutil.Compare[K]), nil
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// streamKeys streams the vertices of g from cursor and returns their keys.
+func streamKeys(t *testing.T, g graph.Interface[string, string], cursor graph.Cursor, limit int) []string {
+	ch := make(chan []graph.Vertex[string, string])
+	done := make(chan error, 1)
+	go func() {
+		_, err := g.StreamVerticesWithContext(context.Background(), cursor, limit, ch)
+		done <- err
+	}()
+
+	var keys []string
+	for batch := range ch {
+		for _, v := range batch {
+			keys = append(keys, v.ID())
+		}
+	}
+	assert.NoError(t, <-done)
+
+	return keys
+}
+
+// streamEdgeIDs streams the edges of g from cursor and returns their IDs.
+func streamEdgeIDs(t *testing.T, g graph.Interface[string, string], cursor graph.Cursor, limit int) []graph.EdgeID {
+	ch := make(chan graph.Edge[string])
+	done := make(chan error, 1)
+	go func() {
+		_, err := g.StreamEdgesWithContext(context.Background(), cursor, limit, ch)
+		done <- err
+	}()
+
+	var ids []graph.EdgeID
+	for e := range ch {
+		ids = append(ids, edgeutil.ID(e))
+	}
+	assert.NoError(t, <-done)
+
+	return ids
+}
+
+func TestStream_ResumeVertices(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.StringHash, graph.Directed())
+	is.NoError(err)
+	for _, v := range []string{"C", "A", "B"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+
+	cursor := EmptyCursor()
+	is.Equal([]string{"A", "B", "C"}, streamKeys(t, g, cursor, 2))
+
+	// A resumed stream continues after the last key sent, regardless of the
+	// vertices added or removed before it in the meantime.
+	for _, v := range []string{"E", "Bb", "D"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.RemoveVertex("A"))
+	is.NoError(g.RemoveVertex("C"))
+	is.Equal([]string{"D", "E"}, streamKeys(t, g, cursor, 1))
+
+	// The cursor can be saved and restored.
+	restored := EmptyCursor()
+	is.NoError(restored.SetState(cursor.State()))
+	is.NoError(g.AddVertexWithOptions("F"))
+	is.Equal([]string{"F"}, streamKeys(t, g, restored, 5))
+
+	is.NoError(restored.SetState([]byte("not a position")))
+	_, err = g.StreamVerticesWithContext(context.Background(), restored, 1, make(chan []graph.Vertex[string, string]))
+	is.Error(err)
+}
+
+func TestStream_ResumeEdges(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.StringHash, graph.Directed(), graph.MultiGraph())
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B"))
+	is.NoError(g.AddEdgeWithOptions("B", "C"))
+
+	cursor := EmptyCursor()
+	is.Len(streamEdgeIDs(t, g, cursor, 1), 2)
+
+	// A parallel edge after the last one sent is streamed; edges added before
+	// it are not.
+	m := g.(graph.Multigraph[string, string])
+	id, err := m.InsertEdge("B", "C")
+	is.NoError(err)
+	_, err = m.InsertEdge("A", "C")
+	is.NoError(err)
+	is.NoError(g.RemoveEdge("B", "C"))
+	later, err := m.InsertEdge("C", "A")
+	is.NoError(err)
+
+	ids := streamEdgeIDs(t, g, cursor, 2)
+	is.Equal([]graph.EdgeID{later}, ids)
+	is.NotContains(ids, id)
+
+	// Undirected graphs stream each edge once, however the pages split the
+	// two directions it is stored in.
+	u, err := New(graph.StringHash)
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C"} {
+		is.NoError(u.AddVertexWithOptions(v))
+	}
+	is.NoError(u.AddEdgeWithOptions("C", "A"))
+	is.NoError(u.AddEdgeWithOptions("A", "B"))
+	is.NoError(u.AddEdgeWithOptions("B", "B"))
+	for limit := 1; limit <= 4; limit++ {
+		is.Len(streamEdgeIDs(t, u, EmptyCursor(), limit), 3)
+	}
+}
+
+func TestStream_Pager(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	store, err := NewMemoryStore[int, int]()
+	is.NoError(err)
+	g, err := NewWithStore(graph.IntHash, store, graph.Directed(), graph.MultiGraph())
+	is.NoError(err)
+	for i := 0; i < 20; i++ {
+		is.NoError(g.AddVertexWithOptions((i * 7) % 20))
+	}
+	for i := 0; i < 20; i++ {
+		is.NoError(g.AddEdgeWithOptions(i, (i*3)%20))
+		is.NoError(g.AddEdgeWithOptions((i*3)%20, i))
+	}
+
+	// Parallel edges added out of the order of their IDs are paged by ID.
+	is.NoError(store.AddEdge(4, 5, newEdgeWithID(4, 5, nil, 1000)))
+	is.NoError(store.AddEdge(4, 5, newEdgeWithID(4, 5, nil, 999)))
+
+	pager := store.(Pager[int, int])
+
+	var keys []int
+	var after *int
+	for {
+		page, err := pager.PageVertices(after, 3)
+		is.NoError(err)
+		keys = append(keys, page...)
+		if len(page) < 3 {
+			break
+		}
+		after = &page[len(page)-1]
+	}
+	all, _ := store.ListVertices()
+	is.Equal(all, keys)
+
+	var edges []graph.Edge[int]
+	var last graph.Edge[int]
+	for {
+		page, err := pager.PageEdges(last, 7)
+		is.NoError(err)
+		edges = append(edges, page...)
+		if len(page) < 7 {
+			break
+		}
+		last = page[len(page)-1]
+	}
+	listed, _ := store.ListEdges()
+	is.Equal(listed, edges)
+
+	// A page starts after the given edge, which need not be in the store.
+	page, err := pager.PageEdges(newEdgeWithID(4, 5, nil, 999), 3)
+	is.NoError(err)
+	i := slices.IndexFunc(listed, func(e graph.Edge[int]) bool {
+		return edgeutil.ID(e) == 1000
+	})
+	is.Equal(listed[i:i+3], page)
+	page, err = pager.PageEdges(NewEdgeWithOptions(4, 4), 1)
+	is.NoError(err)
+	is.Equal(listed[i-1:i], page)
+
+	// Stores that do not page are paged from their lists.
+	plain, err := NewWithStore(graph.IntHash, &countingStore[int, int]{Store: store}, graph.Directed(), graph.MultiGraph())
+	is.NoError(err)
+	ch := make(chan graph.Edge[int])
+	go func() {
+		_, err := plain.StreamEdgesWithContext(context.Background(), EmptyCursor(), 4, ch)
+		is.NoError(err)
+	}()
+	var streamed []graph.Edge[int]
+	for e := range ch {
+		streamed = append(streamed, e)
+	}
+	is.Equal(listed, streamed)
+}
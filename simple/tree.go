@@ -0,0 +1,596 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sixafter/graph"
+)
+
+// rootedTree wraps a directed or undirected graph and enforces the invariants
+// of a rooted tree on it, as described by graph.RootedTree. It keeps the
+// parent and children of every vertex in the tree, so tree queries do not
+// need to walk the edges of the graph.
+type rootedTree[K graph.Ordered, T any] struct {
+	graph.Interface[K, T]
+
+	// lock guards the tree structure below and makes checking and adding an
+	// edge atomic.
+	lock sync.RWMutex
+
+	// root is the root of the tree, valid if hasRoot is set.
+	root    K
+	hasRoot bool
+
+	// parents maps every vertex of the tree except the root to its parent.
+	parents map[K]K
+
+	// children maps every vertex of the tree to its children.
+	children map[K]map[K]struct{}
+}
+
+// newRootedTree wraps g, deriving the tree structure from the vertices and
+// edges it already holds, such as those added by a Builder. It returns an
+// error if they do not form a tree.
+func newRootedTree[K graph.Ordered, T any](g graph.Interface[K, T]) (*rootedTree[K, T], error) {
+	t := &rootedTree[K, T]{
+		Interface: g,
+		parents:   make(map[K]K),
+		children:  make(map[K]map[K]struct{}),
+	}
+
+	adjacencyMap, err := g.AdjacencyMap()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetAdjacencyMap, err)
+	}
+	if len(adjacencyMap) == 0 {
+		return t, nil
+	}
+
+	keys := make([]K, 0, len(adjacencyMap))
+	for key := range adjacencyMap {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	// The root is the vertex without a parent that has edges, or the first
+	// vertex if there are no edges.
+	t.root, t.hasRoot = keys[0], true
+	if g.Traits().IsDirected {
+		predecessorMap, err := g.PredecessorMap()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetAdjacencyMap, err)
+		}
+		for _, key := range keys {
+			if len(predecessorMap[key]) == 0 && len(adjacencyMap[key]) > 0 {
+				t.root = key
+				break
+			}
+		}
+	} else {
+		for _, key := range keys {
+			if len(adjacencyMap[key]) > 0 {
+				t.root = key
+				break
+			}
+		}
+	}
+
+	queue := []K{t.root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for neighbor := range adjacencyMap[current] {
+			if parent, ok := t.parents[current]; ok && parent == neighbor && !g.Traits().IsDirected {
+				continue
+			}
+			if t.inTree(neighbor) {
+				return nil, fmt.Errorf("%w: %v -> %v", graph.ErrEdgeCreatesCycle, current, neighbor)
+			}
+			t.link(current, neighbor)
+			queue = append(queue, neighbor)
+		}
+	}
+
+	for _, key := range keys {
+		if len(adjacencyMap[key]) > 0 && !t.inTree(key) {
+			return nil, fmt.Errorf("%w: %v", graph.ErrVertexNotInTree, key)
+		}
+	}
+
+	return t, nil
+}
+
+// inTree reports whether the vertex is the root or has a parent.
+func (t *rootedTree[K, T]) inTree(hash K) bool {
+	if t.hasRoot && hash == t.root {
+		return true
+	}
+	_, ok := t.parents[hash]
+	return ok
+}
+
+// link records child as a child of parent.
+func (t *rootedTree[K, T]) link(parent, child K) {
+	t.parents[child] = parent
+	if t.children[parent] == nil {
+		t.children[parent] = make(map[K]struct{})
+	}
+	t.children[parent][child] = struct{}{}
+}
+
+// unlink detaches child from its parent.
+func (t *rootedTree[K, T]) unlink(child K) {
+	parent, ok := t.parents[child]
+	if !ok {
+		return
+	}
+
+	delete(t.parents, child)
+	delete(t.children[parent], child)
+	if len(t.children[parent]) == 0 {
+		delete(t.children, parent)
+	}
+}
+
+// check returns an error if hash does not identify a vertex of the tree.
+func (t *rootedTree[K, T]) check(hash K) error {
+	exists, err := t.Interface.HasVertex(hash)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %v", graph.ErrVertexNotFound, hash)
+	}
+	if !t.inTree(hash) {
+		return fmt.Errorf("%w: %v", graph.ErrVertexNotInTree, hash)
+	}
+	return nil
+}
+
+func (t *rootedTree[K, T]) AddVertex(vertex graph.Vertex[K, T]) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.Interface.AddVertex(vertex); err != nil {
+		return err
+	}
+
+	if !t.hasRoot {
+		t.root, t.hasRoot = t.Hash()(vertex.Value()), true
+	}
+
+	return nil
+}
+
+func (t *rootedTree[K, T]) AddVertexWithOptions(value T, options ...graph.VertexOption) error {
+	v := NewVertexWithOptions(t.Hash()(value), value, func(p graph.VertexProperties) {
+		for _, option := range options {
+			option(p)
+		}
+	})
+
+	return t.AddVertex(v)
+}
+
+func (t *rootedTree[K, T]) AddVerticesFrom(g graph.Interface[K, T]) error {
+	vertices, err := g.Vertices()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	// Add the root of another tree first, so it becomes the root here too.
+	if tree, ok := g.(graph.RootedTree[K, T]); ok {
+		if root, err := tree.Root(); err == nil {
+			sort.SliceStable(vertices, func(i, j int) bool {
+				return vertices[i].ID() == root && vertices[j].ID() != root
+			})
+		}
+	}
+
+	for _, vertex := range vertices {
+		if err = t.AddVertex(vertex); err != nil {
+			return fmt.Errorf("%w: %v", graph.ErrFailedToAddVertex, vertex.ID())
+		}
+	}
+
+	return nil
+}
+
+func (t *rootedTree[K, T]) AddEdge(edge graph.Edge[K]) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	source, target := edge.Source(), edge.Target()
+	for _, hash := range []K{source, target} {
+		exists, err := t.Interface.HasVertex(hash)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: %v", graph.ErrVertexNotFound, hash)
+		}
+	}
+
+	// Every edge of the tree links a parent to a child, so the links tell
+	// whether the edge exists without asking the graph.
+	if t.linked(source, target) {
+		return graph.ErrEdgeAlreadyExists
+	}
+
+	if !t.hasRoot {
+		t.root, t.hasRoot = source, true
+	}
+
+	parent, child := source, target
+	switch sourceIn, targetIn := t.inTree(source), t.inTree(target); {
+	case sourceIn && targetIn:
+		if t.Traits().IsDirected && target != t.root && !t.isAncestor(target, source) {
+			return fmt.Errorf("%w: %v", graph.ErrVertexHasParent, target)
+		}
+		return graph.ErrEdgeCreatesCycle
+	case !sourceIn && (t.Traits().IsDirected || !targetIn):
+		return fmt.Errorf("%w: %v", graph.ErrVertexNotInTree, source)
+	case !sourceIn:
+		// In an undirected tree the edge may be given in either direction.
+		parent, child = target, source
+	}
+
+	if err := t.Interface.AddEdge(edge); err != nil {
+		return err
+	}
+
+	t.link(parent, child)
+	return nil
+}
+
+// linked reports whether the tree has an edge from source to target, or
+// between them in either direction if the tree is undirected.
+func (t *rootedTree[K, T]) linked(source, target K) bool {
+	if parent, ok := t.parents[target]; ok && parent == source {
+		return true
+	}
+	if t.Traits().IsDirected {
+		return false
+	}
+
+	parent, ok := t.parents[source]
+	return ok && parent == target
+}
+
+// isAncestor reports whether ancestor is on the path from hash to the root,
+// or is hash itself.
+func (t *rootedTree[K, T]) isAncestor(ancestor, hash K) bool {
+	for current, ok := hash, true; ok; current, ok = t.parents[current] {
+		if current == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *rootedTree[K, T]) AddEdgeWithOptions(source, target K, options ...graph.EdgeOption) error {
+	e := NewEdgeWithOptions(source, target, func(p graph.EdgeProperties) {
+		for _, option := range options {
+			option(p)
+		}
+	})
+
+	return t.AddEdge(e)
+}
+
+// AddEdgesFrom adds the edges of g. Edges are retried until the vertices they
+// attach to have joined the tree, so they may come in any order.
+func (t *rootedTree[K, T]) AddEdgesFrom(g graph.Interface[K, T]) error {
+	pending, err := g.Edges()
+	if err != nil {
+		return fmt.Errorf("%w: %v", graph.ErrFailedToGetEdges, err)
+	}
+
+	for len(pending) > 0 {
+		var deferred []graph.Edge[K]
+		for _, edge := range pending {
+			err = t.AddEdge(edge.Clone())
+			if errors.Is(err, graph.ErrVertexNotInTree) {
+				deferred = append(deferred, edge)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("%w (%v, %v): %v", graph.ErrFailedToAddEdge, edge.Source(), edge.Target(), err)
+			}
+		}
+
+		if len(deferred) == len(pending) {
+			edge := deferred[0]
+			return fmt.Errorf("%w (%v, %v): %v", graph.ErrFailedToAddEdge, edge.Source(), edge.Target(), graph.ErrVertexNotInTree)
+		}
+		pending = deferred
+	}
+
+	return nil
+}
+
+// RemoveEdge removes the edge between a leaf and its parent. The leaf leaves
+// the tree until another edge attaches it.
+func (t *rootedTree[K, T]) RemoveEdge(source, target K) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	child := target
+	if parent, ok := t.parents[source]; ok && parent == target && !t.Traits().IsDirected {
+		child = source
+	}
+
+	if len(t.children[child]) > 0 {
+		return fmt.Errorf("%w: %v has children", graph.ErrEdgeDisconnectsTree, child)
+	}
+
+	if err := t.Interface.RemoveEdge(source, target); err != nil {
+		return err
+	}
+
+	t.unlink(child)
+	return nil
+}
+
+// RemoveVertex removes a vertex without edges. If it is the root, the first
+// remaining vertex becomes the root.
+func (t *rootedTree[K, T]) RemoveVertex(hash K) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.Interface.RemoveVertex(hash); err != nil {
+		return err
+	}
+
+	if t.hasRoot && hash == t.root {
+		t.hasRoot = false
+
+		vertices, err := t.Interface.Vertices()
+		if err != nil {
+			return fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+		}
+		if len(vertices) > 0 {
+			t.root, t.hasRoot = vertices[0].ID(), true
+		}
+	}
+
+	return nil
+}
+
+func (t *rootedTree[K, T]) Clone() (graph.Interface[K, T], error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	g, err := t.Interface.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	clone := &rootedTree[K, T]{
+		Interface: g,
+		root:      t.root,
+		hasRoot:   t.hasRoot,
+		parents:   make(map[K]K, len(t.parents)),
+		children:  make(map[K]map[K]struct{}, len(t.children)),
+	}
+	for child, parent := range t.parents {
+		clone.link(parent, child)
+	}
+
+	return clone, nil
+}
+
+func (t *rootedTree[K, T]) Root() (K, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if !t.hasRoot {
+		var zero K
+		return zero, graph.ErrTreeHasNoRoot
+	}
+
+	return t.root, nil
+}
+
+func (t *rootedTree[K, T]) Parent(hash K) (K, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	var zero K
+	if err := t.check(hash); err != nil {
+		return zero, err
+	}
+	if hash == t.root {
+		return zero, graph.ErrVertexIsRoot
+	}
+
+	return t.parents[hash], nil
+}
+
+func (t *rootedTree[K, T]) Children(hash K) ([]K, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if err := t.check(hash); err != nil {
+		return nil, err
+	}
+
+	return t.sortedChildren(hash), nil
+}
+
+// sortedChildren returns the children of the vertex in key order.
+func (t *rootedTree[K, T]) sortedChildren(hash K) []K {
+	children := make([]K, 0, len(t.children[hash]))
+	for child := range t.children[hash] {
+		children = append(children, child)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i] < children[j]
+	})
+	return children
+}
+
+func (t *rootedTree[K, T]) Ancestors(hash K) ([]K, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if err := t.check(hash); err != nil {
+		return nil, err
+	}
+
+	return t.ancestors(hash), nil
+}
+
+// ancestors returns the path from the parent of the vertex up to the root.
+func (t *rootedTree[K, T]) ancestors(hash K) []K {
+	var ancestors []K
+	for parent, ok := t.parents[hash]; ok; parent, ok = t.parents[parent] {
+		ancestors = append(ancestors, parent)
+	}
+	return ancestors
+}
+
+func (t *rootedTree[K, T]) Descendants(hash K) ([]K, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if err := t.check(hash); err != nil {
+		return nil, err
+	}
+
+	return t.descendants(hash), nil
+}
+
+// descendants returns the descendants of the vertex in breadth-first order.
+func (t *rootedTree[K, T]) descendants(hash K) []K {
+	var descendants []K
+	for queue := []K{hash}; len(queue) > 0; queue = queue[1:] {
+		children := t.sortedChildren(queue[0])
+		descendants = append(descendants, children...)
+		queue = append(queue, children...)
+	}
+	return descendants
+}
+
+func (t *rootedTree[K, T]) Depth(hash K) (int, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if err := t.check(hash); err != nil {
+		return 0, err
+	}
+
+	return len(t.ancestors(hash)), nil
+}
+
+func (t *rootedTree[K, T]) Subtree(hash K) (graph.RootedTree[K, T], error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if err := t.check(hash); err != nil {
+		return nil, err
+	}
+
+	s, err := NewLike[K, T](t)
+	if err != nil {
+		return nil, err
+	}
+	sub := s.(*rootedTree[K, T])
+
+	vertex, err := t.Interface.Vertex(hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetVertex, hash)
+	}
+	if err = sub.AddVertex(vertex); err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToAddVertex, hash)
+	}
+
+	// Descendants come in breadth-first order, so every parent is in the
+	// subtree before its children.
+	for _, child := range t.descendants(hash) {
+		parent := t.parents[child]
+
+		if vertex, err = t.Interface.Vertex(child); err != nil {
+			return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetVertex, child)
+		}
+		if err = sub.AddVertex(vertex); err != nil {
+			return nil, fmt.Errorf("%w: %v", graph.ErrFailedToAddVertex, child)
+		}
+
+		edge, err := t.Interface.Edge(parent, child)
+		if err != nil {
+			return nil, err
+		}
+		if err = sub.AddEdge(NewEdge(parent, child, edge.Properties().Clone())); err != nil {
+			return nil, fmt.Errorf("%w (%v, %v): %v", graph.ErrFailedToAddEdge, parent, child, err)
+		}
+	}
+
+	return sub, nil
+}
+
+func (t *rootedTree[K, T]) Reroot(hash K) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.check(hash); err != nil {
+		return err
+	}
+	if hash == t.root {
+		return nil
+	}
+
+	// path runs from the new root up to the old one.
+	path := append([]K{hash}, t.ancestors(hash)...)
+
+	if t.Traits().IsDirected {
+		properties := make([]graph.EdgeProperties, len(path)-1)
+		for i := range properties {
+			edge, err := t.Interface.Edge(path[i+1], path[i])
+			if err != nil {
+				return err
+			}
+			properties[i] = edge.Properties()
+		}
+
+		// The edges are reversed in a batch, so a failure leaves both the
+		// graph and the tree structure as they were.
+		err := t.Interface.(graph.Batcher[K, T]).Batch(func(tx graph.Interface[K, T]) error {
+			for i := range properties {
+				if err := tx.RemoveEdge(path[i+1], path[i]); err != nil {
+					return err
+				}
+			}
+			for i, p := range properties {
+				if err := tx.AddEdge(NewEdge(path[i], path[i+1], p)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, vertex := range path[:len(path)-1] {
+		t.unlink(vertex)
+	}
+	for i := 0; i < len(path)-1; i++ {
+		t.link(path[i], path[i+1])
+	}
+	t.root = hash
+
+	return nil
+}
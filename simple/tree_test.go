@@ -0,0 +1,228 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package simple
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+// orgChart builds the tree
+//
+//	CEO -> CFO, CTO
+//	CTO -> Dev, Ops
+func orgChart(t *testing.T, directed bool) graph.RootedTree[string, string] {
+	is := assert.New(t)
+
+	options := []func(*graph.Traits){graph.Tree()}
+	if directed {
+		options = append(options, graph.Directed())
+	}
+
+	g, err := New(graph.StringHash, options...)
+	is.NoError(err)
+
+	for _, v := range []string{"CEO", "CFO", "CTO", "Dev", "Ops"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("CEO", "CTO", EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("CEO", "CFO", EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("CTO", "Dev", EdgeWeight(3)))
+	if directed {
+		is.NoError(g.AddEdgeWithOptions("CTO", "Ops", EdgeWeight(4)))
+	} else {
+		// Undirected edges may be given either way.
+		is.NoError(g.AddEdgeWithOptions("Ops", "CTO", EdgeWeight(4)))
+	}
+
+	return g.(graph.RootedTree[string, string])
+}
+
+func TestRootedTree_Queries(t *testing.T) {
+	t.Parallel()
+
+	for _, directed := range []bool{true, false} {
+		is := assert.New(t)
+		tree := orgChart(t, directed)
+
+		root, err := tree.Root()
+		is.NoError(err)
+		is.Equal("CEO", root)
+
+		parent, err := tree.Parent("Ops")
+		is.NoError(err)
+		is.Equal("CTO", parent)
+		_, err = tree.Parent("CEO")
+		is.ErrorIs(err, graph.ErrVertexIsRoot)
+
+		children, _ := tree.Children("CTO")
+		is.Equal([]string{"Dev", "Ops"}, children)
+
+		ancestors, _ := tree.Ancestors("Dev")
+		is.Equal([]string{"CTO", "CEO"}, ancestors)
+
+		descendants, _ := tree.Descendants("CEO")
+		is.Equal([]string{"CFO", "CTO", "Dev", "Ops"}, descendants)
+
+		depth, _ := tree.Depth("Ops")
+		is.Equal(2, depth)
+
+		_, err = tree.Depth("Nobody")
+		is.ErrorIs(err, graph.ErrVertexNotFound)
+	}
+}
+
+func TestRootedTree_Invariants(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := New(graph.StringHash, graph.Directed(), graph.Tree())
+	for _, v := range []string{"A", "B", "C", "D"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+
+	is.NoError(g.AddEdgeWithOptions("A", "B"))
+	is.NoError(g.AddEdgeWithOptions("B", "C"))
+
+	is.ErrorIs(g.AddEdgeWithOptions("A", "C"), graph.ErrVertexHasParent)
+	is.ErrorIs(g.AddEdgeWithOptions("C", "A"), graph.ErrEdgeCreatesCycle)
+	is.ErrorIs(g.AddEdgeWithOptions("C", "B"), graph.ErrEdgeCreatesCycle)
+	is.ErrorIs(g.AddEdgeWithOptions("D", "C"), graph.ErrVertexNotInTree)
+	is.ErrorIs(g.AddEdgeWithOptions("A", "B"), graph.ErrEdgeAlreadyExists)
+
+	tree := g.(graph.RootedTree[string, string])
+	_, err := tree.Depth("D")
+	is.ErrorIs(err, graph.ErrVertexNotInTree)
+
+	// Only the edge to a leaf can be removed.
+	is.ErrorIs(g.RemoveEdge("A", "B"), graph.ErrEdgeDisconnectsTree)
+	is.NoError(g.RemoveEdge("B", "C"))
+	_, err = tree.Parent("C")
+	is.ErrorIs(err, graph.ErrVertexNotInTree)
+	is.NoError(g.AddEdgeWithOptions("A", "C"))
+
+	u, _ := New(graph.StringHash, graph.Tree())
+	for _, v := range []string{"A", "B", "C"} {
+		is.NoError(u.AddVertexWithOptions(v))
+	}
+	is.NoError(u.AddEdgeWithOptions("B", "A"))
+	is.NoError(u.AddEdgeWithOptions("C", "B"))
+	is.ErrorIs(u.AddEdgeWithOptions("A", "C"), graph.ErrEdgeCreatesCycle)
+	is.ErrorIs(u.AddEdgeWithOptions("A", "B"), graph.ErrEdgeAlreadyExists)
+	is.ErrorIs(u.AddEdgeWithOptions("B", "C"), graph.ErrEdgeAlreadyExists)
+}
+
+func TestRootedTree_SubtreeAndReroot(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	tree := orgChart(t, true)
+	g := graph.Interface[string, string](tree)
+
+	sub, err := tree.Subtree("CTO")
+	is.NoError(err)
+	root, _ := sub.Root()
+	is.Equal("CTO", root)
+	order, _ := sub.Order()
+	is.Equal(3, order)
+	e, err := sub.Edge("CTO", "Ops")
+	is.NoError(err)
+	is.Equal(float64(4), e.Properties().Weight())
+
+	clone, err := g.Clone()
+	is.NoError(err)
+
+	is.NoError(tree.Reroot("Dev"))
+	root, _ = tree.Root()
+	is.Equal("Dev", root)
+	ancestors, _ := tree.Ancestors("CFO")
+	is.Equal([]string{"CEO", "CTO", "Dev"}, ancestors)
+
+	// The edges on the path are reversed, keeping their properties.
+	e, err = g.Edge("CTO", "CEO")
+	is.NoError(err)
+	is.Equal(float64(1), e.Properties().Weight())
+	ok, _ := g.HasEdge("CEO", "CTO")
+	is.False(ok)
+
+	// The clone is unaffected.
+	root, _ = clone.(graph.RootedTree[string, string]).Root()
+	is.Equal("CEO", root)
+}
+
+func TestRootedTree_RerootFailure(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := New(graph.StringHash, graph.Directed(), graph.Tree())
+	is.NoError(err)
+	for _, v := range []string{"a", "b", "c"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("a", "b"))
+	is.NoError(g.AddEdgeWithOptions("b", "c"))
+	tree := g.(graph.RootedTree[string, string])
+
+	vetoed := errors.New("vetoed")
+	unhook := g.(graph.Observable[string]).Hook(func(event graph.Event[string]) error {
+		if event.Type == graph.EdgeAdded && event.Source == "b" && event.Target == "a" {
+			return vetoed
+		}
+		return nil
+	})
+
+	is.ErrorIs(tree.Reroot("c"), vetoed)
+
+	// Neither the graph nor the tree has changed.
+	ok, _ := g.HasEdge("a", "b")
+	is.True(ok)
+	ok, _ = g.HasEdge("b", "c")
+	is.True(ok)
+	ok, _ = g.HasEdge("c", "b")
+	is.False(ok)
+	root, _ := tree.Root()
+	is.Equal("a", root)
+	parent, _ := tree.Parent("b")
+	is.Equal("a", parent)
+
+	unhook()
+	is.NoError(tree.Reroot("c"))
+	ancestors, _ := tree.Ancestors("a")
+	is.Equal([]string{"b", "c"}, ancestors)
+}
+
+func TestRootedTree_Builder(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	b := NewBuilder(graph.IntHash, graph.Directed(), graph.Tree())
+	for i := range 4 {
+		b.AddVertexWithOptions(i)
+	}
+	is.NoError(b.AddEdgeWithOptions(2, 0))
+	is.NoError(b.AddEdgeWithOptions(2, 1))
+	is.NoError(b.AddEdgeWithOptions(1, 3))
+
+	g, err := b.Build()
+	is.NoError(err)
+	tree := g.(graph.RootedTree[int, int])
+	root, _ := tree.Root()
+	is.Equal(2, root)
+	depth, _ := tree.Depth(3)
+	is.Equal(2, depth)
+
+	b = NewBuilder(graph.IntHash, graph.Directed(), graph.Tree())
+	for i := range 3 {
+		b.AddVertexWithOptions(i)
+	}
+	is.NoError(b.AddEdgeWithOptions(0, 2))
+	is.NoError(b.AddEdgeWithOptions(1, 2))
+	_, err = b.Build()
+	is.Error(err, "a vertex with two parents is not a tree")
+}
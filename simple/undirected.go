@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
 	"github.com/sixafter/graph/internal/paths"
 )
 
@@ -19,15 +20,17 @@ import (
 type undirected[K graph.Ordered, T any] struct {
 	hash   graph.Hash[K, T]
 	traits *graph.Traits
-	store  ledger[K, T]
+	store  Store[K, T]
+	events *observers[K]
 }
 
 // newUndirected creates and returns a new undirected graph instance.
-func newUndirected[K graph.Ordered, T any](hash graph.Hash[K, T], traits *graph.Traits, store ledger[K, T]) (*undirected[K, T], error) {
+func newUndirected[K graph.Ordered, T any](hash graph.Hash[K, T], traits *graph.Traits, store Store[K, T]) (*undirected[K, T], error) {
 	return &undirected[K, T]{
 		hash:   hash,
 		traits: traits,
 		store:  store,
+		events: &observers[K]{},
 	}, nil
 }
 
@@ -37,8 +40,11 @@ func (u *undirected[K, T]) Traits() *graph.Traits {
 
 func (u *undirected[K, T]) AddVertex(vertex graph.Vertex[K, T]) error {
 	hash := u.hash(vertex.Value())
+	event := graph.Event[K]{Type: graph.VertexAdded, Vertex: hash, VertexAfter: vertex.Properties()}
 
-	return u.store.AddVertex(hash, vertex.Value(), vertex.Properties())
+	return u.events.change(func() error {
+		return u.store.AddVertex(hash, vertex.Value(), vertex.Properties())
+	}, event)
 }
 
 func (u *undirected[K, T]) AddVertexWithOptions(value T, options ...graph.VertexOption) error {
@@ -64,25 +70,50 @@ func (u *undirected[K, T]) Vertex(hash K) (graph.Vertex[K, T], error) {
 
 func (u *undirected[K, T]) SetVertexWithOptions(value T, options ...graph.VertexOption) error {
 	hash := u.hash(value)
-	_, props, err := u.store.FindVertex(hash)
-	if err != nil {
-		return err
-	}
 
-	for _, option := range options {
-		dp, ok := props.(*VertexProperties) // Attempt to assert the type
-		if !ok {
-			return fmt.Errorf("failed to modify vertex: %T", props)
+	var props graph.VertexProperties
+	describe := func() ([]graph.Event[K], error) {
+		_, before, err := u.store.FindVertex(hash)
+		if err != nil {
+			return nil, err
+		}
+		props = before
+
+		// Options are applied to a copy, leaving the stored properties to the store.
+		if p, ok := props.(*VertexProperties); ok {
+			props = p.Clone()
+		}
+
+		for _, option := range options {
+			dp, ok := props.(*VertexProperties) // Attempt to assert the type
+			if !ok {
+				return nil, fmt.Errorf("failed to modify vertex: %T", props)
+			}
+
+			option(dp)
 		}
 
-		option(dp)
+		return []graph.Event[K]{{Type: graph.VertexModified, Vertex: hash, VertexBefore: before, VertexAfter: props}}, nil
 	}
 
-	return u.store.ModifyVertex(hash, props)
+	return u.events.update(describe, func() error {
+		return u.store.ModifyVertex(hash, props)
+	})
 }
 
 func (u *undirected[K, T]) RemoveVertex(hash K) error {
-	return u.store.RemoveVertex(hash)
+	describe := func() ([]graph.Event[K], error) {
+		_, props, err := u.store.FindVertex(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		return []graph.Event[K]{{Type: graph.VertexRemoved, Vertex: hash, VertexBefore: props}}, nil
+	}
+
+	return u.events.update(describe, func() error {
+		return u.store.RemoveVertex(hash)
+	})
 }
 
 func (u *undirected[K, T]) HasVertex(hash K) (bool, error) {
@@ -95,44 +126,60 @@ func (u *undirected[K, T]) HasVertex(hash K) (bool, error) {
 }
 
 func (u *undirected[K, T]) AddEdge(edge graph.Edge[K]) error {
+	_, err := u.addEdge(edge)
+	return err
+}
+
+// addEdge validates edge and stores it in both directions under a new ID,
+// which it returns.
+func (u *undirected[K, T]) addEdge(edge graph.Edge[K]) (graph.EdgeID, error) {
 	sourceHash := edge.Source()
 	if _, _, err := u.store.FindVertex(sourceHash); err != nil {
-		return fmt.Errorf("could not find source vertex with hash %v: %w", sourceHash, err)
+		return 0, fmt.Errorf("could not find source vertex with hash %v: %w", sourceHash, err)
 	}
 
 	targetHash := edge.Target()
 	if _, _, err := u.store.FindVertex(targetHash); err != nil {
-		return fmt.Errorf("could not find target vertex with hash %v: %w", targetHash, err)
+		return 0, fmt.Errorf("could not find target vertex with hash %v: %w", targetHash, err)
 	}
 
-	if _, err := u.Edge(sourceHash, targetHash); !errors.Is(err, graph.ErrEdgeNotFound) {
-		return graph.ErrEdgeAlreadyExists
+	if !u.traits.IsMultiGraph {
+		if _, err := u.Edge(sourceHash, targetHash); !errors.Is(err, graph.ErrEdgeNotFound) {
+			return 0, graph.ErrEdgeAlreadyExists
+		}
 	}
 
 	// If the user opted in to preventing cycles, run a cycle check.
 	if u.traits.PreventCycles {
 		createsCycle, err := paths.WouldCreateCycle[K, T](u, sourceHash, targetHash)
 		if err != nil {
-			return fmt.Errorf("check for cycles: %w", err)
+			return 0, fmt.Errorf("check for cycles: %w", err)
 		}
 		if createsCycle {
-			return graph.ErrEdgeCreatesCycle
+			return 0, graph.ErrEdgeCreatesCycle
 		}
 	}
 
-	err := u.store.AddEdge(sourceHash, targetHash, edge)
-	if err != nil {
-		return err
-	}
+	// Both directions share the ID, so the pair is counted and removed as
+	// one edge.
+	id := u.store.NextEdgeID()
+	event := graph.Event[K]{Type: graph.EdgeAdded, Source: sourceHash, Target: targetHash, EdgeID: id, EdgeAfter: edge.Properties()}
 
-	rEdge := NewEdge(targetHash, sourceHash, edge.Properties())
+	err := u.events.change(func() error {
+		err := u.store.AddEdge(sourceHash, targetHash, newEdgeWithID(sourceHash, targetHash, edge.Properties(), id))
+		if err != nil {
+			return err
+		}
+
+		rEdge := newEdgeWithID(targetHash, sourceHash, edge.Properties(), id)
 
-	err = u.store.AddEdge(targetHash, sourceHash, rEdge)
+		return u.store.AddEdge(targetHash, sourceHash, rEdge)
+	}, event)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return id, nil
 }
 
 func (u *undirected[K, T]) AddEdgeWithOptions(sourceHash, targetHash K, options ...graph.EdgeOption) error {
@@ -145,6 +192,16 @@ func (u *undirected[K, T]) AddEdgeWithOptions(sourceHash, targetHash K, options
 	return u.AddEdge(e)
 }
 
+func (u *undirected[K, T]) InsertEdge(sourceHash, targetHash K, options ...graph.EdgeOption) (graph.EdgeID, error) {
+	e := NewEdgeWithOptions(sourceHash, targetHash, func(p graph.EdgeProperties) {
+		for _, option := range options {
+			option(p)
+		}
+	})
+
+	return u.addEdge(e)
+}
+
 func (u *undirected[K, T]) AddEdgesFrom(g graph.Interface[K, T]) error {
 	edges, err := g.Edges()
 	if err != nil {
@@ -183,9 +240,9 @@ func (u *undirected[K, T]) AddVerticesFrom(g graph.Interface[K, T]) error {
 }
 
 func (u *undirected[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[T], error) {
-	// In an undirected graph, since multi-graphs aren't supported, the edge AB
-	// is the same as BA. Therefore, if source[target] cannot be found, this
-	// function also looks for target[source].
+	// In an undirected graph, the edge AB is the same as BA. Therefore, if
+	// source[target] cannot be found, this function also looks for
+	// target[source].
 	edge, err := u.store.FindEdge(sourceHash, targetHash)
 	if errors.Is(err, graph.ErrEdgeNotFound) {
 		edge, err = u.store.FindEdge(targetHash, sourceHash)
@@ -212,10 +269,6 @@ func (u *undirected[K, T]) Edge(sourceHash, targetHash K) (graph.Edge[T], error)
 	return NewEdge(sourceVertex, targetVertex, edge.Properties()), nil
 }
 
-type tuple[K comparable] struct {
-	source, target K
-}
-
 func (u *undirected[K, T]) Edges() ([]graph.Edge[K], error) {
 	storedEdges, err := u.store.ListEdges()
 	if err != nil {
@@ -227,76 +280,133 @@ func (u *undirected[K, T]) Edges() ([]graph.Edge[K], error) {
 	// one of these two edges, because from an outside perspective, it only is
 	// a single edge.
 	//
-	// To achieve this, Edges keeps track of already-added edges. For each edge,
-	// it also checks if the reversed edge has already been added - e.g., for
-	// an edge (A,B), Edges checks if the edge has been added as (B,A).
-	//
-	// These reversed edges are built as a custom tuple type, which is then used
-	// as a map key for access in O(1) time. It looks scarier than it is.
+	// Both directions are stored under the same edge ID, so Edges keeps track
+	// of the IDs it has already added. Parallel edges have distinct IDs and
+	// are all returned.
 	edges := make([]graph.Edge[K], 0, len(storedEdges)/2)
 
-	added := make(map[tuple[K]]struct{})
+	added := make(map[graph.EdgeID]struct{})
 
 	for _, storedEdge := range storedEdges {
-		reversedEdge := tuple[K]{
-			source: storedEdge.Target(),
-			target: storedEdge.Source(),
-		}
-		if _, ok := added[reversedEdge]; ok {
+		id := edgeutil.ID(storedEdge)
+		if _, ok := added[id]; ok {
 			continue
 		}
 
 		edges = append(edges, storedEdge)
+		added[id] = struct{}{}
+	}
 
-		addedEdge := tuple[K]{
-			source: storedEdge.Source(),
-			target: storedEdge.Target(),
+	return edges, nil
+}
+
+func (u *undirected[K, T]) EdgesBetween(source, target K) ([]graph.Edge[K], error) {
+	if _, _, err := u.store.FindVertex(source); err != nil {
+		return nil, fmt.Errorf("could not find source vertex with hash %v: %w", source, err)
+	}
+	if _, _, err := u.store.FindVertex(target); err != nil {
+		return nil, fmt.Errorf("could not find target vertex with hash %v: %w", target, err)
+	}
+
+	// The reverse of every edge is stored too, so the edges added in either
+	// direction are found under source and target.
+	return u.store.FindEdges(source, target)
+}
+
+func (u *undirected[K, T]) EdgeByID(id graph.EdgeID) (graph.Edge[K], error) {
+	return u.store.FindEdgeByID(id)
+}
+
+func (u *undirected[K, T]) RemoveEdgeByID(id graph.EdgeID) error {
+	describe := func() ([]graph.Event[K], error) {
+		edge, err := u.store.FindEdgeByID(id)
+		if err != nil {
+			return nil, err
 		}
 
-		added[addedEdge] = struct{}{}
+		return removedEdgeEvents([]graph.Edge[K]{edge}), nil
 	}
 
-	return edges, nil
+	return u.events.update(describe, func() error {
+		return u.store.RemoveEdgeByID(id)
+	})
 }
 
 func (u *undirected[K, T]) SetEdgeWithOptions(source, target K, options ...graph.EdgeOption) error {
-	edge, err := u.store.FindEdge(source, target)
-	if err != nil {
-		return err
-	}
+	var edge graph.Edge[K]
+	describe := func() ([]graph.Event[K], error) {
+		var err error
+		edge, err = u.store.FindEdge(source, target)
+		if err != nil {
+			return nil, err
+		}
+		before := edge.Properties()
+
+		// Options are applied to a copy, leaving the stored edge to the store.
+		edge = edge.Clone()
 
-	for _, option := range options {
-		p, ok := edge.Properties().(*EdgeProperties) // Attempt to assert the type
-		if !ok {
-			return fmt.Errorf("failed to modify edge: %T", edge.Properties())
+		for _, option := range options {
+			p, ok := edge.Properties().(*EdgeProperties) // Attempt to assert the type
+			if !ok {
+				return nil, fmt.Errorf("failed to modify edge: %T", edge.Properties())
+			}
+
+			option(p)
 		}
 
-		option(p)
+		return []graph.Event[K]{{
+			Type:       graph.EdgeModified,
+			Source:     source,
+			Target:     target,
+			EdgeID:     edgeutil.ID(edge),
+			EdgeBefore: before,
+			EdgeAfter:  edge.Properties(),
+		}}, nil
 	}
 
-	if err = u.store.ModifyEdge(source, target, edge); err != nil {
-		return err
-	}
+	return u.events.update(describe, func() error {
+		if err := u.store.ModifyEdge(source, target, edge); err != nil {
+			return err
+		}
 
-	reversedEdge := NewEdge(target, source, edge.Properties())
+		reversedEdge := newEdgeWithID(target, source, edge.Properties(), edgeutil.ID(edge))
 
-	return u.store.ModifyEdge(target, source, reversedEdge)
+		return u.store.ModifyEdge(target, source, reversedEdge)
+	})
 }
 
 func (u *undirected[K, T]) RemoveEdge(source, target K) error {
-	if _, err := u.Edge(source, target); err != nil {
-		return err
-	}
+	// The edges are listed under the changes lock, so that each edge removed
+	// has an event, including a parallel edge added just before.
+	describe := func() ([]graph.Event[K], error) {
+		if _, err := u.Edge(source, target); err != nil {
+			return nil, err
+		}
 
-	if err := u.store.RemoveEdge(source, target); err != nil {
-		return fmt.Errorf("failed to remove edge from %v to %v: %w", source, target, err)
-	}
+		edges, err := u.store.FindEdges(source, target)
+		if err != nil {
+			return nil, err
+		}
 
-	if err := u.store.RemoveEdge(target, source); err != nil {
-		return fmt.Errorf("failed to remove edge from %v to %v: %w", target, source, err)
+		return removedEdgeEvents(edges), nil
 	}
 
-	return nil
+	return u.events.update(describe, func() error {
+		if err := u.store.RemoveEdge(source, target); err != nil {
+			return fmt.Errorf("failed to remove edge from %v to %v: %w", source, target, err)
+		}
+
+		// A self-loop is stored once.
+		if source == target {
+			return nil
+		}
+
+		if err := u.store.RemoveEdge(target, source); err != nil {
+			return fmt.Errorf("failed to remove edge from %v to %v: %w", target, source, err)
+		}
+
+		return nil
+	})
 }
 
 func (u *undirected[K, T]) AdjacencyMap() (map[K]map[K]graph.Edge[K], error) {
@@ -317,8 +427,13 @@ func (u *undirected[K, T]) AdjacencyMap() (map[K]map[K]graph.Edge[K], error) {
 	}
 
 	for _, edge := range edges {
-		m[edge.Source()][edge.Target()] = edge
-		m[edge.Target()][edge.Source()] = edge // Ensure edges are bidirectional
+		// Ensure edges are bidirectional, keeping the lightest parallel edge
+		if lighter(m[edge.Source()][edge.Target()], edge) {
+			m[edge.Source()][edge.Target()] = edge
+		}
+		if lighter(m[edge.Target()][edge.Source()], edge) {
+			m[edge.Target()][edge.Source()] = edge
+		}
 	}
 
 	return m, nil
@@ -328,7 +443,22 @@ func (u *undirected[K, T]) PredecessorMap() (map[K]map[K]graph.Edge[K], error) {
 	return u.AdjacencyMap()
 }
 
+// Clone returns a copy of the graph. If the store is Versioned, the clone is
+// backed by a fork of it, which takes constant time: the clone and the graph
+// share their data until either changes, and a change copies only what it
+// touches. Their properties are copied too before either one hands them out,
+// so changing the items of one does not affect the other. Otherwise, the
+// vertices and edges are copied to a new store.
 func (u *undirected[K, T]) Clone() (graph.Interface[K, T], error) {
+	if v, ok := u.store.(Versioned[K, T]); ok {
+		s, err := v.Fork()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", graph.ErrFailedToCloneGraph, err)
+		}
+
+		return newUndirected(u.hash, u.traits.Clone(), s)
+	}
+
 	traits := u.traits.Clone()
 	store, err := u.store.New()
 	if err != nil {
@@ -339,13 +469,14 @@ func (u *undirected[K, T]) Clone() (graph.Interface[K, T], error) {
 		hash:   u.hash,
 		traits: traits,
 		store:  store,
+		events: &observers[K]{},
 	}
 
 	if err := clone.AddVerticesFrom(u); err != nil {
 		return nil, fmt.Errorf("failed to add vertices: %w", err)
 	}
 
-	if err := clone.AddEdgesFrom(u); err != nil {
+	if err := cloneEdges(u.store, store); err != nil {
 		return nil, fmt.Errorf("failed to add edges: %w", err)
 	}
 
@@ -357,11 +488,8 @@ func (u *undirected[K, T]) Order() (int, error) {
 }
 
 func (u *undirected[K, T]) Size() (int, error) {
-	edgeCount, err := u.store.CountEdges()
-
-	// Divide by 2 since every add edge operation on undirected graph is counted
-	// twice.
-	return edgeCount / 2, err
+	// Both stored directions of an edge share its ID and are counted once.
+	return u.store.CountEdges()
 }
 
 func (u *undirected[K, T]) HasEdge(vertex1, vertex2 K) (bool, error) {
@@ -417,40 +545,13 @@ func (u *undirected[K, T]) Vertices() ([]graph.Vertex[K, T], error) {
 }
 
 func (u *undirected[K, T]) Neighbors(hash K) ([]graph.Vertex[K, T], error) {
-	adjacencyMap, err := u.AdjacencyMap()
-	if err != nil {
-		return nil, err
-	}
-
-	neighborsMap, exists := adjacencyMap[hash]
-	if !exists {
-		return nil, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
-	}
-
-	neighbors := make([]graph.Vertex[K, T], 0, len(neighborsMap))
-	for neighborHash := range neighborsMap {
-		vertex, err := u.Vertex(neighborHash)
-		if err != nil {
-			return nil, fmt.Errorf("%w: failed to get neighbor vertex", graph.ErrFailedToGetVertex)
-		}
-		neighbors = append(neighbors, vertex)
-	}
-
-	return neighbors, nil
+	return u.OutNeighbors(hash)
 }
 
 func (u *undirected[K, T]) Degree(hash K) (int, error) {
-	adjacencyMap, err := u.AdjacencyMap()
-	if err != nil {
-		return 0, fmt.Errorf("%w: failed to retrieve adjacency map", graph.ErrFailedToGetEdges)
-	}
-
-	edges, exists := adjacencyMap[hash]
-	if !exists {
-		return 0, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
-	}
-
-	return len(edges), nil
+	// Every edge is stored leaving each of its vertices.
+	edges, err := u.OutEdges(hash)
+	return len(edges), err
 }
 
 func (u *undirected[K, T]) InDegree(hash K) (int, error) {
@@ -461,97 +562,20 @@ func (u *undirected[K, T]) OutDegree(hash K) (int, error) {
 	return u.Degree(hash)
 }
 
-// StreamEdgesWithContext streams edges from the undirected graph in paginated batches. The stream can be canceled
-// or timed out using a context, and the state of the cursor can be used to resume from the last point.
+// StreamEdgesWithContext streams edges from the undirected graph in paginated batches, in the order of
+// Edges. The stream can be canceled or timed out using a context, and the cursor can be used to resume
+// after the last edge sent.
 func (u *undirected[K, T]) StreamEdgesWithContext(ctx context.Context, cursor graph.Cursor, limit int, ch chan<- graph.Edge[K]) (graph.Cursor, error) {
-	defer close(ch) // Ensure the channel is closed when the function returns
-
-	if limit <= 0 {
-		return nil, errors.New("limit must be greater than zero")
-	}
-
-	// Deserialize the cursor state to determine the starting position
-	var currentPosition int64
-	if err := cursor.SetState(cursor.State()); err != nil {
-		return nil, err
-	}
-	state, ok := cursor.(*Cursor)
-	if !ok {
-		return nil, errors.New("invalid cursor type")
-	}
-	currentPosition = state.position
-
-	// Fetch edges from the underlying ledger
-	edges, err := u.Edges()
-	if err != nil {
-		return nil, err
-	}
-
-	// Stream edges in batches
-	for currentPosition < int64(len(edges)) {
-		end := currentPosition + int64(limit)
-		if end > int64(len(edges)) {
-			end = int64(len(edges))
-		}
-
-		for _, edge := range edges[currentPosition:end] {
-			select {
-			case <-ctx.Done(): // Handle cancellation
-				return cursor, ctx.Err()
-			case ch <- edge: // Send each edge to the channel
-			}
-		}
-
-		currentPosition = end
-		state.position = currentPosition
-	}
-
-	return cursor, nil
+	// Each edge is stored in both directions, so only the direction from the
+	// smaller key to the larger one is sent, as Edges does.
+	return streamEdges(ctx, u.store, cursor, limit, ch, func(edge graph.Edge[K]) bool {
+		return edge.Source() <= edge.Target()
+	})
 }
 
-// StreamVerticesWithContext streams vertices from the undirected graph in paginated batches. The stream can be canceled
-// or timed out using a context, and the state of the cursor can be used to resume from the last point.
+// StreamVerticesWithContext streams vertices from the undirected graph in paginated batches, in the
+// order of their keys. The stream can be canceled or timed out using a context, and the cursor can be
+// used to resume after the last vertex sent.
 func (u *undirected[K, T]) StreamVerticesWithContext(ctx context.Context, cursor graph.Cursor, limit int, ch chan<- []graph.Vertex[K, T]) (graph.Cursor, error) {
-	defer close(ch) // Ensure the channel is closed when the function returns
-
-	if limit <= 0 {
-		return nil, errors.New("limit must be greater than zero")
-	}
-
-	// Deserialize the cursor state to determine the starting position
-	var currentPosition int64
-	if err := cursor.SetState(cursor.State()); err != nil {
-		return nil, err
-	}
-	state, ok := cursor.(*Cursor)
-	if !ok {
-		return nil, errors.New("invalid cursor type")
-	}
-	currentPosition = state.position
-
-	// Fetch vertices from the underlying ledger
-	vertices, err := u.Vertices()
-	if err != nil {
-		return nil, err
-	}
-
-	// Stream vertices in batches
-	for currentPosition < int64(len(vertices)) {
-		end := currentPosition + int64(limit)
-		if end > int64(len(vertices)) {
-			end = int64(len(vertices))
-		}
-
-		batch := vertices[currentPosition:end]
-		select {
-		case <-ctx.Done(): // Handle cancellation
-			return cursor, ctx.Err()
-		case ch <- batch: // Send batch to channel
-		}
-
-		currentPosition = end
-		state.position = currentPosition
-	}
-
-	return cursor, nil
+	return streamVertices(ctx, u.store, cursor, limit, ch)
 }
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -167,6 +168,104 @@ func TestCloneGraph_Undirected(t *testing.T) {
 	is.Contains(adjMap["B"], "A", "Cloned graph should contain the reverse edge B -> A for undirected graph")
 }
 
+func TestCloneGraph_CopyOnWrite(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := New[string, string](graph.StringHash, graph.MultiGraph())
+	for _, v := range []string{"A", "B", "C"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "B", EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "C", EdgeWeight(3)))
+
+	clone, err := g.Clone()
+	is.NoError(err)
+
+	// The clone shares the sorted maps of the graph, which each side changes
+	// with its own owner.
+	original := g.(*undirected[string, string]).store.(*memoryLedger[string, string])
+	copied := clone.(*undirected[string, string]).store.(*memoryLedger[string, string])
+	is.NotSame(original.owner, copied.owner)
+
+	parallel, _ := clone.(graph.Multigraph[string, string]).EdgesBetween("A", "B")
+	is.NoError(clone.(graph.Multigraph[string, string]).RemoveEdgeByID(edgeutil.ID(parallel[1])))
+	is.NoError(clone.SetEdgeWithOptions("B", "C", EdgeWeight(30)))
+	is.NoError(g.AddEdgeWithOptions("C", "A", EdgeWeight(4)))
+
+	size, _ := g.Size()
+	is.Equal(4, size)
+	e, _ := g.Edge("C", "B")
+	is.Equal(float64(3), e.Properties().Weight())
+	parallel, _ = g.(graph.Multigraph[string, string]).EdgesBetween("B", "A")
+	is.Len(parallel, 2)
+
+	size, _ = clone.Size()
+	is.Equal(2, size)
+	e, _ = clone.Edge("C", "B")
+	is.Equal(float64(30), e.Properties().Weight())
+	ok, _ := clone.HasEdge("A", "C")
+	is.False(ok)
+
+	// Clones of clones are independent as well.
+	again, err := clone.Clone()
+	is.NoError(err)
+	is.NoError(again.RemoveEdge("B", "C"))
+	ok, _ = clone.HasEdge("B", "C")
+	is.True(ok)
+}
+
+func TestCloneGraph_IsolatedProperties(t *testing.T) {
+	t.Parallel()
+
+	for _, directed := range []bool{true, false} {
+		is := assert.New(t)
+
+		options := []func(*graph.Traits){}
+		if directed {
+			options = append(options, graph.Directed())
+		}
+		g, _ := New(graph.IntHash, options...)
+		is.NoError(g.AddVertexWithOptions(1, VertexItem("k", "v")))
+		is.NoError(g.AddVertexWithOptions(2))
+		is.NoError(g.AddEdgeWithOptions(1, 2, EdgeItem("k", "v")))
+
+		c, err := g.Clone()
+		is.NoError(err)
+
+		// Changing the items of the clone leaves the graph alone.
+		e, _ := c.Edge(1, 2)
+		e.Properties().Items()["k"] = "x"
+		v, _ := c.Vertex(1)
+		v.Properties().Items()["k"] = "x"
+
+		e, _ = g.Edge(1, 2)
+		is.Equal("v", e.Properties().Items()["k"])
+		v, _ = g.Vertex(1)
+		is.Equal("v", v.Properties().Items()["k"])
+
+		// The clone keeps its changes, and changing the graph leaves it alone.
+		e.Properties().Items()["k"] = "y"
+		e, _ = c.Edge(1, 2)
+		is.Equal("x", e.Properties().Items()["k"])
+		v, _ = c.Vertex(1)
+		is.Equal("x", v.Properties().Items()["k"])
+
+		if !directed {
+			e, _ = c.Edge(2, 1)
+			is.Equal("x", e.Properties().Items()["k"])
+			e, _ = g.Edge(2, 1)
+			is.Equal("y", e.Properties().Items()["k"])
+		}
+
+		edges, _ := c.Edges()
+		for _, edge := range edges {
+			is.Equal("x", edge.Properties().Items()["k"])
+		}
+	}
+}
+
 func TestHasEdges_Undirected(t *testing.T) {
 	t.Parallel()
 	is := assert.New(t)
@@ -211,7 +310,7 @@ func TestStreamEdgesWithContext_Undirected(t *testing.T) {
 	is.NoError(g.AddEdgeWithOptions("B", "C", EdgeWeight(2)))
 
 	ctx := context.Background()
-	cursor := &Cursor{position: 0}
+	cursor := EmptyCursor()
 	ch := make(chan graph.Edge[string])
 
 	go func() {
@@ -243,7 +342,7 @@ func TestStreamVerticesWithContext_Undirected(t *testing.T) {
 	is.NoError(g.AddVertexWithOptions("B", VertexWeight(10), VertexItem("label", "VertexB")))
 
 	ctx := context.Background()
-	cursor := &Cursor{position: 0}
+	cursor := EmptyCursor()
 	ch := make(chan []graph.Vertex[string, string])
 
 	go func() {
@@ -381,3 +480,59 @@ func TestInDegree_Undirected(t *testing.T) {
 	is.NoError(err, "Fetching in-degree should not fail for undirected graph")
 	is.Equal(2, inDegree, "In-degree of vertex A should be 2 in undirected graph")
 }
+
+func TestMultigraph_Undirected(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, _ := New(graph.StringHash, graph.Weighted(), graph.MultiGraph())
+	is.NoError(g.AddVertexWithOptions("A"))
+	is.NoError(g.AddVertexWithOptions("B"))
+
+	m := g.(graph.Multigraph[string, string])
+
+	first, err := m.InsertEdge("A", "B", EdgeWeight(4))
+	is.NoError(err)
+	second, err := m.InsertEdge("B", "A", EdgeWeight(3))
+	is.NoError(err)
+	_, err = m.InsertEdge("A", "A", EdgeWeight(9))
+	is.NoError(err)
+
+	size, _ := g.Size()
+	is.Equal(3, size)
+
+	edges, _ := g.Edges()
+	is.Len(edges, 3, "Each parallel edge should be listed once")
+
+	degree, _ := g.Degree("B")
+	is.Equal(2, degree)
+
+	// Edges are found from either end, oriented from source to target.
+	between, err := m.EdgesBetween("B", "A")
+	is.NoError(err)
+	is.Len(between, 2)
+	for _, edge := range between {
+		is.Equal("B", edge.Source())
+		is.Equal("A", edge.Target())
+	}
+
+	adjacency, _ := g.AdjacencyMap()
+	is.Equal(float64(3), adjacency["A"]["B"].Properties().Weight())
+	is.Equal(float64(3), adjacency["B"]["A"].Properties().Weight())
+
+	byID, err := m.EdgeByID(second)
+	is.NoError(err)
+	is.Equal("B", byID.Source(), "EdgeByID should return the edge as it was added")
+
+	is.NoError(m.RemoveEdgeByID(first))
+	between, _ = m.EdgesBetween("A", "B")
+	is.Len(between, 1)
+	between, _ = m.EdgesBetween("B", "A")
+	is.Len(between, 1)
+	size, _ = g.Size()
+	is.Equal(2, size)
+
+	is.NoError(g.RemoveEdge("A", "A"))
+	size, _ = g.Size()
+	is.Equal(1, size)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package graph
+
+// Snapshot is a read-only view of a graph as of a point in time. Changes to
+// the graph after the snapshot was taken are not visible in it, so
+// algorithms that read a graph for a long time, such as PageRank, see a
+// consistent graph while it keeps changing. Modifying a snapshot returns
+// ErrImmutableGraph; its Clone is a modifiable graph.
+type Snapshot[K Ordered, T any] interface {
+	Interface[K, T]
+
+	// Version returns the version of the graph the snapshot is pinned to.
+	// Versions increase with every change to the graph.
+	Version() uint64
+
+	// Release lets the graph stop preserving the version of the snapshot.
+	// Afterwards, the snapshot returns ErrSnapshotReleased. Snapshots that
+	// are not released are released when they are garbage collected.
+	Release()
+}
+
+// Snapshotter is implemented by graphs that can take snapshots of
+// themselves. Taking a snapshot and cloning it are constant time
+// operations: the snapshot shares its data with the graph, and the graph
+// copies the data it changes while a snapshot holds on to it.
+//
+// Example:
+//
+//	s, err := g.(graph.Snapshotter[string, string]).Snapshot()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer s.Release()
+//
+//	ranks, err := metrics.PageRank[string, string](s, 0.85, 100, 1e-6)
+type Snapshotter[K Ordered, T any] interface {
+	// Snapshot returns a snapshot of the current version of the graph.
+	Snapshot() (Snapshot[K, T], error)
+}
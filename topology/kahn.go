@@ -7,9 +7,11 @@ package topology
 
 import (
 	"fmt"
+	"iter"
 	"sort"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/iterate"
 )
 
 // TopologicalSort computes a topological ordering of the vertices in a DirectedGraph acyclic graph (DAG).
@@ -43,22 +45,17 @@ func TopologicalSort[K graph.Ordered, T any](g graph.Interface[K, T]) ([]K, erro
 		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetGraphOrder, err)
 	}
 
-	adjacencyMap, err := g.AdjacencyMap()
+	successors, inDegrees, err := kahnState(g)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetAdjacencyMap, err)
-	}
-
-	predecessorMap, err := g.PredecessorMap()
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetPredecessorMap, err)
+		return nil, err
 	}
 
 	q := make([]K, 0)
 
-	for vertex, predecessors := range predecessorMap {
-		if len(predecessors) == 0 {
+	for vertex, inDegree := range inDegrees {
+		if inDegree == 0 {
 			q = append(q, vertex)
-			delete(predecessorMap, vertex)
+			delete(inDegrees, vertex)
 		}
 	}
 
@@ -70,15 +67,12 @@ func TopologicalSort[K graph.Ordered, T any](g graph.Interface[K, T]) ([]K, erro
 
 		order = append(order, currentVertex)
 
-		edges := adjacencyMap[currentVertex]
+		for target := range successors(currentVertex) {
+			inDegrees[target]--
 
-		for target := range edges {
-			predecessors := predecessorMap[target]
-			delete(predecessors, currentVertex)
-
-			if len(predecessors) == 0 {
+			if inDegrees[target] == 0 {
 				q = append(q, target)
-				delete(predecessorMap, target)
+				delete(inDegrees, target)
 			}
 		}
 	}
@@ -146,22 +140,17 @@ func TopologicalSortDeterministic[K graph.Ordered, T any](g graph.Interface[K, T
 		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetGraphOrder, err)
 	}
 
-	adjacencyMap, err := g.AdjacencyMap()
+	successors, inDegrees, err := kahnState(g)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetAdjacencyMap, err)
-	}
-
-	predecessorMap, err := g.PredecessorMap()
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetPredecessorMap, err)
+		return nil, err
 	}
 
 	q := make([]K, 0)
 
-	for vertex, predecessors := range predecessorMap {
-		if len(predecessors) == 0 {
+	for vertex, inDegree := range inDegrees {
+		if inDegree == 0 {
 			q = append(q, vertex)
-			delete(predecessorMap, vertex)
+			delete(inDegrees, vertex)
 		}
 	}
 
@@ -178,15 +167,13 @@ func TopologicalSortDeterministic[K graph.Ordered, T any](g graph.Interface[K, T
 
 		order = append(order, currentVertex)
 		frontier := make([]K, 0)
-		edges := adjacencyMap[currentVertex]
 
-		for target := range edges {
-			predecessors := predecessorMap[target]
-			delete(predecessors, currentVertex)
+		for target := range successors(currentVertex) {
+			inDegrees[target]--
 
-			if len(predecessors) == 0 {
+			if inDegrees[target] == 0 {
 				frontier = append(frontier, target)
-				delete(predecessorMap, target)
+				delete(inDegrees, target)
 			}
 		}
 
@@ -204,3 +191,33 @@ func TopologicalSortDeterministic[K graph.Ordered, T any](g graph.Interface[K, T
 
 	return order, nil
 }
+
+// kahnState returns the successors of the vertices of g and the number of
+// distinct predecessors of each vertex, read through the incidence queries of
+// the graph where it has them.
+func kahnState[K graph.Ordered, T any](g graph.Interface[K, T]) (func(K) iter.Seq[K], map[K]int, error) {
+	vertices, err := iterate.Vertices(g)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetAdjacencyMap, err)
+	}
+
+	successors, err := iterate.Successors(g)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetAdjacencyMap, err)
+	}
+
+	predecessors, err := iterate.Predecessors(g)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetPredecessorMap, err)
+	}
+
+	inDegrees := make(map[K]int)
+	for vertex := range vertices {
+		inDegrees[vertex] = 0
+		for range predecessors(vertex) {
+			inDegrees[vertex]++
+		}
+	}
+
+	return successors, inDegrees, nil
+}
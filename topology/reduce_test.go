@@ -37,11 +37,12 @@ func TestTransitiveReduction_RemoveRedundantEdges(t *testing.T) {
 	edges, err := reduced.Edges()
 	is.NoError(err, "Fetching edges should not fail")
 
-	expectedEdges := []graph.Edge[int]{
-		simple.NewEdgeWithOptions(1, 2),
-		simple.NewEdgeWithOptions(2, 3),
+	// Edges carry the IDs assigned by the graph, so compare their endpoints.
+	pairs := make([][2]int, 0, len(edges))
+	for _, edge := range edges {
+		pairs = append(pairs, [2]int{edge.Source(), edge.Target()})
 	}
-	is.ElementsMatch(expectedEdges, edges, "Redundant edge (1 -> 3) should be removed")
+	is.ElementsMatch([][2]int{{1, 2}, {2, 3}}, pairs, "Redundant edge (1 -> 3) should be removed")
 }
 
 func TestTransitiveReduction_WithCycles(t *testing.T) {
@@ -7,9 +7,11 @@ package topology
 
 import (
 	"fmt"
+	"iter"
 	"math"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/iterate"
 	"github.com/sixafter/graph/internal/queue"
 )
 
@@ -51,21 +53,27 @@ func TarjanFrom[K graph.Ordered, T any](g graph.Interface[K, T]) ([][]K, error)
 		return nil, graph.ErrSCCDetectionNotDirected
 	}
 
-	adjacencyMap, err := g.AdjacencyMap()
+	vertices, err := iterate.Vertices(g)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrAdjacencyMap, err)
+	}
+
+	// Successors are read from the graph as the search reaches each vertex.
+	successors, err := iterate.Successors(g)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", graph.ErrAdjacencyMap, err)
 	}
 
 	state := &sccState[K]{
-		adjacencyMap: adjacencyMap,
-		components:   make([][]K, 0),
-		stack:        queue.NewStack[K](),
-		visited:      make(map[K]struct{}),
-		lowLink:      make(map[K]int),
-		index:        make(map[K]int),
+		successors: successors,
+		components: make([][]K, 0),
+		stack:      queue.NewStack[K](),
+		visited:    make(map[K]struct{}),
+		lowLink:    make(map[K]int),
+		index:      make(map[K]int),
 	}
 
-	for hash := range state.adjacencyMap {
+	for hash := range vertices {
 		if _, ok := state.visited[hash]; !ok {
 			findSCC(hash, state)
 		}
@@ -75,13 +83,13 @@ func TarjanFrom[K graph.Ordered, T any](g graph.Interface[K, T]) ([][]K, error)
 }
 
 type sccState[K comparable] struct {
-	adjacencyMap map[K]map[K]graph.Edge[K]
-	stack        *queue.Stack[K]
-	visited      map[K]struct{}
-	lowLink      map[K]int
-	index        map[K]int
-	components   [][]K
-	time         int
+	successors func(K) iter.Seq[K]
+	stack      *queue.Stack[K]
+	visited    map[K]struct{}
+	lowLink    map[K]int
+	index      map[K]int
+	components [][]K
+	time       int
 }
 
 // findSCC identifies and extracts strongly connected components (SCCs)
@@ -125,7 +133,7 @@ func findSCC[K comparable](vertexHash K, state *sccState[K]) {
 
 	state.time++
 
-	for adjacency := range state.adjacencyMap[vertexHash] {
+	for adjacency := range state.successors(vertexHash) {
 		if _, ok := state.visited[adjacency]; !ok {
 			findSCC[K](adjacency, state)
 
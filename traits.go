@@ -70,7 +70,8 @@ func Directed() TraitsOption {
 
 // MultiGraph creates a multigraph. A multigraph is a graph that allows multiple
 // edges between the same pair of vertices. This functional option sets the
-// IsMultiGraph field in Traits.
+// IsMultiGraph field in Traits. Use the Multigraph interface to address the
+// individual parallel edges.
 //
 // Example:
 //
@@ -97,7 +98,8 @@ func PreventCycles() TraitsOption {
 }
 
 // Rooted creates a rooted graph. Rooted graphs have a designated root DefaultVertex,
-// commonly used in tree metadata structures.
+// commonly used in tree metadata structures. Graphs with this trait enforce
+// the tree invariants described by RootedTree and implement it.
 //
 // Example:
 //
@@ -7,9 +7,9 @@ package traverse
 
 import (
 	"fmt"
-	"sort"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/iterate"
 )
 
 // BFS performs a breadth-first search on the graph, starting from the given vertex. The visit
@@ -80,14 +80,29 @@ func BFSWithDepthTracking[K graph.Ordered, T any](g graph.Interface[K, T], start
 		return graph.ErrNilInputGraph
 	}
 
-	// Retrieve the adjacency map of the graph.
-	adjacencyMap, err := g.AdjacencyMap()
+	return bfs(g, start, false, visit)
+}
+
+// bfs implements BFSWithDepthTracking. If reverse is set, the edges are
+// followed from their targets to their sources.
+func bfs[K graph.Ordered, T any](g graph.Interface[K, T], start K, reverse bool, visit func(K, int) bool) error {
+	// Graphs with dense vertex indices are traversed without an adjacency map.
+	if ix, ok := g.(graph.Indexed[K]); ok {
+		return bfsIndexed(ix, start, reverse, visit)
+	}
+
+	// List the neighbors of each vertex as the traversal reaches it.
+	list := iterate.Successors[K, T]
+	if reverse {
+		list = iterate.Predecessors[K, T]
+	}
+	neighbors, err := list(g)
 	if err != nil {
 		return fmt.Errorf("could not get adjacency map: %w", err)
 	}
 
 	// Ensure the starting vertex exists in the graph.
-	if _, ok := adjacencyMap[start]; !ok {
+	if ok, _ := g.HasVertex(start); !ok {
 		return fmt.Errorf("could not find start vertex with key %v", start)
 	}
 
@@ -115,25 +130,58 @@ func BFSWithDepthTracking[K graph.Ordered, T any](g graph.Interface[K, T], start
 			return nil
 		}
 
-		// Gather and sort neighbors for deterministic processing.
-		neighbors := make([]K, 0)
-		for neighbor := range adjacencyMap[current.vertex] {
+		// Neighbors are listed in ascending order, so the traversal is
+		// deterministic.
+		for neighbor := range neighbors(current.vertex) {
 			if !visited[neighbor] {
-				neighbors = append(neighbors, neighbor)
+				visited[neighbor] = true
+				q = append(q, queueNode{vertex: neighbor, depth: current.depth + 1})
 			}
 		}
-		// Sort neighbors to ensure deterministic order.
-		sort.Slice(neighbors, func(i, j int) bool {
-			return neighbors[i] < neighbors[j]
-		})
-
-		// Add sorted neighbors to the queue.
-		for _, neighbor := range neighbors {
-			visited[neighbor] = true
-			q = append(q, queueNode{vertex: neighbor, depth: current.depth + 1})
-		}
 	}
 
 	// Return nil to indicate that the traversal completed successfully.
 	return nil
 }
+
+// bfsIndexed performs BFSWithDepthTracking on a graph with dense vertex
+// indices. Successors are listed in index order, which is key order, so the
+// traversal visits the vertices in the same order.
+func bfsIndexed[K graph.Ordered](ix graph.Indexed[K], start K, reverse bool, visit func(K, int) bool) error {
+	neighbors := ix.Successors
+	if reverse {
+		neighbors = ix.Predecessors
+	}
+
+	first, ok := ix.VertexIndex(start)
+	if !ok {
+		return fmt.Errorf("could not find start vertex with key %v", start)
+	}
+
+	type queueNode struct {
+		vertex int // The index of the current vertex.
+		depth  int // The depth of the vertex from the starting point.
+	}
+
+	q := []queueNode{{vertex: first, depth: 0}}
+	visited := map[int]bool{first: true}
+
+	for len(q) > 0 {
+		current := q[0]
+		q = q[1:]
+
+		if stop := visit(ix.VertexKey(current.vertex), current.depth); stop {
+			return nil
+		}
+
+		targets, _ := neighbors(current.vertex)
+		for _, target := range targets {
+			if !visited[target] {
+				visited[target] = true
+				q = append(q, queueNode{vertex: target, depth: current.depth + 1})
+			}
+		}
+	}
+
+	return nil
+}
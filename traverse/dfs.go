@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/iterate"
 	"github.com/sixafter/graph/internal/queue"
 )
 
@@ -28,14 +29,24 @@ import (
 //
 // DFS is non-recursive and maintains a Stack instead.
 func DFS[K graph.Ordered, T any](g graph.Interface[K, T], start K, visit func(K) bool) error {
-	// Retrieve the adjacency map for the graph, which provides edges for each vertex.
-	adjacencyMap, err := g.AdjacencyMap()
+	return dfs(g, start, false, visit)
+}
+
+// dfs implements DFS. If reverse is set, the edges are followed from their
+// targets to their sources.
+func dfs[K graph.Ordered, T any](g graph.Interface[K, T], start K, reverse bool, visit func(K) bool) error {
+	// List the neighbors of each vertex as the traversal reaches it.
+	list := iterate.Successors[K, T]
+	if reverse {
+		list = iterate.Predecessors[K, T]
+	}
+	neighbors, err := list(g)
 	if err != nil {
 		return fmt.Errorf("could not get adjacency map: %w", err)
 	}
 
 	// Ensure that the starting vertex exists in the graph.
-	if _, ok := adjacencyMap[start]; !ok {
+	if ok, _ := g.HasVertex(start); !ok {
 		return fmt.Errorf("could not find start vertex with hash %v", start)
 	}
 
@@ -67,8 +78,8 @@ func DFS[K graph.Ordered, T any](g graph.Interface[K, T], start K, visit func(K)
 
 			// Enqueue all adjacent vertices of the current vertex onto the stack.
 			// These vertices will be processed later.
-			for adjacency := range adjacencyMap[current] {
-				stack.Push(adjacency)
+			for neighbor := range neighbors(current) {
+				stack.Push(neighbor)
 			}
 		}
 	}
@@ -0,0 +1,55 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package traverse
+
+import (
+	"github.com/sixafter/graph"
+)
+
+// ReverseBFS performs a breadth-first search that follows the edges of the graph backwards, from
+// their targets to their sources, starting from the given vertex. It visits the vertices that have
+// a path to the start vertex, such as the dependents of a package in a dependency graph. The visit
+// function and the order of the traversal are those of BFS.
+//
+// Graphs implementing graph.Iterable or graph.Indexed are walked through their predecessor
+// queries; other graphs are read through their predecessor map.
+//
+// Example:
+//
+//	_ = traverse.ReverseBFS(g, "lib", func(dependent string) bool {
+//		fmt.Println(dependent)
+//		return false
+//	})
+func ReverseBFS[K graph.Ordered, T any](g graph.Interface[K, T], start K, visit func(K) bool) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	return bfs(g, start, true, func(vertex K, _ int) bool {
+		return visit(vertex)
+	})
+}
+
+// ReverseBFSWithDepthTracking performs ReverseBFS, passing the number of edges between each vertex
+// and the start vertex to visit as BFSWithDepthTracking does.
+func ReverseBFSWithDepthTracking[K graph.Ordered, T any](g graph.Interface[K, T], start K, visit func(K, int) bool) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	return bfs(g, start, true, visit)
+}
+
+// ReverseDFS performs a depth-first search that follows the edges of the graph backwards, from
+// their targets to their sources, starting from the given vertex. The visit function is that of
+// DFS.
+func ReverseDFS[K graph.Ordered, T any](g graph.Interface[K, T], start K, visit func(K) bool) error {
+	if g == nil {
+		return graph.ErrNilInputGraph
+	}
+
+	return dfs(g, start, true, visit)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package traverse
+
+import (
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/csr"
+	"github.com/sixafter/graph/simple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseTraversals(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	// app -> lib -> core, tool -> core, core -> base
+	g, err := simple.New(graph.StringHash, graph.Directed())
+	is.NoError(err)
+	for _, v := range []string{"app", "lib", "core", "tool", "base"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("app", "lib"))
+	is.NoError(g.AddEdgeWithOptions("lib", "core"))
+	is.NoError(g.AddEdgeWithOptions("tool", "core"))
+	is.NoError(g.AddEdgeWithOptions("core", "base"))
+
+	frozen, err := csr.Freeze(g)
+	is.NoError(err)
+
+	for _, h := range []graph.Interface[string, string]{g, frozen} {
+		var order []string
+		depths := map[string]int{}
+		is.NoError(ReverseBFSWithDepthTracking(h, "core", func(v string, depth int) bool {
+			order = append(order, v)
+			depths[v] = depth
+			return false
+		}))
+		is.Equal([]string{"core", "lib", "tool", "app"}, order)
+		is.Equal(2, depths["app"])
+
+		var visited []string
+		is.NoError(ReverseDFS(h, "core", func(v string) bool {
+			visited = append(visited, v)
+			return false
+		}))
+		is.ElementsMatch([]string{"core", "lib", "tool", "app"}, visited)
+
+		// The traversal can stop early.
+		count := 0
+		is.NoError(ReverseBFS(h, "base", func(string) bool {
+			count++
+			return count == 2
+		}))
+		is.Equal(2, count)
+	}
+
+	is.Error(ReverseBFS(g, "missing", func(string) bool { return false }))
+	is.ErrorIs(ReverseDFS[string, string](nil, "core", func(string) bool { return false }), graph.ErrNilInputGraph)
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package views
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
+	"github.com/sixafter/graph/internal/iterate"
+	"github.com/sixafter/graph/internal/stream"
+)
+
+// OutNeighbors returns the successors of the vertex. See graph.Incidence.
+func (v *view[K, T]) OutNeighbors(hash K) ([]graph.Vertex[K, T], error) {
+	edges, err := v.OutEdges(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.neighborVertices(edges, graph.Edge[K].Target)
+}
+
+// InNeighbors returns the predecessors of the vertex. See graph.Incidence.
+func (v *view[K, T]) InNeighbors(hash K) ([]graph.Vertex[K, T], error) {
+	edges, err := v.InEdges(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.neighborVertices(edges, graph.Edge[K].Source)
+}
+
+// OutEdges returns the edges leaving the vertex. See graph.Incidence.
+func (v *view[K, T]) OutEdges(hash K) ([]graph.Edge[K], error) {
+	return incidentEdges(v.out(hash))
+}
+
+// InEdges returns the edges entering the vertex. See graph.Incidence.
+func (v *view[K, T]) InEdges(hash K) ([]graph.Edge[K], error) {
+	return incidentEdges(v.in(hash))
+}
+
+// vertex returns the vertex of the underlying graph with the given key and
+// whether it belongs to the view.
+func (v *view[K, T]) vertex(hash K) (graph.Vertex[K, T], bool, error) {
+	vertex, err := v.g.Vertex(hash)
+	if errors.Is(err, graph.ErrVertexNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", graph.ErrFailedToGetVertex, err)
+	}
+
+	ok, err := v.keeps(vertex)
+	return vertex, ok, err
+}
+
+// has reports whether the vertex with the given key belongs to the view.
+func (v *view[K, T]) has(hash K) (bool, error) {
+	if v.keep == nil {
+		return v.g.HasVertex(hash)
+	}
+
+	_, ok, err := v.vertex(hash)
+	return ok, err
+}
+
+// keeps reports whether a vertex of the underlying graph belongs to the view.
+func (v *view[K, T]) keeps(vertex graph.Vertex[K, T]) (bool, error) {
+	if v.keep == nil {
+		return true, nil
+	}
+
+	return v.keep(vertex)
+}
+
+// includes reports whether an edge of the underlying graph belongs to the
+// view.
+func (v *view[K, T]) includes(edge graph.Edge[K]) (bool, error) {
+	if v.include != nil && !v.include(edge) {
+		return false, nil
+	}
+	if !v.endpoints {
+		return true, nil
+	}
+
+	ok, err := v.has(edge.Source())
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return v.has(edge.Target())
+}
+
+// orient returns an edge of the underlying graph as the view orients it.
+func (v *view[K, T]) orient(edge graph.Edge[K]) graph.Edge[K] {
+	if v.reversed {
+		return edgeutil.Reverse(edge)
+	}
+	return edge
+}
+
+// walkEdges passes the edges of the view to yield until it returns false.
+func (v *view[K, T]) walkEdges(yield func(graph.Edge[K]) bool) error {
+	edges, err := iterate.Edges(v.g)
+	if err != nil {
+		return err
+	}
+
+	for edge := range edges {
+		ok, err := v.includes(edge)
+		if err != nil {
+			return err
+		}
+		if ok && !yield(v.orient(edge)) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// pageEdges returns up to limit edges of the view following after, ordered
+// as edgeutil.Compare orders them. A nil after starts at the first edge.
+func (v *view[K, T]) pageEdges(after *stream.EdgePosition[K], limit int) ([]graph.Edge[K], error) {
+	var err error
+	edges := func(yield func(graph.Edge[K]) bool) {
+		err = v.walkEdges(yield)
+	}
+
+	page := stream.SelectPage(edges, limit, func(edge graph.Edge[K]) bool {
+		return after == nil || after.Before(edge)
+	}, edgeutil.Compare[K])
+
+	return page, err
+}
+
+// edges returns the edges of the view.
+func (v *view[K, T]) edges() ([]graph.Edge[K], error) {
+	var edges []graph.Edge[K]
+	err := v.walkEdges(func(edge graph.Edge[K]) bool {
+		edges = append(edges, edge)
+		return true
+	})
+
+	return edges, err
+}
+
+// out returns the edges of the view leaving the vertex, ordered by target and
+// then by ID.
+func (v *view[K, T]) out(hash K) ([]graph.Edge[K], error) {
+	if v.reversed {
+		return v.incident(hash, v.baseIn)
+	}
+	return v.incident(hash, v.baseOut)
+}
+
+// in returns the edges of the view entering the vertex, ordered by source and
+// then by ID.
+func (v *view[K, T]) in(hash K) ([]graph.Edge[K], error) {
+	if v.reversed {
+		return v.incident(hash, v.baseOut)
+	}
+	return v.incident(hash, v.baseIn)
+}
+
+// incident returns the edges that list returns for the vertex in the
+// underlying graph that belong to the view, as the view orients them.
+func (v *view[K, T]) incident(hash K, list func(K) ([]graph.Edge[K], error)) ([]graph.Edge[K], error) {
+	ok, err := v.has(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
+	}
+
+	edges, err := list(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]graph.Edge[K], 0, len(edges))
+	for _, edge := range edges {
+		ok, err := v.includes(edge)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			selected = append(selected, v.orient(edge))
+		}
+	}
+
+	return selected, nil
+}
+
+// baseOut returns the edges of the underlying graph leaving the vertex,
+// ordered by target and then by ID.
+func (v *view[K, T]) baseOut(hash K) ([]graph.Edge[K], error) {
+	if inc, ok := v.g.(graph.Incidence[K, T]); ok {
+		return inc.OutEdges(hash)
+	}
+	return v.scan(hash, true)
+}
+
+// baseIn returns the edges of the underlying graph entering the vertex,
+// ordered by source and then by ID.
+func (v *view[K, T]) baseIn(hash K) ([]graph.Edge[K], error) {
+	if inc, ok := v.g.(graph.Incidence[K, T]); ok {
+		return inc.InEdges(hash)
+	}
+	return v.scan(hash, false)
+}
+
+// scan finds the edges of the vertex in the edge list of an underlying graph
+// that does not implement graph.Incidence. The edges of an undirected graph
+// are oriented to leave the vertex if out is set, and to enter it otherwise.
+func (v *view[K, T]) scan(hash K, out bool) ([]graph.Edge[K], error) {
+	ok, err := v.g.HasVertex(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
+	}
+
+	all, err := v.g.Edges()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	directed := v.g.Traits().IsDirected
+	var edges []graph.Edge[K]
+	for _, edge := range all {
+		if out && edge.Source() == hash || !out && edge.Target() == hash {
+			edges = append(edges, edge)
+			continue
+		}
+
+		// Edges lists an edge of an undirected graph in one direction only.
+		if !directed && (out && edge.Target() == hash || !out && edge.Source() == hash) {
+			edges = append(edges, edgeutil.Reverse(edge))
+		}
+	}
+
+	other := graph.Edge[K].Target
+	if !out {
+		other = graph.Edge[K].Source
+	}
+	slices.SortStableFunc(edges, func(a, b graph.Edge[K]) int {
+		return cmp.Or(cmp.Compare(other(a), other(b)), cmp.Compare(edgeutil.ID(a), edgeutil.ID(b)))
+	})
+
+	return edges, nil
+}
+
+// neighborVertices returns the distinct vertices that key selects from the
+// edges, which must be ordered by those keys.
+func (v *view[K, T]) neighborVertices(edges []graph.Edge[K], key func(graph.Edge[K]) K) ([]graph.Vertex[K, T], error) {
+	vertices := make([]graph.Vertex[K, T], 0, len(edges))
+	for i, edge := range edges {
+		// Parallel edges lead to the same neighbor.
+		if i > 0 && key(edges[i-1]) == key(edge) {
+			continue
+		}
+
+		vertex, err := v.g.Vertex(key(edge))
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get neighbor vertex", graph.ErrFailedToGetVertex)
+		}
+		vertices = append(vertices, vertex)
+	}
+
+	return vertices, nil
+}
+
+// incidentEdges wraps the error of an incidence query for a missing vertex in
+// ErrVertexNotFound.
+func incidentEdges[K graph.Ordered](edges []graph.Edge[K], err error) ([]graph.Edge[K], error) {
+	if errors.Is(err, graph.ErrVertexNotFound) {
+		return nil, fmt.Errorf("%w: vertex not found", graph.ErrVertexNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetEdges, err)
+	}
+
+	return edges, nil
+}
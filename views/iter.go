@@ -0,0 +1,122 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package views
+
+import (
+	"iter"
+
+	"github.com/sixafter/graph"
+)
+
+// AllVertices returns the keys and values of the vertices in ascending order
+// of their keys. See graph.Iterable.
+func (v *view[K, T]) AllVertices() iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		it, ok := v.g.(graph.Iterable[K, T])
+		if !ok {
+			vertices, err := v.Vertices()
+			if err != nil {
+				return
+			}
+			for _, vertex := range vertices {
+				if !yield(vertex.ID(), vertex.Value()) {
+					return
+				}
+			}
+			return
+		}
+
+		for key, value := range it.AllVertices() {
+			ok, err := v.has(key)
+			if err != nil {
+				return
+			}
+			if ok && !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// AllEdges returns the edges in the order of Edges. See graph.Iterable.
+func (v *view[K, T]) AllEdges() iter.Seq[graph.Edge[K]] {
+	return func(yield func(graph.Edge[K]) bool) {
+		_ = v.walkEdges(yield)
+	}
+}
+
+// Successors returns the keys of the targets of the edges leaving the vertex
+// in ascending order. See graph.Iterable.
+func (v *view[K, T]) Successors(hash K) iter.Seq[K] {
+	return neighbors(func() ([]graph.Edge[K], error) {
+		return v.out(hash)
+	}, graph.Edge[K].Target)
+}
+
+// Predecessors returns the keys of the sources of the edges entering the
+// vertex in ascending order. See graph.Iterable.
+func (v *view[K, T]) Predecessors(hash K) iter.Seq[K] {
+	return neighbors(func() ([]graph.Edge[K], error) {
+		return v.in(hash)
+	}, graph.Edge[K].Source)
+}
+
+// IncidentEdges returns the edges leaving the vertex followed by the edges
+// entering it. The edges of an undirected view have the vertex as their
+// source. See graph.Iterable.
+func (v *view[K, T]) IncidentEdges(hash K) iter.Seq[graph.Edge[K]] {
+	return func(yield func(graph.Edge[K]) bool) {
+		out, err := v.out(hash)
+		if err != nil {
+			return
+		}
+		for _, edge := range out {
+			if !yield(edge) {
+				return
+			}
+		}
+
+		// Every edge of an undirected view leaves each of its vertices.
+		if !v.traits.IsDirected {
+			return
+		}
+
+		in, err := v.in(hash)
+		if err != nil {
+			return
+		}
+		for _, edge := range in {
+			// Self-loops have been returned with the edges leaving the vertex.
+			if edge.Source() == hash {
+				continue
+			}
+			if !yield(edge) {
+				return
+			}
+		}
+	}
+}
+
+// neighbors returns a sequence of the distinct keys that key selects from the
+// edges returned by list, which must be ordered by those keys.
+func neighbors[K graph.Ordered](list func() ([]graph.Edge[K], error), key func(graph.Edge[K]) K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		edges, err := list()
+		if err != nil {
+			return
+		}
+
+		for i, edge := range edges {
+			// Parallel edges lead to the same neighbor.
+			if i > 0 && key(edges[i-1]) == key(edge) {
+				continue
+			}
+			if !yield(key(edge)) {
+				return
+			}
+		}
+	}
+}
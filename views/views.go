@@ -0,0 +1,553 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package views
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/internal/edgeutil"
+	"github.com/sixafter/graph/internal/iterate"
+	"github.com/sixafter/graph/internal/stream"
+	"github.com/sixafter/graph/simple"
+)
+
+// view is a read-only graph that selects vertices and edges of an underlying
+// graph and may reverse its edges. Nothing is copied: every query is answered
+// from the underlying graph when it is made, so the view reflects later
+// changes to that graph.
+//
+// A vertex of the underlying graph belongs to the view if keep accepts it. An
+// edge belongs to the view if include accepts it and, when endpoints is set,
+// both of its vertices belong to the view. Edges are passed to keep and
+// include as the underlying graph orients them; the view returns them
+// reversed if reversed is set.
+//
+// The view implements graph.Interface, graph.Iterable and graph.Incidence.
+// All methods that modify the graph return graph.ErrImmutableGraph; Clone
+// returns a mutable copy of the view.
+type view[K graph.Ordered, T any] struct {
+	g      graph.Interface[K, T]
+	traits *graph.Traits
+
+	// keep reports whether a vertex belongs to the view. A nil keep accepts
+	// every vertex.
+	keep func(graph.Vertex[K, T]) (bool, error)
+
+	// include reports whether an edge belongs to the view. A nil include
+	// accepts every edge.
+	include func(graph.Edge[K]) bool
+
+	// endpoints is set if the vertices of an edge must belong to the view for
+	// the edge to belong to it.
+	endpoints bool
+
+	// reversed is set if the edges of the view run opposite to the edges of
+	// the underlying graph.
+	reversed bool
+}
+
+// InducedSubgraph returns a read-only view of the subgraph of g induced by
+// keys: the vertices of g whose keys are given, and every edge of g between
+// two of them. Keys that are not vertices of g are ignored.
+//
+// Example:
+//
+//	sub, err := views.InducedSubgraph(g, []string{"A", "B", "C"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	order, err := topology.TopologicalSort(sub)
+func InducedSubgraph[K graph.Ordered, T any](g graph.Interface[K, T], keys []K) (graph.Interface[K, T], error) {
+	if g == nil {
+		return nil, graph.ErrNilInputGraph
+	}
+
+	set := make(map[K]struct{}, len(keys))
+	for _, key := range keys {
+		set[key] = struct{}{}
+	}
+
+	return &view[K, T]{
+		g:      g,
+		traits: g.Traits().Clone(),
+		keep: func(vertex graph.Vertex[K, T]) (bool, error) {
+			_, ok := set[vertex.ID()]
+			return ok, nil
+		},
+		endpoints: true,
+	}, nil
+}
+
+// EdgeSubgraph returns a read-only view of the subgraph of g induced by the
+// edges that pred accepts: those edges, and the vertices of g they connect.
+// Vertices without an accepted edge are not part of the view. The edges of
+// an undirected graph may be passed to pred in either direction.
+//
+// Example:
+//
+//	heavy, err := views.EdgeSubgraph(g, func(e graph.Edge[string]) bool {
+//		return e.Properties().Weight() >= 10
+//	})
+func EdgeSubgraph[K graph.Ordered, T any](g graph.Interface[K, T], pred func(graph.Edge[K]) bool) (graph.Interface[K, T], error) {
+	if g == nil {
+		return nil, graph.ErrNilInputGraph
+	}
+
+	v := &view[K, T]{
+		g:       g,
+		traits:  g.Traits().Clone(),
+		include: pred,
+	}
+
+	// A vertex belongs to the view if one of its edges does.
+	v.keep = func(vertex graph.Vertex[K, T]) (bool, error) {
+		out, err := v.baseOut(vertex.ID())
+		if err != nil {
+			return false, err
+		}
+		if slices.ContainsFunc(out, pred) {
+			return true, nil
+		}
+
+		in, err := v.baseIn(vertex.ID())
+		if err != nil {
+			return false, err
+		}
+		return slices.ContainsFunc(in, pred), nil
+	}
+
+	return v, nil
+}
+
+// Reverse returns a read-only view of g with the direction of every edge
+// reversed: the view has an edge from B to A for each edge of g from A to B.
+// The reverse of an undirected graph has the same edges as the graph.
+//
+// Example:
+//
+//	reversed, err := views.Reverse(g)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	// The vertices from which "D" can be reached.
+//	err = traverse.BFS(reversed, "D", visit)
+func Reverse[K graph.Ordered, T any](g graph.Interface[K, T]) (graph.Interface[K, T], error) {
+	if g == nil {
+		return nil, graph.ErrNilInputGraph
+	}
+
+	return &view[K, T]{
+		g:        g,
+		traits:   g.Traits().Clone(),
+		reversed: g.Traits().IsDirected,
+	}, nil
+}
+
+// Filter returns a read-only view of g with the vertices that vertexPred
+// accepts and the edges between them that edgePred accepts. A nil predicate
+// accepts everything. The edges of an undirected graph may be passed to
+// edgePred in either direction.
+//
+// Example:
+//
+//	active, err := views.Filter(g,
+//		func(v graph.Vertex[string, string]) bool {
+//			return v.Properties().Items()["active"] == true
+//		},
+//		func(e graph.Edge[string]) bool {
+//			return e.Properties().Weight() > 0
+//		},
+//	)
+func Filter[K graph.Ordered, T any](g graph.Interface[K, T], vertexPred func(graph.Vertex[K, T]) bool, edgePred func(graph.Edge[K]) bool) (graph.Interface[K, T], error) {
+	if g == nil {
+		return nil, graph.ErrNilInputGraph
+	}
+
+	v := &view[K, T]{
+		g:       g,
+		traits:  g.Traits().Clone(),
+		include: edgePred,
+	}
+
+	if vertexPred != nil {
+		v.keep = func(vertex graph.Vertex[K, T]) (bool, error) {
+			return vertexPred(vertex), nil
+		}
+		v.endpoints = true
+	}
+
+	return v, nil
+}
+
+func (v *view[K, T]) AddVertex(_ graph.Vertex[K, T]) error {
+	return graph.ErrImmutableGraph
+}
+
+func (v *view[K, T]) AddVertexWithOptions(_ T, _ ...graph.VertexOption) error {
+	return graph.ErrImmutableGraph
+}
+
+func (v *view[K, T]) AddVerticesFrom(_ graph.Interface[K, T]) error {
+	return graph.ErrImmutableGraph
+}
+
+func (v *view[K, T]) Vertex(hash K) (graph.Vertex[K, T], error) {
+	vertex, ok, err := v.vertex(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, graph.ErrVertexNotFound
+	}
+
+	return vertex, nil
+}
+
+func (v *view[K, T]) SetVertexWithOptions(_ T, _ ...graph.VertexOption) error {
+	return graph.ErrImmutableGraph
+}
+
+func (v *view[K, T]) RemoveVertex(_ K) error {
+	return graph.ErrImmutableGraph
+}
+
+// Vertices returns the vertices of the view in ascending order of their keys.
+func (v *view[K, T]) Vertices() ([]graph.Vertex[K, T], error) {
+	all, err := v.g.Vertices()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+	}
+
+	vertices := make([]graph.Vertex[K, T], 0, len(all))
+	for _, vertex := range all {
+		ok, err := v.keeps(vertex)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+		}
+		if ok {
+			vertices = append(vertices, vertex)
+		}
+	}
+
+	slices.SortFunc(vertices, func(a, b graph.Vertex[K, T]) int {
+		return cmp.Compare(a.ID(), b.ID())
+	})
+
+	return vertices, nil
+}
+
+// StreamVerticesWithContext streams vertices from the view in paginated batches, in the order of
+// Vertices. The cursor holds the key of the last vertex sent, so a resumed stream continues with
+// the next key that belongs to the view at that time.
+func (v *view[K, T]) StreamVerticesWithContext(ctx context.Context, cursor graph.Cursor, limit int, ch chan<- []graph.Vertex[K, T]) (graph.Cursor, error) {
+	defer close(ch) // Ensure the channel is closed when the function returns
+
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than zero")
+	}
+
+	position, err := stream.ReadCursor[stream.VertexPosition[K]](cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := iterate.Vertices(v.g)
+	if err != nil {
+		return cursor, err
+	}
+
+	send := func(batch []graph.Vertex[K, T]) error {
+		select {
+		case <-ctx.Done(): // Handle cancellation
+			return ctx.Err()
+		case ch <- batch: // Send batch to channel
+		}
+
+		return stream.WriteCursor(cursor, stream.VertexPosition[K]{Key: batch[len(batch)-1].ID()})
+	}
+
+	batch := make([]graph.Vertex[K, T], 0, limit)
+	for key := range keys {
+		if position != nil && key <= position.Key {
+			continue
+		}
+
+		vertex, ok, err := v.vertex(key)
+		if err != nil {
+			return cursor, fmt.Errorf("%w: %v", graph.ErrFailedToListVertices, err)
+		}
+		if !ok {
+			continue
+		}
+
+		batch = append(batch, vertex)
+		if len(batch) == limit {
+			if err = send(batch); err != nil {
+				return cursor, err
+			}
+			batch = make([]graph.Vertex[K, T], 0, limit)
+		}
+	}
+
+	if len(batch) > 0 {
+		if err = send(batch); err != nil {
+			return cursor, err
+		}
+	}
+
+	return cursor, nil
+}
+
+func (v *view[K, T]) HasVertex(hash K) (bool, error) {
+	_, ok, err := v.vertex(hash)
+	return ok, err
+}
+
+func (v *view[K, T]) AddEdge(_ graph.Edge[K]) error {
+	return graph.ErrImmutableGraph
+}
+
+func (v *view[K, T]) AddEdgeWithOptions(_, _ K, _ ...graph.EdgeOption) error {
+	return graph.ErrImmutableGraph
+}
+
+func (v *view[K, T]) AddEdgesFrom(_ graph.Interface[K, T]) error {
+	return graph.ErrImmutableGraph
+}
+
+// Edge retrieves the edge between two vertices. Of several parallel edges,
+// the one with the lowest ID is returned.
+func (v *view[K, T]) Edge(source, target K) (graph.Edge[T], error) {
+	edge, err := v.find(source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceVertex, err := v.g.Vertex(source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetVertex, err)
+	}
+
+	targetVertex, err := v.g.Vertex(target)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetVertex, err)
+	}
+
+	return simple.NewEdge(sourceVertex.Value(), targetVertex.Value(), edge.Properties()), nil
+}
+
+// Edges returns the edges of the view. An edge of an undirected graph is
+// returned once.
+func (v *view[K, T]) Edges() ([]graph.Edge[K], error) {
+	edges, err := v.edges()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+	}
+
+	return edges, nil
+}
+
+// StreamEdgesWithContext streams edges from the view in paginated batches, ordered by source,
+// then by target, then by ID. The cursor holds the last edge sent, so a resumed stream continues
+// with the next edge that belongs to the view at that time. Each batch is selected in a pass over
+// the edges of the underlying graph that keeps no more than limit of them.
+func (v *view[K, T]) StreamEdgesWithContext(ctx context.Context, cursor graph.Cursor, limit int, ch chan<- graph.Edge[K]) (graph.Cursor, error) {
+	defer close(ch) // Ensure the channel is closed when the function returns
+
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than zero")
+	}
+
+	position, err := stream.ReadCursor[stream.EdgePosition[K]](cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		edges, err := v.pageEdges(position, limit)
+		if err != nil {
+			return cursor, fmt.Errorf("%w: %v", graph.ErrFailedToListEdges, err)
+		}
+
+		for _, edge := range edges {
+			select {
+			case <-ctx.Done(): // Handle cancellation
+				return cursor, ctx.Err()
+			case ch <- edge: // Send each edge to the channel
+			}
+
+			next := stream.EdgePositionOf(edge)
+			position = &next
+			if err = stream.WriteCursor(cursor, next); err != nil {
+				return cursor, err
+			}
+		}
+
+		if len(edges) < limit {
+			return cursor, nil
+		}
+	}
+}
+
+func (v *view[K, T]) SetEdgeWithOptions(_, _ K, _ ...graph.EdgeOption) error {
+	return graph.ErrImmutableGraph
+}
+
+func (v *view[K, T]) RemoveEdge(_, _ K) error {
+	return graph.ErrImmutableGraph
+}
+
+func (v *view[K, T]) HasEdge(source, target K) (bool, error) {
+	_, err := v.find(source, target)
+	if errors.Is(err, graph.ErrVertexNotFound) || errors.Is(err, graph.ErrEdgeNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// AdjacencyMap builds an adjacency map of the view. Of several parallel
+// edges, the one with the lowest weight is kept.
+func (v *view[K, T]) AdjacencyMap() (map[K]map[K]graph.Edge[K], error) {
+	m, err := v.toMap(v.out, graph.Edge[K].Target)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetAdjacencyMap, err)
+	}
+
+	return m, nil
+}
+
+// PredecessorMap builds a predecessor map of the view. Of several parallel
+// edges, the one with the lowest weight is kept.
+func (v *view[K, T]) PredecessorMap() (map[K]map[K]graph.Edge[K], error) {
+	m, err := v.toMap(v.in, graph.Edge[K].Source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToGetPredecessorMap, err)
+	}
+
+	return m, nil
+}
+
+// Clone returns a mutable copy of the view, backed by the default in-memory
+// store of the simple package.
+func (v *view[K, T]) Clone() (graph.Interface[K, T], error) {
+	clone, err := simple.NewLike[K, T](v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToCloneGraph, err)
+	}
+
+	if err = clone.AddVerticesFrom(v); err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToAddVertices, err)
+	}
+
+	if err = clone.AddEdgesFrom(v); err != nil {
+		return nil, fmt.Errorf("%w: %v", graph.ErrFailedToAddEdges, err)
+	}
+
+	return clone, nil
+}
+
+func (v *view[K, T]) Order() (int, error) {
+	vertices, err := v.Vertices()
+	return len(vertices), err
+}
+
+func (v *view[K, T]) Size() (int, error) {
+	edges, err := v.Edges()
+	return len(edges), err
+}
+
+func (v *view[K, T]) Hash() graph.Hash[K, T] {
+	return v.g.Hash()
+}
+
+func (v *view[K, T]) Traits() *graph.Traits {
+	return v.traits
+}
+
+func (v *view[K, T]) Neighbors(hash K) ([]graph.Vertex[K, T], error) {
+	return v.OutNeighbors(hash)
+}
+
+// Degree returns the number of edges incident to the vertex. In a directed
+// view it is the sum of the in- and out-degree.
+func (v *view[K, T]) Degree(hash K) (int, error) {
+	out, err := v.OutDegree(hash)
+	if err != nil || !v.traits.IsDirected {
+		return out, err
+	}
+
+	in, err := v.InDegree(hash)
+	return in + out, err
+}
+
+func (v *view[K, T]) InDegree(hash K) (int, error) {
+	edges, err := v.InEdges(hash)
+	return len(edges), err
+}
+
+func (v *view[K, T]) OutDegree(hash K) (int, error) {
+	edges, err := v.OutEdges(hash)
+	return len(edges), err
+}
+
+// find returns the first edge of the view from source to target.
+func (v *view[K, T]) find(source, target K) (graph.Edge[K], error) {
+	ok, err := v.has(target)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, graph.ErrVertexNotFound
+	}
+
+	edges, err := v.out(source)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, edge := range edges {
+		if edge.Target() == target {
+			return edge, nil
+		}
+	}
+
+	return nil, graph.ErrEdgeNotFound
+}
+
+// toMap builds an adjacency or predecessor map from the edges that list
+// returns for each vertex, keyed by the vertex that neighbor selects.
+func (v *view[K, T]) toMap(list func(K) ([]graph.Edge[K], error), neighbor func(graph.Edge[K]) K) (map[K]map[K]graph.Edge[K], error) {
+	vertices, err := v.Vertices()
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[K]map[K]graph.Edge[K], len(vertices))
+	for _, vertex := range vertices {
+		edges, err := list(vertex.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[K]graph.Edge[K], len(edges))
+		for _, edge := range edges {
+			if current, ok := row[neighbor(edge)]; !ok || edgeutil.Weight(edge) < edgeutil.Weight(current) {
+				row[neighbor(edge)] = edge
+			}
+		}
+		m[vertex.ID()] = row
+	}
+
+	return m, nil
+}
@@ -0,0 +1,443 @@
+// Copyright (c) 2024-2025 Six After, Inc
+//
+// This source code is licensed under the Apache 2.0 License found in the
+// LICENSE file in the root directory of this source tree.
+
+package views
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/sixafter/graph"
+	"github.com/sixafter/graph/csr"
+	"github.com/sixafter/graph/metrics"
+	"github.com/sixafter/graph/paths"
+	"github.com/sixafter/graph/simple"
+	"github.com/sixafter/graph/topology"
+	"github.com/stretchr/testify/assert"
+)
+
+func keys(vertices []graph.Vertex[string, string]) []string {
+	result := make([]string, len(vertices))
+	for i, vertex := range vertices {
+		result[i] = vertex.ID()
+	}
+	return result
+}
+
+func TestInducedSubgraph(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "D", simple.EdgeWeight(3)))
+	is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(4)))
+	is.NoError(g.AddEdgeWithOptions("D", "E", simple.EdgeWeight(5)))
+
+	sub, err := InducedSubgraph(g, []string{"D", "A", "B", "X"})
+	is.NoError(err)
+
+	vertices, err := sub.Vertices()
+	is.NoError(err)
+	is.Equal([]string{"A", "B", "D"}, keys(vertices))
+
+	size, _ := sub.Size()
+	is.Equal(2, size)
+	ok, _ := sub.HasEdge("A", "B")
+	is.True(ok)
+	ok, _ = sub.HasEdge("A", "C")
+	is.False(ok)
+	_, err = sub.Vertex("C")
+	is.ErrorIs(err, graph.ErrVertexNotFound)
+	_, err = sub.Neighbors("C")
+	is.ErrorIs(err, graph.ErrVertexNotFound)
+
+	edge, err := sub.Edge("B", "D")
+	is.NoError(err)
+	is.Equal(3.0, edge.Properties().Weight())
+
+	degree, _ := sub.Degree("B")
+	is.Equal(2, degree)
+	degree, _ = sub.OutDegree("A")
+	is.Equal(1, degree)
+
+	adjacencyMap, err := sub.AdjacencyMap()
+	is.NoError(err)
+	is.Len(adjacencyMap, 3)
+	is.Contains(adjacencyMap["A"], "B")
+	is.NotContains(adjacencyMap["A"], "C")
+
+	order, err := topology.TopologicalSort(sub)
+	is.NoError(err)
+	is.Equal([]string{"A", "B", "D"}, order)
+
+	// The view follows changes to the graph.
+	is.NoError(g.AddEdgeWithOptions("A", "D", simple.EdgeWeight(1)))
+	path, err := paths.DijkstraFrom(sub, "A", "D")
+	is.NoError(err)
+	is.Equal([]string{"A", "D"}, path)
+
+	_, err = InducedSubgraph[string, string](nil, nil)
+	is.ErrorIs(err, graph.ErrNilInputGraph)
+}
+
+func TestEdgeSubgraph(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "D", simple.EdgeWeight(3)))
+	is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(4)))
+	is.NoError(g.AddEdgeWithOptions("D", "E", simple.EdgeWeight(5)))
+
+	sub, err := EdgeSubgraph(g, func(e graph.Edge[string]) bool {
+		return e.Properties().Weight() >= 3
+	})
+	is.NoError(err)
+
+	// Only the vertices of the accepted edges are kept.
+	vertices, err := sub.Vertices()
+	is.NoError(err)
+	is.Equal([]string{"B", "C", "D", "E"}, keys(vertices))
+	ok, _ := sub.HasVertex("A")
+	is.False(ok)
+
+	edges, err := sub.Edges()
+	is.NoError(err)
+	is.Len(edges, 3)
+
+	neighbors, err := sub.(graph.Incidence[string, string]).InNeighbors("D")
+	is.NoError(err)
+	is.Equal([]string{"B", "C"}, keys(neighbors))
+
+	components, err := topology.TarjanFrom(sub)
+	is.NoError(err)
+	is.Len(components, 4)
+}
+
+func TestReverse(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "D", simple.EdgeWeight(3)))
+	is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(4)))
+	is.NoError(g.AddEdgeWithOptions("D", "E", simple.EdgeWeight(5)))
+
+	rev, err := Reverse(g)
+	is.NoError(err)
+
+	it := rev.(graph.Iterable[string, string])
+	is.Equal([]string{"B", "C"}, slices.Collect(it.Successors("D")))
+	is.Equal([]string{"E"}, slices.Collect(it.Predecessors("D")))
+	is.Len(slices.Collect(it.IncidentEdges("D")), 3)
+
+	// Edges keep their properties and IDs.
+	forward, _ := g.Edges()
+	backward, err := rev.Edges()
+	is.NoError(err)
+	is.Len(backward, len(forward))
+	for i, edge := range backward {
+		is.Equal(forward[i].Source(), edge.Target())
+		is.Equal(forward[i].Target(), edge.Source())
+		is.Equal(forward[i].Properties().Weight(), edge.Properties().Weight())
+		is.Equal(forward[i].(graph.IdentifiedEdge[string]).ID(), edge.(graph.IdentifiedEdge[string]).ID())
+	}
+
+	edge, err := rev.Edge("B", "A")
+	is.NoError(err)
+	is.Equal(1.0, edge.Properties().Weight())
+	_, err = rev.Edge("A", "B")
+	is.ErrorIs(err, graph.ErrEdgeNotFound)
+
+	order, err := topology.TopologicalSortDeterministic(rev, func(a, b string) bool {
+		return a < b
+	})
+	is.NoError(err)
+	is.Equal([]string{"E", "F", "D", "B", "C", "A"}, order)
+
+	path, err := paths.DijkstraFrom(rev, "E", "A")
+	is.NoError(err)
+	is.Equal([]string{"E", "D", "B", "A"}, path)
+
+	// Reversing an undirected graph changes nothing.
+	u, err := simple.New(graph.StringHash)
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		is.NoError(u.AddVertexWithOptions(v))
+	}
+	is.NoError(u.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+	is.NoError(u.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+	is.NoError(u.AddEdgeWithOptions("B", "D", simple.EdgeWeight(3)))
+	is.NoError(u.AddEdgeWithOptions("C", "D", simple.EdgeWeight(4)))
+	is.NoError(u.AddEdgeWithOptions("D", "E", simple.EdgeWeight(5)))
+	urev, err := Reverse(u)
+	is.NoError(err)
+	edges, _ := u.Edges()
+	reversed, err := urev.Edges()
+	is.NoError(err)
+	is.Equal(edges, reversed)
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "D", simple.EdgeWeight(3)))
+	is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(4)))
+	is.NoError(g.AddEdgeWithOptions("D", "E", simple.EdgeWeight(5)))
+
+	f, err := Filter(g,
+		func(v graph.Vertex[string, string]) bool {
+			return v.ID() != "C"
+		},
+		func(e graph.Edge[string]) bool {
+			return e.Properties().Weight() < 5
+		},
+	)
+	is.NoError(err)
+
+	order, _ := f.Order()
+	is.Equal(5, order)
+	size, _ := f.Size()
+	is.Equal(2, size)
+
+	predecessorMap, err := f.PredecessorMap()
+	is.NoError(err)
+	is.Equal([]string{"B"}, slices.Sorted(maps.Keys(predecessorMap["D"])))
+	is.Empty(predecessorMap["E"])
+
+	ranks, err := metrics.PageRank(f, 0.85, 100, 1e-6)
+	is.NoError(err)
+	is.Len(ranks, 5)
+
+	// A filter without predicates shows the whole graph.
+	all, err := Filter(g, nil, nil)
+	is.NoError(err)
+	size, _ = all.Size()
+	is.Equal(5, size)
+}
+
+func TestView_Undirected(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := simple.New(graph.StringHash)
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "D", simple.EdgeWeight(3)))
+	is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(4)))
+	is.NoError(g.AddEdgeWithOptions("D", "E", simple.EdgeWeight(5)))
+	is.NoError(g.AddEdgeWithOptions("B", "C"))
+	sub, err := InducedSubgraph(g, []string{"A", "B", "C"})
+	is.NoError(err)
+
+	neighbors, err := sub.Neighbors("B")
+	is.NoError(err)
+	is.Equal([]string{"A", "C"}, keys(neighbors))
+	degree, _ := sub.Degree("A")
+	is.Equal(2, degree)
+
+	coefficients, err := metrics.ClusteringCoefficient(sub)
+	is.NoError(err)
+	is.InDelta(1.0, coefficients["A"], 1e-9)
+
+	diameter, err := metrics.Diameter(sub)
+	is.NoError(err)
+	is.Equal(1, diameter)
+}
+
+func TestView_Frozen(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := simple.New(graph.StringHash, graph.Directed(), graph.Weighted())
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "D", simple.EdgeWeight(3)))
+	is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(4)))
+	is.NoError(g.AddEdgeWithOptions("D", "E", simple.EdgeWeight(5)))
+
+	// Graphs without an edge index are read from their edge list.
+	frozen, err := csr.Freeze(g)
+	is.NoError(err)
+	rev, err := Reverse[string, string](frozen)
+	is.NoError(err)
+
+	neighbors, err := rev.Neighbors("D")
+	is.NoError(err)
+	is.Equal([]string{"B", "C"}, keys(neighbors))
+	path, err := paths.DijkstraFrom(rev, "E", "A")
+	is.NoError(err)
+	is.Equal([]string{"E", "D", "B", "A"}, path)
+
+	u, err := simple.New(graph.StringHash)
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		is.NoError(u.AddVertexWithOptions(v))
+	}
+	is.NoError(u.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+	is.NoError(u.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+	is.NoError(u.AddEdgeWithOptions("B", "D", simple.EdgeWeight(3)))
+	is.NoError(u.AddEdgeWithOptions("C", "D", simple.EdgeWeight(4)))
+	is.NoError(u.AddEdgeWithOptions("D", "E", simple.EdgeWeight(5)))
+	ufrozen, err := csr.Freeze(u)
+	is.NoError(err)
+	sub, err := InducedSubgraph[string, string](ufrozen, []string{"A", "B", "D"})
+	is.NoError(err)
+	neighbors, err = sub.Neighbors("B")
+	is.NoError(err)
+	is.Equal([]string{"A", "D"}, keys(neighbors))
+	edges, err := sub.(graph.Incidence[string, string]).InEdges("B")
+	is.NoError(err)
+	for _, edge := range edges {
+		is.Equal("B", edge.Target())
+	}
+}
+
+func TestView_Immutable(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := simple.New(graph.StringHash, graph.Directed())
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "D", simple.EdgeWeight(3)))
+	is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(4)))
+	is.NoError(g.AddEdgeWithOptions("D", "E", simple.EdgeWeight(5)))
+
+	sub, err := InducedSubgraph(g, []string{"A", "B"})
+	is.NoError(err)
+
+	is.ErrorIs(sub.AddVertexWithOptions("G"), graph.ErrImmutableGraph)
+	is.ErrorIs(sub.AddEdgeWithOptions("A", "B"), graph.ErrImmutableGraph)
+	is.ErrorIs(sub.RemoveEdge("A", "B"), graph.ErrImmutableGraph)
+	is.ErrorIs(sub.RemoveVertex("A"), graph.ErrImmutableGraph)
+
+	clone, err := sub.Clone()
+	is.NoError(err)
+	is.NoError(clone.AddVertexWithOptions("G"))
+	order, _ := clone.Order()
+	is.Equal(3, order)
+	size, _ := clone.Size()
+	is.Equal(1, size)
+	order, _ = sub.Order()
+	is.Equal(2, order)
+}
+
+func TestView_Stream(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	g, err := simple.New(graph.StringHash, graph.Directed())
+	is.NoError(err)
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		is.NoError(g.AddVertexWithOptions(v))
+	}
+	is.NoError(g.AddEdgeWithOptions("A", "B", simple.EdgeWeight(1)))
+	is.NoError(g.AddEdgeWithOptions("A", "C", simple.EdgeWeight(2)))
+	is.NoError(g.AddEdgeWithOptions("B", "D", simple.EdgeWeight(3)))
+	is.NoError(g.AddEdgeWithOptions("C", "D", simple.EdgeWeight(4)))
+	is.NoError(g.AddEdgeWithOptions("D", "E", simple.EdgeWeight(5)))
+
+	rev, err := Reverse(g)
+	is.NoError(err)
+
+	// The streams are buffered enough to run to the end, closing their
+	// channels, before they are read.
+	streamEdges := func(g graph.Interface[string, string], cursor graph.Cursor, limit int) []string {
+		ch := make(chan graph.Edge[string], 16)
+		_, err := g.StreamEdgesWithContext(context.Background(), cursor, limit, ch)
+		is.NoError(err)
+
+		var pairs []string
+		for edge := range ch {
+			pairs = append(pairs, edge.Source()+">"+edge.Target())
+		}
+		return pairs
+	}
+	streamKeys := func(g graph.Interface[string, string], cursor graph.Cursor, limit int) []string {
+		ch := make(chan []graph.Vertex[string, string], 16)
+		_, err := g.StreamVerticesWithContext(context.Background(), cursor, limit, ch)
+		is.NoError(err)
+
+		var result []string
+		for batch := range ch {
+			is.LessOrEqual(len(batch), limit)
+			result = append(result, keys(batch)...)
+		}
+		return result
+	}
+
+	cursor := simple.EmptyCursor()
+	is.Equal([]string{"B>A", "C>A", "D>B", "D>C", "E>D"}, streamEdges(rev, cursor, 2))
+
+	// A resumed stream continues after the last edge sent, regardless of the
+	// edges added before it in the meantime.
+	is.NoError(g.AddEdgeWithOptions("A", "D"))
+	is.NoError(g.AddEdgeWithOptions("F", "E"))
+	is.Equal([]string{"E>F"}, streamEdges(rev, cursor, 2))
+
+	filtered, err := Filter(g, func(v graph.Vertex[string, string]) bool {
+		return v.ID() != "C"
+	}, nil)
+	is.NoError(err)
+
+	cursor = simple.EmptyCursor()
+	is.Equal([]string{"A", "B", "D", "E", "F"}, streamKeys(filtered, cursor, 2))
+	is.Equal([]string{"A>B", "A>D", "B>D", "D>E", "F>E"}, streamEdges(filtered, simple.EmptyCursor(), 3))
+
+	is.NoError(g.AddVertexWithOptions("Bb"))
+	is.NoError(g.AddVertexWithOptions("G"))
+	is.Equal([]string{"G"}, streamKeys(filtered, cursor, 2))
+
+	// The cursor can be saved and restored.
+	restored := simple.EmptyCursor()
+	is.NoError(restored.SetState(cursor.State()))
+	is.NoError(g.AddVertexWithOptions("H"))
+	is.Equal([]string{"H"}, streamKeys(filtered, restored, 2))
+
+	is.NoError(restored.SetState([]byte("not a position")))
+	_, err = filtered.StreamVerticesWithContext(context.Background(), restored, 1, make(chan []graph.Vertex[string, string]))
+	is.Error(err)
+}